@@ -1,19 +1,21 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"os/signal"
 	"strconv"
 	"strings"
 	"syscall"
-	"time"
 
 	"github.com/rkristelijn/glab-tui/cmd/tui"
+	"github.com/rkristelijn/glab-tui/internal/analysis"
 	"github.com/rkristelijn/glab-tui/internal/config"
 	"github.com/rkristelijn/glab-tui/internal/core"
 	"github.com/rkristelijn/glab-tui/internal/gitlab"
+	"github.com/rkristelijn/glab-tui/internal/joblog"
 )
 
 func Run(args []string) {
@@ -26,7 +28,19 @@ func Run(args []string) {
 
 	switch command {
 	case "pipelines", "p":
-		listPipelines()
+		limit := 0
+		for i := 1; i < len(args); i++ {
+			if (args[i] == "--limit" || args[i] == "-n") && i+1 < len(args) {
+				n, err := strconv.Atoi(args[i+1])
+				if err != nil || n < 0 {
+					fmt.Println("Usage: glab-tui pipelines [--limit N]")
+					os.Exit(1)
+				}
+				limit = n
+				i++
+			}
+		}
+		listPipelines(limit)
 	case "job", "j":
 		if len(args) < 2 {
 			fmt.Println("Usage: glab-tui job <job-id>")
@@ -35,36 +49,191 @@ func Run(args []string) {
 		checkJob(args[1])
 	case "logs", "l":
 		if len(args) < 2 {
-			fmt.Println("Usage: glab-tui logs [--follow] <job-id>")
+			fmt.Println("Usage: glab-tui logs [--follow | --errors] [--demo] <job-id>")
 			fmt.Println("  --follow, -f    Stream logs in real-time")
+			fmt.Println("  --errors, -e    Show only the lines reporting failures")
 			os.Exit(1)
 		}
 
 		// Parse flags and job ID
-		var follow bool
+		var follow, errorsOnly, demo bool
 		var jobIDStr string
 
 		for i := 1; i < len(args); i++ {
 			arg := args[i]
-			if arg == "--follow" || arg == "-f" {
+			switch {
+			case arg == "--follow" || arg == "-f":
 				follow = true
-			} else if !strings.HasPrefix(arg, "-") {
+			case arg == "--errors" || arg == "-e":
+				errorsOnly = true
+			case arg == "--demo":
+				demo = true
+			case !strings.HasPrefix(arg, "-") && jobIDStr == "":
 				jobIDStr = arg
-				break
 			}
 		}
 
 		if jobIDStr == "" {
 			fmt.Println("Error: job ID required")
-			fmt.Println("Usage: glab-tui logs [--follow] <job-id>")
+			fmt.Println("Usage: glab-tui logs [--follow | --errors] [--demo] <job-id>")
 			os.Exit(1)
 		}
 
-		if follow {
-			streamJobLogs(jobIDStr)
-		} else {
-			showJobLogs(jobIDStr)
+		switch {
+		case follow:
+			streamJobLogs(jobIDStr, demo)
+		case errorsOnly:
+			showJobErrors(jobIDStr, demo)
+		default:
+			showJobLogs(jobIDStr, demo)
+		}
+	case "dashboard", "dash":
+		var list, demo bool
+		for _, arg := range args[1:] {
+			switch arg {
+			case "--list":
+				list = true
+			case "--demo":
+				demo = true
+			}
+		}
+		switch {
+		case list:
+			listDashboard(demo)
+		case demo:
+			if err := tui.StartDashboardWithMockData(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		default:
+			if err := tui.StartDashboard(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	case "mrs", "mr":
+		demo := len(args) > 1 && args[1] == "--demo"
+		listMergeRequests(demo)
+	case "run":
+		var ref string
+		var variables []core.JobVariable
+		for i := 1; i < len(args); i++ {
+			switch {
+			case args[i] == "--ref" && i+1 < len(args):
+				ref = args[i+1]
+				i++
+			case args[i] == "--var" && i+1 < len(args):
+				variable, err := core.ParseJobVariable(args[i+1])
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				variables = append(variables, variable)
+				i++
+			default:
+				fmt.Println("Usage: glab-tui run [--ref REF] [--var KEY=VAL ...]")
+				os.Exit(1)
+			}
+		}
+		runPipeline(ref, variables)
+	case "tests", "t":
+		var pipelineIDStr string
+		demo := false
+		for _, arg := range args[1:] {
+			if arg == "--demo" {
+				demo = true
+			} else if !strings.HasPrefix(arg, "-") {
+				pipelineIDStr = arg
+			}
+		}
+		if pipelineIDStr == "" {
+			fmt.Println("Usage: glab-tui tests <pipeline-id> [--demo]")
+			os.Exit(1)
+		}
+		showTestReport(pipelineIDStr, demo)
+	case "flaky":
+		usage := func() {
+			fmt.Println("Usage: glab-tui flaky [--ref REF] [--last N] [--demo]")
+			os.Exit(1)
+		}
+		var ref string
+		last := analysis.DefaultLast
+		demo := false
+		for i := 1; i < len(args); i++ {
+			switch {
+			case args[i] == "--ref" && i+1 < len(args):
+				ref = args[i+1]
+				i++
+			case (args[i] == "--last" || args[i] == "-n") && i+1 < len(args):
+				n, err := strconv.Atoi(args[i+1])
+				if err != nil || n <= 0 {
+					usage()
+				}
+				last = n
+				i++
+			case args[i] == "--demo":
+				demo = true
+			default:
+				usage()
+			}
+		}
+		showFlaky(ref, last, demo)
+	case "stats":
+		usage := func() {
+			fmt.Println("Usage: glab-tui stats [--ref REF] [--last N] [--demo]")
+			os.Exit(1)
+		}
+		var ref string
+		last := analysis.DefaultLast
+		demo := false
+		for i := 1; i < len(args); i++ {
+			switch {
+			case args[i] == "--ref" && i+1 < len(args):
+				ref = args[i+1]
+				i++
+			case (args[i] == "--last" || args[i] == "-n") && i+1 < len(args):
+				n, err := strconv.Atoi(args[i+1])
+				if err != nil || n <= 0 {
+					usage()
+				}
+				last = n
+				i++
+			case args[i] == "--demo":
+				demo = true
+			default:
+				usage()
+			}
+		}
+		showDurations(ref, last, demo)
+	case "artifacts", "a":
+		usage := func() {
+			fmt.Println("Usage: glab-tui artifacts <job-id> [--path P] [-o DIR]")
+			fmt.Println("  Without --path or -o the files are listed, not extracted")
+			os.Exit(1)
+		}
+		var jobIDStr, path string
+		dir := "."
+		extract := false
+		for i := 1; i < len(args); i++ {
+			switch {
+			case args[i] == "--path" && i+1 < len(args):
+				path = args[i+1]
+				extract = true
+				i++
+			case (args[i] == "-o" || args[i] == "--output") && i+1 < len(args):
+				dir = args[i+1]
+				extract = true
+				i++
+			case !strings.HasPrefix(args[i], "-") && jobIDStr == "":
+				jobIDStr = args[i]
+			default:
+				usage()
+			}
+		}
+		if jobIDStr == "" {
+			usage()
 		}
+		jobArtifacts(jobIDStr, extract, path, dir)
 	case "test-real":
 		testRealGitLab()
 	case "help", "h", "--help":
@@ -90,26 +259,9 @@ func Run(args []string) {
 	}
 }
 
-func listPipelines() {
-	// First try to get current project from git context
-	projectPath, err := getCurrentProjectPath()
-	if err != nil {
-		fmt.Printf("Warning: Could not detect GitLab project: %v\n", err)
-		// Fall back to mock data
-		pipelines := core.GetMockPipelines()
-		displayPipelines(pipelines, "Mock Data - No Project Context")
-		return
-	}
-
-	// Try using glab command directly first (most reliable)
-	if pipelines, err := getProjectPipelinesViaGlab(projectPath); err == nil {
-		displayPipelines(pipelines, fmt.Sprintf("Real Data via glab - %s", projectPath))
-		return
-	} else {
-		fmt.Printf("glab command failed: %v\n", err)
-	}
-
-	// Fallback to direct API calls
+// listPipelines prints the project's pipelines. A limit of 0 uses
+// MAX_PIPELINES_PER_PROJECT.
+func listPipelines(limit int) {
 	cfg, err := config.Load()
 	if err != nil {
 		fmt.Printf("Failed to load config: %v\n", err)
@@ -119,172 +271,167 @@ func listPipelines() {
 		return
 	}
 
-	if cfg.GitLab.Token == "" || cfg.GitLab.Token == "your-token-here" {
-		fmt.Println("❌ No GitLab token found!")
-		fmt.Println("Please set GITLAB_TOKEN in your .env file")
-		// Fall back to mock data
-		pipelines := core.GetMockPipelines()
-		displayPipelines(pipelines, "Mock Data - No Token")
-		return
-	}
-
-	client, err := gitlab.NewClient(cfg)
+	service, err := gitlab.NewService(cfg, "")
 	if err != nil {
-		fmt.Printf("Failed to create GitLab client: %v\n", err)
+		fmt.Printf("Warning: Could not connect to a GitLab project: %v\n", err)
 		// Fall back to mock data
 		pipelines := core.GetMockPipelines()
-		displayPipelines(pipelines, "Mock Data - Client Error")
-		return
-	}
-
-	// Get project details first
-	project, err := client.GetProjectByPath(projectPath)
-	if err != nil {
-		fmt.Printf("Failed to get project %s: %v\n", projectPath, err)
-		// Fall back to mock data
-		pipelines := core.GetMockPipelines()
-		displayPipelines(pipelines, "Mock Data - Project Not Found")
+		displayPipelines(pipelines, "Mock Data - No Project Context")
 		return
 	}
 
-	// Extract project ID (this is a bit hacky, we need to improve the client interface)
-	projectID := extractProjectID(project)
-	if projectID == 0 {
-		fmt.Printf("Could not extract project ID from project data\n")
-		// Fall back to mock data
-		pipelines := core.GetMockPipelines()
-		displayPipelines(pipelines, "Mock Data - No Project ID")
-		return
+	var pipelines []core.Pipeline
+	if limit > 0 {
+		pipelines, err = service.ListRecentPipelines(context.Background(), core.PipelineListOptions{Limit: limit})
+	} else {
+		pipelines, err = service.ListPipelines(context.Background())
 	}
-
-	// Get real pipeline data
-	pipelines, err := client.GetProjectPipelines(projectID)
 	if err != nil {
 		fmt.Printf("Failed to get pipelines: %v\n", err)
 		// Fall back to mock data
-		mockPipelines := core.GetMockPipelines()
-		displayPipelines(mockPipelines, "Mock Data - API Error")
+		source := "Mock Data - API Error"
+		if errors.Is(err, core.ErrNotFound) {
+			source = "Mock Data - Project Not Found"
+		}
+		displayPipelines(core.GetMockPipelines(), source)
 		return
 	}
 
-	displayPipelines(pipelines, fmt.Sprintf("Real Data via API - %s", projectPath))
+	displayPipelines(pipelines, fmt.Sprintf("Real Data - %s", service.Project()))
 }
 
-func getProjectPipelinesViaGlab(projectPath string) ([]core.Pipeline, error) {
-	// Use glab command to get pipeline data
-	cmd := exec.Command("glab", "pipeline", "list")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("glab command failed: %w", err)
+// listDashboard prints the latest pipeline of every project and ref of the
+// configured group
+func listDashboard(demo bool) {
+	var service *core.Service
+	if demo {
+		service = core.NewMockService(tui.MockDashboardConfig())
+	} else {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Printf("❌ Failed to load config: %v\n", err)
+			os.Exit(1)
+		}
+		service, err = gitlab.NewGroupService(cfg)
+		if err != nil {
+			fmt.Printf("❌ Could not connect to GitLab: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
-	// Parse the text output
-	pipelines, err := parseGlabPipelineText(string(output))
+	rows, err := service.Dashboard(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse glab output: %w", err)
+		fmt.Printf("❌ Failed to load dashboard: %v\n", err)
+		os.Exit(1)
 	}
 
-	return pipelines, nil
+	fmt.Printf("GitLab Dashboard (%d rows):\n", len(rows))
+	fmt.Println("Project                             Ref                   ID          Status")
+	fmt.Println("──────────────────────────────────────────────────────────────────────────────")
+	for _, row := range rows {
+		project := row.Project.PathWithNamespace
+		if project == "" {
+			project = row.Project.Name
+		}
+		switch {
+		case row.Err != nil:
+			fmt.Printf("%-35s error: %v\n", project, row.Err)
+		case row.Pipeline == nil:
+			fmt.Printf("%-35s %-20s no pipelines\n", project, shortRef(row.Ref))
+		default:
+			fmt.Printf("%-35s %-20s  %-10d  %s %s\n",
+				project, shortRef(row.Ref), row.Pipeline.ID, getStatusIcon(row.Pipeline.Status), row.Pipeline.Status)
+		}
+	}
 }
 
-func parseGlabPipelineText(output string) ([]core.Pipeline, error) {
-	lines := strings.Split(output, "\n")
-	var pipelines []core.Pipeline
+// listMergeRequests prints the project's open merge requests with their
+// approvals and head pipeline
+func listMergeRequests(demo bool) {
+	service := core.NewMockService(nil)
+	if !demo {
+		service = newService()
+	}
 
-	// Extract project name from header line like "Showing 30 pipelines on group/project"
-	projectName := "current-project"
-	for _, line := range lines {
-		if strings.HasPrefix(line, "Showing") && strings.Contains(line, " on ") {
-			parts := strings.Split(line, " on ")
-			if len(parts) > 1 {
-				fullPath := strings.TrimSuffix(parts[1], ". (Page 1)")
-				// Extract just the project name from the full path
-				pathParts := strings.Split(fullPath, "/")
-				if len(pathParts) > 0 {
-					projectName = pathParts[len(pathParts)-1]
-				}
-			}
-			break
-		}
+	mrs, err := service.MergeRequests(context.Background())
+	if err != nil {
+		fmt.Printf("❌ Failed to load merge requests: %v\n", err)
+		os.Exit(1)
 	}
 
-	// Skip header lines and parse pipeline data
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "Showing") || strings.HasPrefix(line, "State") {
-			continue
+	fmt.Printf("Open Merge Requests (%s, %d):\n", service.Project(), len(mrs))
+	fmt.Println("MR      Title                                    Author         Branches                        Approvals  Pipeline")
+	fmt.Println("──────────────────────────────────────────────────────────────────────────────────────────────────────────────────────")
+	for _, mr := range mrs {
+		title := mr.Title
+		if mr.Draft && !strings.HasPrefix(strings.ToLower(title), "draft") {
+			title = "Draft: " + title
 		}
-
-		// Parse lines like: "(running) • #1997196243	(#6866)	refs/merge-requests/406/head	(less than a minute ago)"
-		if strings.Contains(line, "#") {
-			pipeline := parsePipelineLine(line, projectName)
-			if pipeline.ID != 0 {
-				pipelines = append(pipelines, pipeline)
-			}
+		approvals := "?"
+		if mr.Approvals != nil {
+			approvals = mr.Approvals.String()
 		}
+		pipeline := "○ none"
+		if p := mr.HeadPipeline; p != nil {
+			pipeline = fmt.Sprintf("%s %s #%d", getStatusIcon(p.Status), p.Status, p.ID)
+		}
+		fmt.Printf("!%-6d %-40s %-14s %-31s %-10s %s\n",
+			mr.IID, truncate(title, 40), truncate("@"+mr.Author, 14), truncate(mr.SourceBranch+" → "+mr.TargetBranch, 31), approvals, pipeline)
 	}
+}
 
-	return pipelines, nil
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
 }
 
-func parsePipelineLine(line, projectName string) core.Pipeline {
-	// Extract status
-	var status string
-	if strings.Contains(line, "(running)") {
-		status = "running"
-	} else if strings.Contains(line, "(success)") {
-		status = "success"
-	} else if strings.Contains(line, "(failed)") {
-		status = "failed"
-	} else if strings.Contains(line, "(waiting_for_resource)") {
-		status = "waiting_for_resource"
-	} else {
-		status = "unknown"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
 	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
 
-	// Extract pipeline ID
-	var pipelineID int
-	if idx := strings.Index(line, "#"); idx != -1 {
-		idStr := ""
-		for i := idx + 1; i < len(line) && (line[i] >= '0' && line[i] <= '9'); i++ {
-			idStr += string(line[i])
-		}
-		if id, err := strconv.Atoi(idStr); err == nil {
-			pipelineID = id
-		}
+// runPipeline creates a pipeline for ref, the current branch if empty
+func runPipeline(ref string, variables []core.JobVariable) {
+	service := newService()
+	ctx := context.Background()
+
+	if ref == "" {
+		ref = service.DefaultRef(ctx)
 	}
 
-	// Extract ref (simplified) - it's the 3rd tab-separated field
-	parts := strings.Split(line, "\t")
-	ref := "unknown"
-	if len(parts) >= 3 {
-		ref = strings.TrimSpace(parts[2])
-		// Clean up long merge request refs
-		if strings.HasPrefix(ref, "refs/merge-requests/") {
-			ref = "MR-" + strings.Split(ref, "/")[2]
-		}
+	fmt.Printf("🚀 Running pipeline for %s on %s...\n", service.Project(), ref)
+	pipeline, err := service.CreatePipeline(ctx, ref, variables)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
 	}
 
-	// Determine job status based on pipeline status
-	jobs := "pending"
-	switch status {
-	case "running":
-		jobs = "in progress"
-	case "success":
-		jobs = "completed"
-	case "failed":
-		jobs = "failed"
-	case "waiting_for_resource":
-		jobs = "queued"
+	fmt.Printf("%s Pipeline #%d %s\n", getStatusIcon(pipeline.Status), pipeline.ID, pipeline.Status)
+	if pipeline.WebURL != "" {
+		fmt.Printf("🔗 %s\n", pipeline.WebURL)
+	}
+}
+
+// newService connects to the project of the current repository or exits
+func newService() *core.Service {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("❌ Failed to load config: %v\n", err)
+		os.Exit(1)
 	}
 
-	return core.Pipeline{
-		ID:          pipelineID,
-		Status:      status,
-		Ref:         ref,
-		ProjectName: projectName,
-		Jobs:        jobs,
+	service, err := gitlab.NewService(cfg, "")
+	if err != nil {
+		fmt.Printf("❌ Could not detect GitLab project: %v\n", err)
+		fmt.Println("💡 Make sure you're in a GitLab repository and authenticated with 'glab auth login'")
+		os.Exit(1)
 	}
+
+	return service
 }
 
 func displayPipelines(pipelines []core.Pipeline, source string) {
@@ -295,53 +442,16 @@ func displayPipelines(pipelines []core.Pipeline, source string) {
 	for _, p := range pipelines {
 		status := getStatusIcon(p.Status)
 		fmt.Printf("%-10d  %s %-8s %-15s %-20s %s\n",
-			p.ID, status, p.Status, p.ProjectName, p.Ref, p.Jobs)
+			p.ID, status, p.Status, p.ProjectName, shortRef(p.Ref), p.Jobs)
 	}
 }
 
-func getCurrentProjectPath() (string, error) {
-	// Get the remote URL
-	cmd := exec.Command("git", "remote", "get-url", "origin")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("failed to get git remote: %w", err)
+// shortRef cleans up long merge request refs
+func shortRef(ref string) string {
+	if strings.HasPrefix(ref, "refs/merge-requests/") {
+		return "MR-" + strings.Split(ref, "/")[2]
 	}
-
-	remoteURL := strings.TrimSpace(string(output))
-
-	// Parse GitLab project path from URL
-	if strings.Contains(remoteURL, "gitlab") {
-		// Extract project path from GitLab URL
-		if strings.HasPrefix(remoteURL, "git@") {
-			// SSH format: git@gitlab.com:group/project.git
-			parts := strings.Split(remoteURL, ":")
-			if len(parts) >= 2 {
-				return strings.TrimSuffix(parts[1], ".git"), nil
-			}
-		} else if strings.HasPrefix(remoteURL, "https://") {
-			// HTTPS format: https://gitlab.com/group/project.git
-			parts := strings.Split(remoteURL, "/")
-			if len(parts) >= 4 {
-				projectPath := strings.Join(parts[3:], "/")
-				return strings.TrimSuffix(projectPath, ".git"), nil
-			}
-		}
-	}
-
-	return "", fmt.Errorf("not a GitLab repository or unsupported URL format: %s", remoteURL)
-}
-
-func extractProjectID(project interface{}) int {
-	// This is a temporary hack - we need to improve the client interface
-	// For now, try to extract ID from the project data
-	if p, ok := project.(map[string]interface{}); ok {
-		if id, exists := p["id"]; exists {
-			if idFloat, ok := id.(float64); ok {
-				return int(idFloat)
-			}
-		}
-	}
-	return 0
+	return ref
 }
 
 func getStatusIcon(status string) string {
@@ -366,23 +476,13 @@ func checkJob(jobIDStr string) {
 
 	fmt.Printf("Checking job %d...\n", jobID)
 
-	// Try to get current project from git context
-	projectPath, err := getCurrentProjectPath()
-	if err != nil {
-		fmt.Printf("❌ Could not detect GitLab project: %v\n", err)
-		fmt.Println("💡 Make sure you're in a GitLab repository")
-		os.Exit(1)
-	}
-
-	fmt.Printf("📊 Project: %s\n", projectPath)
+	service := newService()
+	fmt.Printf("📊 Project: %s\n", service.Project())
 
-	// Use glab command to get job details with project context
-	// First try the project-specific endpoint
-	cmd := exec.Command("glab", "api", fmt.Sprintf("projects/%s/jobs/%d", strings.ReplaceAll(projectPath, "/", "%2F"), jobID))
-	output, err := cmd.Output()
+	jobInfo, err := service.GetJob(context.Background(), jobID)
 	if err != nil {
 		// Check if it's a 404 (job not found) vs auth issue
-		if strings.Contains(string(output), "404 Not Found") {
+		if errors.Is(err, core.ErrNotFound) {
 			fmt.Printf("❌ Job %d not found\n", jobID)
 			fmt.Println("💡 Make sure the job ID is correct and from the current project")
 		} else {
@@ -392,13 +492,6 @@ func checkJob(jobIDStr string) {
 		os.Exit(1)
 	}
 
-	// Parse the JSON response to extract key info
-	jobInfo, err := parseJobJSON(string(output))
-	if err != nil {
-		fmt.Printf("❌ Failed to parse job data: %v\n", err)
-		os.Exit(1)
-	}
-
 	// Display job information
 	fmt.Printf("✅ Job %d details:\n", jobID)
 	fmt.Printf("   Name: %s\n", jobInfo.Name)
@@ -409,187 +502,352 @@ func checkJob(jobIDStr string) {
 	}
 }
 
-// Simple JSON parser for job info
-func parseJobJSON(jsonStr string) (core.Job, error) {
-	// For now, use a simple approach to extract key fields
-	// In a production version, we'd use proper JSON parsing
-
-	job := core.Job{}
-
-	// Extract name
-	if nameStart := strings.Index(jsonStr, `"name":"`); nameStart != -1 {
-		nameStart += 8
-		if nameEnd := strings.Index(jsonStr[nameStart:], `"`); nameEnd != -1 {
-			job.Name = jsonStr[nameStart : nameStart+nameEnd]
-		}
+func streamJobLogs(jobIDStr string, demo bool) {
+	jobID, err := strconv.Atoi(jobIDStr)
+	if err != nil {
+		fmt.Printf("Invalid job ID: %s\n", jobIDStr)
+		os.Exit(1)
 	}
 
-	// Extract status
-	if statusStart := strings.Index(jsonStr, `"status":"`); statusStart != -1 {
-		statusStart += 10
-		if statusEnd := strings.Index(jsonStr[statusStart:], `"`); statusEnd != -1 {
-			job.Status = jsonStr[statusStart : statusStart+statusEnd]
-		}
+	service := core.NewMockService(nil)
+	if !demo {
+		service = newService()
 	}
 
-	// Extract stage
-	if stageStart := strings.Index(jsonStr, `"stage":"`); stageStart != -1 {
-		stageStart += 9
-		if stageEnd := strings.Index(jsonStr[stageStart:], `"`); stageEnd != -1 {
-			job.Stage = jsonStr[stageStart : stageStart+stageEnd]
-		}
-	}
+	fmt.Printf("🔄 Streaming logs for job %d (Ctrl+C to exit)...\n", jobID)
+	fmt.Println("─────────────────────────────────────────────────")
 
-	// Extract duration if available
-	if durationStart := strings.Index(jsonStr, `"duration":`); durationStart != -1 {
-		durationStart += 11
-		if durationEnd := strings.Index(jsonStr[durationStart:], `,`); durationEnd != -1 {
-			durationStr := jsonStr[durationStart : durationStart+durationEnd]
-			if durationStr != "null" {
-				job.Duration = durationStr + "s"
+	// Stop following on Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	received := false
+	for chunk := range service.FollowJobLogs(ctx, jobID, core.DefaultTraceInterval) {
+		switch {
+		case chunk.Err != nil:
+			if !received {
+				fmt.Printf("❌ Failed to get job logs: %v\n", chunk.Err)
+				os.Exit(1)
 			}
+			fmt.Printf("\n❌ Error fetching logs: %v\n", chunk.Err)
+		case chunk.Done:
+			fmt.Printf("\n─────────────────────────────────────────────────\n")
+			fmt.Printf("✅ Job %d completed with status: %s\n", jobID, chunk.Status)
+			return
+		case chunk.Reset:
+			if received {
+				fmt.Printf("\n─────────────── log restarted ───────────────\n")
+			}
+			fmt.Print(chunk.Data)
+		default:
+			fmt.Print(chunk.Data)
 		}
+		received = true
 	}
 
-	return job, nil
+	fmt.Printf("\n─────────────────────────────────────────────────\n")
+	fmt.Printf("🛑 Log streaming stopped\n")
 }
 
-func streamJobLogs(jobIDStr string) {
+func showJobLogs(jobIDStr string, demo bool) {
 	jobID, err := strconv.Atoi(jobIDStr)
 	if err != nil {
 		fmt.Printf("Invalid job ID: %s\n", jobIDStr)
 		os.Exit(1)
 	}
 
-	// Auto-detect current project
-	projectPath, err := getCurrentProjectPath()
+	fmt.Printf("Fetching logs for job %d...\n", jobID)
+
+	service := core.NewMockService(nil)
+	if !demo {
+		service = newService()
+	}
+	logs, err := service.GetJobLogs(context.Background(), jobID)
 	if err != nil {
-		fmt.Printf("❌ Could not detect GitLab project: %v\n", err)
+		fmt.Printf("❌ Failed to get job logs: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("🔄 Streaming logs for job %d (Ctrl+C to exit)...\n", jobID)
+	fmt.Printf("📋 Job %d logs:\n", jobID)
 	fmt.Println("─────────────────────────────────────────────────")
+	fmt.Println(logs)
+}
 
-	wrapper := gitlab.NewGlabWrapper(projectPath)
+// showJobErrors prints the lines of a job log that report failures, as the
+// terminal would show them, with their line numbers
+func showJobErrors(jobIDStr string, demo bool) {
+	jobID, err := strconv.Atoi(jobIDStr)
+	if err != nil {
+		fmt.Printf("Invalid job ID: %s\n", jobIDStr)
+		os.Exit(1)
+	}
+
+	service := core.NewMockService(nil)
+	if !demo {
+		service = newService()
+	}
+	matcher, err := service.ErrorMatcher()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
 
-	// Track last log position to avoid duplicates
-	var lastLogSize int64 = 0
+	fmt.Printf("Fetching logs for job %d...\n", jobID)
+	logs, err := service.GetJobLogs(context.Background(), jobID)
+	if err != nil {
+		fmt.Printf("❌ Failed to get job logs: %v\n", err)
+		os.Exit(1)
+	}
 
-	// Setup signal handling for graceful exit
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	lines := joblog.Parse(logs).Lines
+	failures := matcher.Failures(lines)
+	if len(failures) == 0 {
+		fmt.Printf("✅ No failure lines in job %d\n", jobID)
+		return
+	}
 
-	// Create ticker for polling
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
+	fmt.Printf("❌ %s in job %d:\n", plural(len(failures), "failure line"), jobID)
+	fmt.Println("─────────────────────────────────────────────────")
+	for _, i := range failures {
+		fmt.Printf("%5d: %s\n", i+1, lines[i].Text)
+	}
+}
 
-	// Initial log fetch
-	logs, err := wrapper.GetJobLogs(jobID)
+// showTestReport prints the per-suite counts of a pipeline's test report and
+// the output of every failed test case
+func showTestReport(pipelineIDStr string, demo bool) {
+	pipelineID, err := strconv.Atoi(pipelineIDStr)
 	if err != nil {
-		fmt.Printf("❌ Failed to get job logs: %v\n", err)
+		fmt.Printf("Invalid pipeline ID: %s\n", pipelineIDStr)
 		os.Exit(1)
 	}
 
-	if logs != "" {
-		fmt.Print(logs)
-		lastLogSize = int64(len(logs))
+	service := core.NewMockService(nil)
+	if !demo {
+		service = newService()
 	}
 
-	// Check initial job status
-	jobStatus, err := wrapper.GetJobStatus(jobID)
-	if err == nil && (jobStatus == "success" || jobStatus == "failed" || jobStatus == "canceled") {
-		fmt.Printf("\n─────────────────────────────────────────────────\n")
-		fmt.Printf("✅ Job %d completed with status: %s\n", jobID, jobStatus)
+	report, err := service.TestReport(context.Background(), pipelineID)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if len(report.Suites) == 0 {
+		fmt.Printf("Pipeline %d has no test report\n", pipelineID)
+		fmt.Println("💡 Jobs publish one with artifacts:reports:junit")
 		return
 	}
 
-	for {
-		select {
-		case <-sigChan:
-			fmt.Printf("\n─────────────────────────────────────────────────\n")
-			fmt.Printf("🛑 Log streaming stopped\n")
-			return
+	fmt.Printf("🧪 Test report of pipeline %d: %s", pipelineID, report.TestCounts)
+	if d := core.FormatDuration(report.Time); d != "" {
+		fmt.Printf(" in %s", d)
+	}
+	fmt.Println()
+	fmt.Println("Suite                          Tests  Passed  Failed  Errors  Skipped  Time      Jobs")
+	fmt.Println("──────────────────────────────────────────────────────────────────────────────────────────")
+	for _, suite := range report.Suites {
+		status := "success"
+		if !suite.Passed() || suite.SuiteError != "" {
+			status = "failed"
+		}
+		jobs := make([]string, 0, len(suite.JobIDs))
+		for _, id := range suite.JobIDs {
+			jobs = append(jobs, strconv.Itoa(id))
+		}
+		fmt.Printf("%s %-28s %5d  %6d  %6d  %6d  %7d  %-8s  %s\n",
+			getStatusIcon(status), truncate(suite.Name, 28), suite.Total, suite.Success, suite.Failed, suite.Error, suite.Skipped,
+			core.FormatDuration(suite.Time), strings.Join(jobs, ", "))
+		if suite.SuiteError != "" {
+			fmt.Printf("  ⚠ %s\n", suite.SuiteError)
+		}
+	}
 
-		case <-ticker.C:
-			// Get current logs
-			currentLogs, err := wrapper.GetJobLogs(jobID)
-			if err != nil {
-				fmt.Printf("\n❌ Error fetching logs: %v\n", err)
-				continue
+	for _, suite := range report.Suites {
+		for _, c := range suite.FailedCases() {
+			name := c.Name
+			if c.Classname != "" {
+				name = c.Classname + " › " + c.Name
 			}
-
-			// Check if there are new logs
-			currentSize := int64(len(currentLogs))
-			if currentSize > lastLogSize {
-				// Print only new content
-				newContent := currentLogs[lastLogSize:]
-				fmt.Print(newContent)
-				lastLogSize = currentSize
+			fmt.Printf("\n✗ %s: %s (%s)\n", suite.Name, name, c.Status)
+			if c.File != "" {
+				fmt.Printf("  File: %s\n", c.File)
 			}
-
-			// Check job status
-			jobStatus, err := wrapper.GetJobStatus(jobID)
-			if err == nil && (jobStatus == "success" || jobStatus == "failed" || jobStatus == "canceled") {
-				fmt.Printf("\n─────────────────────────────────────────────────\n")
-				fmt.Printf("✅ Job %d completed with status: %s\n", jobID, jobStatus)
-				return
+			for _, section := range []struct{ title, text string }{
+				{"Stack trace", c.StackTrace},
+				{"System output", c.SystemOutput},
+			} {
+				if text := strings.TrimRight(section.text, "\n"); text != "" {
+					fmt.Printf("  ── %s ──\n", section.title)
+					fmt.Println("  " + strings.ReplaceAll(text, "\n", "\n  "))
+				}
 			}
 		}
 	}
 }
 
-func showJobLogs(jobIDStr string) {
-	jobID, err := strconv.Atoi(jobIDStr)
+// showFlaky prints the jobs and test cases that both passed and failed on
+// the same code in the last pipelines of a ref, most flips first
+func showFlaky(ref string, last int, demo bool) {
+	service := core.NewMockService(nil)
+	if !demo {
+		service = newService()
+	}
+	ctx := context.Background()
+	if ref == "" {
+		ref = service.DefaultRef(ctx)
+	}
+
+	fmt.Printf("🔍 Analyzing the last %d pipelines of %s...\n", last, ref)
+	report, err := analysis.Analyze(ctx, service, analysis.Options{Ref: ref, Last: last, Tests: true})
 	if err != nil {
-		fmt.Printf("Invalid job ID: %s\n", jobIDStr)
+		fmt.Printf("❌ %v\n", err)
 		os.Exit(1)
 	}
+	fmt.Printf("📊 %s on %s, %s compared\n",
+		plural(report.Pipelines, "finished pipeline"), plural(report.Commits, "commit"), plural(report.TestReports, "test report"))
+	if len(report.Flaky) == 0 {
+		fmt.Println("✅ No flaky jobs or tests found")
+		if report.Pipelines > 0 && report.Pipelines == report.Commits {
+			fmt.Println("💡 Every pipeline ran a different commit; retries are the only signal")
+		}
+		return
+	}
 
-	fmt.Printf("Fetching logs for job %d...\n", jobID)
+	fmt.Println()
+	fmt.Println("  #  Kind  Name                                                Flips  Retry  Commit  Failed         Pipelines")
+	fmt.Println("────────────────────────────────────────────────────────────────────────────────────────────────────────────────")
+	for i, f := range report.Flaky {
+		pipelines := make([]string, 0, len(f.Pipelines))
+		for _, id := range f.Pipelines {
+			pipelines = append(pipelines, strconv.Itoa(id))
+		}
+		fmt.Printf("%3d  %-4s  %-50s  %5d  %5d  %6d  %3d/%-3d %4.0f%%  %s\n",
+			i+1, f.Kind, truncate(f.Name, 50), f.Flips(), f.RetryFlips, f.CommitFlips,
+			f.Failures, f.Runs, f.FailureRate()*100, strings.Join(pipelines, ", "))
+	}
+}
+
+// showDurations prints the p50/p90 durations of the pipelines, stages and
+// jobs in the last pipelines of a ref, slowest first
+func showDurations(ref string, last int, demo bool) {
+	service := core.NewMockService(nil)
+	if !demo {
+		service = newService()
+	}
+	ctx := context.Background()
+	if ref == "" {
+		ref = service.DefaultRef(ctx)
+	}
 
-	// Auto-detect current project
-	projectPath, err := getCurrentProjectPath()
+	fmt.Printf("⏱️  Timing the last %d pipelines of %s...\n", last, ref)
+	report, err := analysis.AnalyzeDurations(ctx, service, analysis.Options{Ref: ref, Last: last})
 	if err != nil {
-		fmt.Printf("❌ Could not detect GitLab project: %v\n", err)
+		fmt.Printf("❌ %v\n", err)
 		os.Exit(1)
 	}
+	if len(report.Jobs) == 0 {
+		fmt.Printf("No successful jobs in %s\n", plural(report.Pipelines, "finished pipeline"))
+		return
+	}
+
+	p := report.Pipeline
+	fmt.Printf("📊 %s, %d successful: p50 %s, p90 %s, max %s, queued p50 %s\n",
+		plural(report.Pipelines, "finished pipeline"), p.Runs,
+		formatSeconds(p.P50), formatSeconds(p.P90), formatSeconds(p.Max), formatSeconds(report.Queued.P50))
 
-	wrapper := gitlab.NewGlabWrapper(projectPath)
-	logs, err := wrapper.GetJobLogs(jobID)
+	const trendWidth = 20
+	fmt.Println()
+	fmt.Println("Stage                 Runs  p50       p90       max       Trend")
+	fmt.Println("─────────────────────────────────────────────────────────────────────────────────")
+	for _, stage := range report.Stages {
+		fmt.Printf("%-20s  %4d  %-8s  %-8s  %-8s  %s\n", truncate(stage.Name, 20), stage.Runs,
+			formatSeconds(stage.P50), formatSeconds(stage.P90), formatSeconds(stage.Max), analysis.Sparkline(stage.Trend, trendWidth))
+	}
+
+	fmt.Println()
+	fmt.Println("Job                             Stage         Runs  p50       p90       max       Queued p50  Trend")
+	fmt.Println("──────────────────────────────────────────────────────────────────────────────────────────────────────────────────")
+	for _, job := range report.Jobs {
+		fmt.Printf("%-30s  %-12s  %4d  %-8s  %-8s  %-8s  %-10s  %s\n", truncate(job.Name, 30), truncate(job.Stage, 12), job.Runs,
+			formatSeconds(job.P50), formatSeconds(job.P90), formatSeconds(job.Max), formatSeconds(job.Queued.P50), analysis.Sparkline(job.Trend, trendWidth))
+	}
+}
+
+// formatSeconds renders a duration, "-" when there is none
+func formatSeconds(seconds float64) string {
+	if d := core.FormatDuration(seconds); d != "" {
+		return d
+	}
+	return "-"
+}
+
+// jobArtifacts lists the files in the artifacts of a job, or extracts the
+// ones below path into dir
+func jobArtifacts(jobIDStr string, extract bool, path, dir string) {
+	jobID, err := strconv.Atoi(jobIDStr)
 	if err != nil {
-		fmt.Printf("❌ Failed to get job logs: %v\n", err)
+		fmt.Printf("Invalid job ID: %s\n", jobIDStr)
 		os.Exit(1)
 	}
 
-	fmt.Printf("📋 Job %d logs:\n", jobID)
-	fmt.Println("─────────────────────────────────────────────────")
-	fmt.Println(logs)
-}
+	service := newService()
 
-func testRealGitLab() {
-	fmt.Println("Testing real GitLab connection using glab...")
+	// A single file comes straight from GitLab, without the whole archive.
+	// Directories aren't served that way and are extracted from the archive.
+	if extract && path != "" && !strings.HasSuffix(path, "/") {
+		fmt.Printf("📦 Downloading %s from the artifacts of job %d...\n", path, jobID)
+		file, err := service.ExtractJobArtifactFile(context.Background(), jobID, dir, path)
+		if err == nil {
+			fmt.Printf("✅ Extracted %s\n", file)
+			return
+		}
+		if !errors.Is(err, core.ErrNotFound) {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+	}
 
-	// Test glab command directly
-	cmd := exec.Command("glab", "pipeline", "list", "-R", "group/project/frontend-apps")
-	output, err := cmd.Output()
+	fmt.Printf("📦 Downloading artifacts of job %d...\n", jobID)
+	artifacts, err := service.JobArtifacts(context.Background(), jobID)
 	if err != nil {
-		fmt.Printf("❌ Failed to run glab command: %v\n", err)
+		fmt.Printf("❌ %v\n", err)
+		if errors.Is(err, core.ErrArtifactsTooLarge) {
+			fmt.Println("💡 Extract single files with --path")
+		}
 		os.Exit(1)
 	}
 
-	fmt.Println("✅ glab command successful!")
-	fmt.Println("📋 Raw pipeline data:")
-	fmt.Println(string(output))
+	if !extract {
+		files := artifacts.Files()
+		fmt.Printf("Artifacts of job %d (%d files, %s archive):\n", jobID, len(files), core.FormatSize(artifacts.Size()))
+		for _, f := range files {
+			fmt.Printf("%10s  %s\n", core.FormatSize(f.Size), f.Path)
+		}
+		return
+	}
+
+	written, err := artifacts.ExtractPrefix(dir, path)
+	for _, file := range written {
+		fmt.Printf("✓ %s\n", file)
+	}
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Extracted %d files to %s\n", len(written), dir)
+}
+
+func testRealGitLab() {
+	fmt.Println("Testing real GitLab connection...")
 
-	// Parse the output
-	pipelines, err := gitlab.ParseGlabPipelineList(string(output))
+	service := newService()
+	pipelines, err := service.ListPipelines(context.Background())
 	if err != nil {
-		fmt.Printf("❌ Failed to parse pipeline data: %v\n", err)
+		fmt.Printf("❌ Failed to get pipelines: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("✅ Parsed %d pipelines!\n", len(pipelines))
+	fmt.Printf("✅ Loaded %d pipelines from %s!\n", len(pipelines), service.Project())
 	for i, p := range pipelines {
 		if i < 5 { // Show first 5
 			fmt.Printf("  Pipeline #%d: %s (%s)\n", p.ID, p.Status, p.Ref)
@@ -606,10 +864,23 @@ USAGE:
     glab-tui [COMMAND]          Run CLI command
 
 COMMANDS:
-    pipelines, p               List pipelines
+    pipelines, p [--limit N]   List pipelines (all pages up to N)
     job, j <job-id>           Check specific job status
     logs, l [--follow] <job-id>  Show job logs
         --follow, -f          🔥 Stream logs in real-time
+        --errors, -e          ❌ Only the lines reporting failures (LOG_ERROR_SETS, LOG_ERROR_PATTERN)
+        --demo                Use mock data
+    mrs, mr [--demo]          🔀 Open merge requests with approvals and pipeline status
+    run [--ref REF] [--var KEY=VAL ...]  Run a new pipeline (default: current branch)
+    tests, t <pipeline-id> [--demo]  🧪 JUnit test report: suites, per-job counts and failures
+    flaky [--ref REF] [--last N] [--demo]  🔍 Flaky jobs and tests in the last N pipelines (default 50)
+    stats [--ref REF] [--last N] [--demo]  ⏱️  p50/p90 durations per stage and job in the last N pipelines
+    artifacts, a <job-id>     📦 List the files in the artifacts of a job
+        --path P              Extract the file P or the directory P
+        -o DIR                Extract into DIR (default: current directory)
+    dashboard, dash           📊 Latest pipelines of every project in a group
+        --list                Print the dashboard instead of starting the TUI
+        --demo                Use mock data
     demo, d                   🎯 Demo mode with mock data (for non-GitLab repos)
     remote, url <gitlab-url>  🌐 Connect to remote GitLab project
     test-real                 Test GitLab API connection
@@ -624,10 +895,22 @@ EXAMPLES:
     glab-tui url https://gitlab.com/group/project  # 🌐 Remote project (short)
     glab-tui speed                    # 🔥 CHALLENGE MODE
     glab-tui pipelines                # List pipelines in CLI
+    glab-tui pipelines --limit 250    # Last 250 pipelines, across pages
+    glab-tui mrs                      # Open merge requests
+    glab-tui run                      # Run a pipeline for the current branch
+    glab-tui run --ref main --var DEPLOY_ENV=staging  # Pipeline with variables
+    glab-tui dashboard                # 📊 Group dashboard (GITLAB_GROUP_PATH)
+    glab-tui dashboard --list         # Group dashboard as a table
     glab-tui job 11098249149         # Check specific job
+    glab-tui tests 1996879423        # Failed tests of a pipeline
+    glab-tui flaky --ref main --last 50  # Jobs and tests that flip between pass and fail
+    glab-tui stats --ref main         # Which jobs make pipelines slow
+    glab-tui artifacts 11098249149   # List job artifacts
+    glab-tui artifacts 11098249149 --path coverage -o out  # Extract coverage/ into out/
     glab-tui logs 11098249149        # Show job logs (static)
     glab-tui logs --follow 11098249149  # 🔥 Stream logs in real-time
     glab-tui logs -f 11098249149     # 🔥 Stream logs (short flag)
+    glab-tui logs --errors 11098249149  # ❌ Jump straight to what failed
     glab-tui test-real               # Test GitLab connection
     glab-tui help                    # Show help`)
 }
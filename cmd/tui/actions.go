@@ -0,0 +1,250 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// noticeDuration is how long the outcome of an action stays in the status bar
+const noticeDuration = 10 * time.Second
+
+type actionKind int
+
+const (
+	retryPipelineAction actionKind = iota
+	cancelPipelineAction
+	retryJobAction
+	cancelJobAction
+	playJobAction
+	deletePipelineAction
+)
+
+// action is a pipeline or job action waiting for confirmation
+type action struct {
+	kind    actionKind
+	service *core.Service // Scoped to the project of the target
+	id      int           // Pipeline or job ID
+	name    string        // Job name, empty for pipelines
+	input   string        // KEY=value variables typed for a manual job
+}
+
+// actionMsg reports the outcome of an action
+type actionMsg struct {
+	action   action
+	pipeline *core.Pipeline // Set by pipeline actions
+	job      *core.Job      // Set by job actions
+	err      error
+}
+
+// target describes what the action works on, e.g. "job #12 (deploy)"
+func (a action) target() string {
+	switch a.kind {
+	case retryPipelineAction, cancelPipelineAction, deletePipelineAction:
+		return fmt.Sprintf("pipeline #%d", a.id)
+	}
+	return fmt.Sprintf("job #%d (%s)", a.id, a.name)
+}
+
+// verb is the action in the imperative, e.g. "Retry"
+func (a action) verb() string {
+	switch a.kind {
+	case retryPipelineAction, retryJobAction:
+		return "Retry"
+	case cancelPipelineAction, cancelJobAction:
+		return "Cancel"
+	case deletePipelineAction:
+		return "Delete"
+	}
+	return "Play"
+}
+
+// run performs the action against GitLab
+func (a action) run() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		msg := actionMsg{action: a}
+		switch a.kind {
+		case retryPipelineAction:
+			msg.pipeline, msg.err = a.service.RetryPipeline(ctx, a.id)
+		case cancelPipelineAction:
+			msg.pipeline, msg.err = a.service.CancelPipeline(ctx, a.id)
+		case retryJobAction:
+			msg.job, msg.err = a.service.RetryJob(ctx, a.id)
+		case cancelJobAction:
+			msg.job, msg.err = a.service.CancelJob(ctx, a.id)
+		case playJobAction:
+			var variables []core.JobVariable
+			if variables, msg.err = core.ParseJobVariables(a.input); msg.err == nil {
+				msg.job, msg.err = a.service.PlayJob(ctx, a.id, variables)
+			}
+		}
+		return msg
+	}
+}
+
+// askPipelineAction asks to confirm a pipeline action, or explains why the
+// pipeline's status doesn't allow it
+func (m *model) askPipelineAction(kind actionKind, service *core.Service, pipeline core.Pipeline) {
+	switch {
+	case kind == retryPipelineAction && !core.CanRetryPipeline(pipeline.Status):
+		m.setNotice(fmt.Sprintf("Pipeline #%d is %s, nothing to retry", pipeline.ID, pipeline.Status), true)
+	case kind == cancelPipelineAction && !core.IsActive(pipeline.Status):
+		m.setNotice(fmt.Sprintf("Pipeline #%d is %s, nothing to cancel", pipeline.ID, pipeline.Status), true)
+	default:
+		m.pendingAction = &action{kind: kind, service: service, id: pipeline.ID}
+	}
+}
+
+// askJobAction asks to confirm a job action, or explains why the job's
+// status doesn't allow it
+func (m *model) askJobAction(kind actionKind, job core.Job) {
+	switch {
+	case kind == retryJobAction && !core.CanRetryJob(job.Status):
+		m.setNotice(fmt.Sprintf("Job %s is %s and can't be retried", job.Name, job.Status), true)
+	case kind == cancelJobAction && !core.IsActive(job.Status):
+		m.setNotice(fmt.Sprintf("Job %s is %s, nothing to cancel", job.Name, job.Status), true)
+	case kind == playJobAction && job.Status != "manual":
+		m.setNotice(fmt.Sprintf("Job %s is %s, only manual jobs can be played", job.Name, job.Status), true)
+	default:
+		m.pendingAction = &action{kind: kind, service: m.jobService, id: job.ID, name: job.Name}
+	}
+}
+
+// updateAction handles keys while an action waits for confirmation. Manual
+// jobs take a line of variables and run on Enter; other actions run on y.
+func (m model) updateAction(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	a := m.pendingAction
+	key := msg.String()
+
+	switch {
+	case key == "ctrl+c":
+		m.stopFollowingLogs()
+		return m, tea.Quit
+	case key == "esc":
+		m.pendingAction = nil
+	case a.kind == playJobAction:
+		switch msg.Type {
+		case tea.KeyEnter:
+			return m.confirmAction()
+		case tea.KeyBackspace:
+			if len(a.input) > 0 {
+				a.input = a.input[:len(a.input)-1]
+			}
+		case tea.KeySpace:
+			a.input += " "
+		case tea.KeyRunes:
+			a.input += string(msg.Runes)
+		}
+	case key == "y" || key == "Y" || key == "enter":
+		return m.confirmAction()
+	default:
+		m.pendingAction = nil
+	}
+	return m, nil
+}
+
+// confirmAction runs the pending action
+func (m model) confirmAction() (tea.Model, tea.Cmd) {
+	a := *m.pendingAction
+	m.pendingAction = nil
+
+	if a.kind == playJobAction {
+		if _, err := core.ParseJobVariables(a.input); err != nil {
+			m.setNotice(err.Error(), true)
+			return m, nil
+		}
+	}
+
+	m.setNotice(fmt.Sprintf("%s %s...", a.verb(), a.target()), false)
+	return m, a.run()
+}
+
+// applyAction shows the outcome of an action and reflects it in the loaded
+// data right away; the next refresh brings the rest
+func (m *model) applyAction(msg actionMsg) tea.Cmd {
+	a := msg.action
+	if msg.err != nil {
+		m.setNotice(fmt.Sprintf("%s %s failed: %v", a.verb(), a.target(), msg.err), true)
+		return nil
+	}
+	m.setNotice(fmt.Sprintf("✅ %s %s: %s", a.verb(), a.target(), m.actionStatus(msg)), false)
+
+	now := time.Now()
+	if p := msg.pipeline; p != nil {
+		for _, pipelines := range [][]core.Pipeline{m.pipelines, m.mrPipelines} {
+			for i := range pipelines {
+				if pipelines[i].ID == a.id {
+					pipelines[i].Status = p.Status
+					m.changed[pipelineChangeKey(a.id)] = now
+				}
+			}
+		}
+		for i, row := range m.dashboard {
+			if row.Pipeline != nil && row.Pipeline.ID == a.id {
+				updated := *row.Pipeline
+				updated.Status = p.Status
+				m.dashboard[i].Pipeline = &updated
+				m.changed[dashboardChangeKey(row)] = now
+			}
+		}
+	}
+	if job := msg.job; job != nil {
+		// A retry creates a new job that takes the old one's place
+		for i := range m.jobs {
+			if m.jobs[i].ID == a.id {
+				m.jobs[i].ID = job.ID
+				m.jobs[i].Status = job.Status
+				m.changed[jobChangeKey(job.ID)] = now
+			}
+		}
+	}
+	return m.poll()
+}
+
+func (m model) actionStatus(msg actionMsg) string {
+	if msg.pipeline != nil {
+		return "now " + msg.pipeline.Status
+	}
+	if msg.job != nil && msg.job.ID != msg.action.id {
+		return fmt.Sprintf("new job #%d is %s", msg.job.ID, msg.job.Status)
+	}
+	if msg.job != nil {
+		return "now " + msg.job.Status
+	}
+	return "done"
+}
+
+func (m *model) setNotice(text string, isErr bool) {
+	m.notice = text
+	m.noticeErr = isErr
+	m.noticeAt = time.Now()
+}
+
+// renderNotice shows the outcome of the last action for a while
+func (m model) renderNotice() string {
+	if m.notice == "" || time.Since(m.noticeAt) > noticeDuration {
+		return ""
+	}
+	if m.noticeErr {
+		return failedStyle.Render("❌ " + m.notice)
+	}
+	return successStyle.Render(m.notice)
+}
+
+// renderAction shows the confirmation prompt of the pending action
+func (m model) renderAction() string {
+	a := m.pendingAction
+	promptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF87")).Bold(true)
+
+	if a.kind == playJobAction {
+		prompt := promptStyle.Render(fmt.Sprintf("▶ Play %s?", a.target()))
+		prompt += "\n  Variables (KEY=value or KEY=\"with spaces\", space separated, optional): " + a.input + "█"
+		return prompt + "\n" + lipgloss.NewStyle().Faint(true).Render("  Enter: play | Esc: cancel")
+	}
+	return promptStyle.Render(fmt.Sprintf("⚠️  %s %s? [y/N]", a.verb(), a.target()))
+}
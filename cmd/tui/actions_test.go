@@ -0,0 +1,25 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+func TestApplyAction_PipelineStatus(t *testing.T) {
+	m := newModel(core.NewMockService(nil), true, pipelineView)
+	m.pipelines = []core.Pipeline{{ID: 1, Status: "failed"}, {ID: 2, Status: "failed"}}
+	m.mrPipelines = []core.Pipeline{{ID: 1, Status: "failed"}}
+
+	m.applyAction(actionMsg{action: action{kind: retryPipelineAction, id: 1}, pipeline: &core.Pipeline{ID: 1, Status: "running"}})
+
+	if m.pipelines[0].Status != "running" {
+		t.Errorf("pipeline list status = %s, want running", m.pipelines[0].Status)
+	}
+	if m.mrPipelines[0].Status != "running" {
+		t.Errorf("merge request pipeline status = %s, want running", m.mrPipelines[0].Status)
+	}
+	if m.pipelines[1].Status != "failed" {
+		t.Errorf("other pipeline changed to %s", m.pipelines[1].Status)
+	}
+}
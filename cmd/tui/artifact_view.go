@@ -0,0 +1,248 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+type artifactsMsg struct {
+	service   *core.Service
+	jobID     int
+	artifacts *core.Artifacts
+	err       error
+}
+
+type artifactPreviewMsg struct {
+	jobID     int
+	path      string
+	content   []byte
+	text      bool
+	truncated bool
+	err       error
+}
+
+type artifactDownloadMsg struct {
+	dir     string   // Where files were extracted, "" if the archive was saved
+	written []string // Extracted files, or the saved archive
+	err     error
+}
+
+func fetchArtifacts(service *core.Service, jobID int) tea.Cmd {
+	return func() tea.Msg {
+		artifacts, err := service.JobArtifacts(context.Background(), jobID)
+		return artifactsMsg{service: service, jobID: jobID, artifacts: artifacts, err: err}
+	}
+}
+
+func previewArtifact(artifacts *core.Artifacts, path string) tea.Cmd {
+	return func() tea.Msg {
+		content, text, truncated, err := artifacts.Preview(path)
+		return artifactPreviewMsg{jobID: artifacts.JobID, path: path, content: content, text: text, truncated: truncated, err: err}
+	}
+}
+
+// downloadArtifacts extracts files into the job's artifact directory, or
+// saves the whole archive if no files are given
+func downloadArtifacts(artifacts *core.Artifacts, files []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(files) == 0 {
+			file := fmt.Sprintf("artifacts-%d.zip", artifacts.JobID)
+			return artifactDownloadMsg{written: []string{file}, err: artifacts.Save(file)}
+		}
+		dir := artifactDir(artifacts.JobID)
+		written, err := artifacts.Extract(dir, files...)
+		return artifactDownloadMsg{dir: dir, written: written, err: err}
+	}
+}
+
+// artifactDir is where downloaded artifact files of a job go
+func artifactDir(jobID int) string {
+	return "artifacts-" + strconv.Itoa(jobID)
+}
+
+// openArtifacts switches to the artifacts of a job and downloads them
+func (m *model) openArtifacts(job core.Job) tea.Cmd {
+	if m.currentView != artifactView {
+		m.artifactParent = m.currentView
+	}
+	m.artifactJob = job
+	m.artifactService = m.jobService
+	m.artifacts = nil
+	m.artifactFiles = nil
+	m.artifactCursor = 0
+	m.artifactSelected = make(map[string]struct{})
+	m.currentView = artifactView
+	label := fmt.Sprintf("Downloading artifacts of job %s", job.Name)
+	return tea.Batch(m.startLoading(artifactView, label), fetchArtifacts(m.artifactService, job.ID))
+}
+
+// applyArtifacts lists the files of downloaded artifacts
+func (m *model) applyArtifacts(msg artifactsMsg) {
+	if msg.service != m.artifactService || msg.jobID != m.artifactJob.ID {
+		return
+	}
+	m.finishLoading(artifactView, msg.err)
+	if msg.err == nil {
+		m.artifacts = msg.artifacts
+		m.artifactFiles = msg.artifacts.Files()
+	}
+}
+
+// applyArtifactPreview shows a text file in the log viewer
+func (m *model) applyArtifactPreview(msg artifactPreviewMsg) tea.Cmd {
+	if m.artifacts == nil || msg.jobID != m.artifacts.JobID || m.currentView != artifactView {
+		return nil
+	}
+	switch {
+	case msg.err != nil:
+		m.setNotice(msg.err.Error(), true)
+		return nil
+	case !msg.text:
+		m.setNotice(fmt.Sprintf("%s is a binary file, press 'd' to download it", msg.path), true)
+		return nil
+	}
+
+	m.stopFollowingLogs()
+	text := string(msg.content)
+	if msg.truncated {
+		text += fmt.Sprintf("\n... preview cut off at %s, press 'd' in the artifacts view to download the file", core.FormatSize(core.MaxPreviewSize))
+	}
+	m.setLogs(text)
+	m.logSource = fmt.Sprintf("%s (Job #%d artifacts)", msg.path, msg.jobID)
+	m.selectedJobID = msg.jobID
+	m.logCursor = 0
+	m.logParent = artifactView
+	m.currentView = logView
+	return tea.ClearScreen
+}
+
+// applyArtifactDownload reports where downloaded artifacts went and clears
+// the selection once the selected files are extracted
+func (m *model) applyArtifactDownload(msg artifactDownloadMsg) {
+	switch {
+	case msg.err != nil:
+		m.setNotice(msg.err.Error(), true)
+		return
+	case len(msg.written) == 1:
+		// The extracted file keeps its path inside the archive
+		m.setNotice("✅ Saved "+msg.written[0], false)
+	default:
+		m.setNotice(fmt.Sprintf("✅ Saved %d files to %s%c", len(msg.written), msg.dir, filepath.Separator), false)
+	}
+	if msg.dir != "" {
+		m.artifactSelected = make(map[string]struct{})
+	}
+}
+
+// updateArtifacts handles keys in the artifacts view
+func (m model) updateArtifacts(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "up", "k":
+		if m.artifactCursor > 0 {
+			m.artifactCursor--
+		}
+	case "down", "j":
+		if m.artifactCursor < len(m.artifactFiles)-1 {
+			m.artifactCursor++
+		}
+	case "g":
+		m.artifactCursor = 0
+	case "G":
+		m.artifactCursor = max(len(m.artifactFiles)-1, 0)
+	case "ctrl+u":
+		m.artifactCursor = max(m.artifactCursor-5, 0)
+	case "ctrl+d":
+		m.artifactCursor = max(min(m.artifactCursor+5, len(m.artifactFiles)-1), 0)
+	case " ":
+		if m.artifactCursor < len(m.artifactFiles) {
+			toggle(m.artifactSelected, m.artifactFiles[m.artifactCursor].Path)
+		}
+	case "A":
+		paths := make([]string, 0, len(m.artifactFiles))
+		for _, f := range m.artifactFiles {
+			paths = append(paths, f.Path)
+		}
+		selectAll(m.artifactSelected, paths)
+	case "r":
+		if !m.loads[artifactView].loading {
+			return m, m.openArtifacts(m.artifactJob)
+		}
+	case "enter":
+		if m.artifactCursor < len(m.artifactFiles) {
+			return m, previewArtifact(m.artifacts, m.artifactFiles[m.artifactCursor].Path)
+		}
+	case "d":
+		// Download the selected files, or the one under the cursor
+		var files []string
+		for _, f := range m.artifactFiles {
+			if _, ok := m.artifactSelected[f.Path]; ok {
+				files = append(files, f.Path)
+			}
+		}
+		if len(files) == 0 && m.artifactCursor < len(m.artifactFiles) {
+			files = []string{m.artifactFiles[m.artifactCursor].Path}
+		}
+		if len(files) > 0 {
+			return m, downloadArtifacts(m.artifacts, files)
+		}
+	case "D":
+		// Download the whole archive
+		if m.artifacts != nil {
+			return m, downloadArtifacts(m.artifacts, nil)
+		}
+	case "esc":
+		m.currentView = m.artifactParent
+		return m, tea.ClearScreen
+	}
+	return m, nil
+}
+
+func (m model) renderArtifactView(title string) string {
+	header := headerStyle.Render(fmt.Sprintf("📦 Artifacts (Job #%d %s)", m.artifactJob.ID, m.artifactJob.Name))
+
+	s := title + "\n"
+	s += header + "\n"
+	if m.artifacts != nil {
+		var total int64
+		for _, f := range m.artifactFiles {
+			total += f.Size
+		}
+		statusLine := fmt.Sprintf("📊 %d files | %s unpacked | %s archive", len(m.artifactFiles), core.FormatSize(total), core.FormatSize(m.artifacts.Size()))
+		if n := len(m.artifactSelected); n > 0 {
+			statusLine += fmt.Sprintf(" | ◆ %d selected", n)
+		}
+		s += lipgloss.NewStyle().Faint(true).Render(statusLine) + "\n"
+	}
+	s += m.renderLoadStatus(artifactView) + "\n"
+
+	if m.artifacts != nil && len(m.artifactFiles) == 0 {
+		s += "The artifacts archive is empty.\n"
+	}
+
+	footer := "\n" + m.renderHelp("Navigation: ↑/↓ or j/k | g/G: first/last | Enter: preview | Space/A: select | d: download selected | D: download archive | r: reload | Esc: back")
+	start, end := m.scrollList(artifactView, m.artifactCursor, len(m.artifactFiles), m.listHeight(s, footer))
+
+	for i := start; i < end; i++ {
+		f := m.artifactFiles[i]
+
+		cursor := "  "
+		if i == m.artifactCursor {
+			cursor = "▶ "
+		}
+
+		line := fmt.Sprintf("%s%s%10s  %s", cursor, selectionMarker(m.artifactSelected, f.Path), core.FormatSize(f.Size), f.Path)
+		if i == m.artifactCursor {
+			line = selectedStyle.Render(line)
+		}
+		s += line + "\n"
+	}
+
+	s += footer
+	return s
+}
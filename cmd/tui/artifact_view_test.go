@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyArtifactDownload(t *testing.T) {
+	dir := artifactDir(7)
+	tests := []struct {
+		name         string
+		msg          artifactDownloadMsg
+		wantNotice   string
+		wantSelected int
+	}{
+		{
+			"one file",
+			artifactDownloadMsg{dir: dir, written: []string{filepath.Join(dir, "coverage", "summary.txt")}},
+			"✅ Saved " + filepath.Join("artifacts-7", "coverage", "summary.txt"), 0,
+		},
+		{
+			"several files",
+			artifactDownloadMsg{dir: dir, written: []string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "b", "c.txt")}},
+			"✅ Saved 2 files to artifacts-7" + string(filepath.Separator), 0,
+		},
+		{
+			"whole archive",
+			artifactDownloadMsg{written: []string{"artifacts-7.zip"}},
+			"✅ Saved artifacts-7.zip", 1,
+		},
+		{
+			"failed",
+			artifactDownloadMsg{dir: dir, err: errors.New("disk full")},
+			"disk full", 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model{artifactSelected: map[string]struct{}{"a.txt": {}}}
+			m.applyArtifactDownload(tt.msg)
+
+			if m.notice != tt.wantNotice {
+				t.Errorf("notice = %q, want %q", m.notice, tt.wantNotice)
+			}
+			if len(m.artifactSelected) != tt.wantSelected {
+				t.Errorf("%d files still selected, want %d", len(m.artifactSelected), tt.wantSelected)
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// bulkAction is an action on the selected pipelines or jobs, waiting for
+// confirmation
+type bulkAction struct {
+	kind    actionKind
+	service *core.Service
+	ids     []int // Selected items the action applies to
+	skipped int   // Selected items whose status doesn't allow the action
+}
+
+// bulkMsg reports the per-item outcome of a bulk action
+type bulkMsg struct {
+	action  bulkAction
+	results []core.BulkResult
+}
+
+func (b bulkAction) jobs() bool {
+	return b.kind == retryJobAction || b.kind == cancelJobAction
+}
+
+// noun names the items, e.g. "3 pipelines"
+func (b bulkAction) noun(n int) string {
+	if n == 1 {
+		return "1 " + b.unit()
+	}
+	return fmt.Sprintf("%d %ss", n, b.unit())
+}
+
+func (b bulkAction) unit() string {
+	if b.jobs() {
+		return "job"
+	}
+	return "pipeline"
+}
+
+// run performs the action on every item, reporting each outcome
+func (b bulkAction) run() tea.Cmd {
+	return func() tea.Msg {
+		s := b.service
+		fn := func(ctx context.Context, id int) (core.BulkResult, error) {
+			switch b.kind {
+			case retryPipelineAction:
+				p, err := s.RetryPipeline(ctx, id)
+				return pipelineResult(p), err
+			case cancelPipelineAction:
+				p, err := s.CancelPipeline(ctx, id)
+				return pipelineResult(p), err
+			case deletePipelineAction:
+				return core.BulkResult{Status: "deleted"}, s.DeletePipeline(ctx, id)
+			case retryJobAction:
+				// A retry creates a new job that takes the old one's place
+				job, err := s.RetryJob(ctx, id)
+				result := jobResult(job)
+				if job != nil {
+					result.NewID = job.ID
+				}
+				return result, err
+			case cancelJobAction:
+				job, err := s.CancelJob(ctx, id)
+				return jobResult(job), err
+			}
+			return core.BulkResult{}, fmt.Errorf("unsupported bulk action")
+		}
+		return bulkMsg{action: b, results: core.Bulk(context.Background(), b.ids, fn)}
+	}
+}
+
+func pipelineResult(p *core.Pipeline) core.BulkResult {
+	if p == nil {
+		return core.BulkResult{}
+	}
+	return core.BulkResult{Status: p.Status}
+}
+
+func jobResult(job *core.Job) core.BulkResult {
+	if job == nil {
+		return core.BulkResult{}
+	}
+	return core.BulkResult{Status: job.Status}
+}
+
+// toggleSelection selects or deselects the row under the cursor
+func (m *model) toggleSelection() {
+	switch m.currentView {
+	case pipelineView:
+		if m.pipelineCursor < len(m.pipelines) {
+			toggle(m.pipelineSelected, m.pipelines[m.pipelineCursor].ID)
+		}
+	case jobView:
+		if m.jobCursor < len(m.jobs) {
+			toggle(m.jobSelected, m.jobs[m.jobCursor].ID)
+		}
+	}
+}
+
+// toggleAll selects every row, or clears the selection if all are selected
+func (m *model) toggleAll() {
+	switch m.currentView {
+	case pipelineView:
+		ids := make([]int, 0, len(m.pipelines))
+		for _, p := range m.pipelines {
+			ids = append(ids, p.ID)
+		}
+		selectAll(m.pipelineSelected, ids)
+	case jobView:
+		ids := make([]int, 0, len(m.jobs))
+		for _, job := range m.jobs {
+			ids = append(ids, job.ID)
+		}
+		selectAll(m.jobSelected, ids)
+	}
+}
+
+func toggle[K comparable](selected map[K]struct{}, id K) {
+	if _, ok := selected[id]; ok {
+		delete(selected, id)
+	} else {
+		selected[id] = struct{}{}
+	}
+}
+
+func selectAll[K comparable](selected map[K]struct{}, ids []K) {
+	all := len(ids) > 0
+	for _, id := range ids {
+		if _, ok := selected[id]; !ok {
+			all = false
+			break
+		}
+	}
+	for _, id := range ids {
+		if all {
+			delete(selected, id)
+		} else {
+			selected[id] = struct{}{}
+		}
+	}
+}
+
+// pruneSelection drops selected IDs that are no longer listed
+func pruneSelection(selected map[int]struct{}, listed map[int]bool) {
+	for id := range selected {
+		if !listed[id] {
+			delete(selected, id)
+		}
+	}
+}
+
+// selectionMarker marks a selected row
+func selectionMarker[K comparable](selected map[K]struct{}, id K) string {
+	if _, ok := selected[id]; ok {
+		return selectedStyle.Render("◆") + " "
+	}
+	return "  "
+}
+
+// askBulkAction prepares a bulk action on the selection of the current
+// view. It returns false if nothing is selected there. Deleting without a
+// selection works on the pipeline under the cursor.
+func (m *model) askBulkAction(kind actionKind) bool {
+	var b bulkAction
+	switch m.currentView {
+	case pipelineView:
+		selected := m.pipelineSelected
+		if len(selected) == 0 && kind == deletePipelineAction && m.pipelineCursor < len(m.pipelines) {
+			selected = map[int]struct{}{m.pipelines[m.pipelineCursor].ID: {}}
+		}
+		if len(selected) == 0 {
+			return false
+		}
+		b = bulkAction{kind: kind, service: m.service}
+		for _, p := range m.pipelines {
+			if _, ok := selected[p.ID]; !ok {
+				continue
+			}
+			if bulkApplies(kind, p.Status) {
+				b.ids = append(b.ids, p.ID)
+			} else {
+				b.skipped++
+			}
+		}
+	case jobView:
+		if len(m.jobSelected) == 0 {
+			return false
+		}
+		switch kind {
+		case retryPipelineAction:
+			kind = retryJobAction
+		case cancelPipelineAction:
+			kind = cancelJobAction
+		default:
+			return false
+		}
+		b = bulkAction{kind: kind, service: m.jobService}
+		for _, job := range m.jobs {
+			if _, ok := m.jobSelected[job.ID]; !ok {
+				continue
+			}
+			if bulkApplies(kind, job.Status) {
+				b.ids = append(b.ids, job.ID)
+			} else {
+				b.skipped++
+			}
+		}
+	default:
+		return false
+	}
+
+	if len(b.ids) == 0 {
+		m.setNotice(fmt.Sprintf("None of the selected %ss can be %s", b.unit(), pastTense(b.kind)), true)
+		return true
+	}
+	m.pendingBulk = &b
+	return true
+}
+
+// bulkApplies reports whether a bulk action applies to an item's status:
+// retry only failed items, cancel only active ones, delete only old ones
+func bulkApplies(kind actionKind, status string) bool {
+	switch kind {
+	case retryPipelineAction:
+		return core.CanRetryPipeline(status)
+	case retryJobAction:
+		return status == "failed" || status == "canceled"
+	case cancelPipelineAction, cancelJobAction:
+		return core.IsActive(status)
+	case deletePipelineAction:
+		return !core.IsActive(status)
+	}
+	return false
+}
+
+func pastTense(kind actionKind) string {
+	switch kind {
+	case retryPipelineAction, retryJobAction:
+		return "retried"
+	case cancelPipelineAction, cancelJobAction:
+		return "canceled"
+	}
+	return "deleted"
+}
+
+// updateBulk handles the confirmation of a bulk action
+func (m model) updateBulk(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	b := *m.pendingBulk
+	m.pendingBulk = nil
+
+	switch msg.String() {
+	case "ctrl+c":
+		m.stopFollowingLogs()
+		return m, tea.Quit
+	case "y", "Y":
+		m.setNotice(fmt.Sprintf("%s %s...", action{kind: b.kind}.verb(), b.noun(len(b.ids))), false)
+		return m, b.run()
+	}
+	return m, nil
+}
+
+// applyBulk shows the per-item summary of a bulk action, updates the rows
+// that changed and clears the selection
+func (m *model) applyBulk(msg bulkMsg) tea.Cmd {
+	m.bulkResults = &msg
+
+	now := time.Now()
+	failed := 0
+	for _, r := range msg.results {
+		if r.Err != nil {
+			failed++
+			continue
+		}
+		m.applyBulkResult(msg.action.kind, r, now)
+	}
+
+	verb := action{kind: msg.action.kind}.verb()
+	if failed > 0 {
+		m.setNotice(fmt.Sprintf("%s: %d of %d failed", verb, failed, len(msg.results)), true)
+	} else {
+		m.setNotice(fmt.Sprintf("✅ %s: %s %s", verb, msg.action.noun(len(msg.results)), pastTense(msg.action.kind)), false)
+	}
+
+	if msg.action.jobs() {
+		m.jobSelected = make(map[int]struct{})
+	} else {
+		m.pipelineSelected = make(map[int]struct{})
+	}
+	return m.poll()
+}
+
+func (m *model) applyBulkResult(kind actionKind, r core.BulkResult, now time.Time) {
+	switch kind {
+	case deletePipelineAction:
+		for i, p := range m.pipelines {
+			if p.ID == r.ID {
+				m.pipelines = append(m.pipelines[:i], m.pipelines[i+1:]...)
+				break
+			}
+		}
+		if m.pipelineCursor >= len(m.pipelines) {
+			m.pipelineCursor = max(len(m.pipelines)-1, 0)
+		}
+	case retryPipelineAction, cancelPipelineAction:
+		for i := range m.pipelines {
+			if m.pipelines[i].ID == r.ID && r.Status != "" {
+				m.pipelines[i].Status = r.Status
+				m.changed[pipelineChangeKey(r.ID)] = now
+			}
+		}
+	case retryJobAction, cancelJobAction:
+		// A retried job's row becomes the new job, like for a single retry
+		for i := range m.jobs {
+			if m.jobs[i].ID == r.ID && r.Status != "" {
+				if r.NewID != 0 {
+					m.jobs[i].ID = r.NewID
+				}
+				m.jobs[i].Status = r.Status
+				m.changed[jobChangeKey(m.jobs[i].ID)] = now
+			}
+		}
+	}
+}
+
+// renderBulkPrompt asks to confirm a bulk action
+func (m model) renderBulkPrompt() string {
+	b := m.pendingBulk
+	prompt := fmt.Sprintf("⚠️  %s %s?", action{kind: b.kind}.verb(), b.noun(len(b.ids)))
+	if b.kind == deletePipelineAction {
+		prompt = fmt.Sprintf("⚠️  Permanently delete %s with their jobs and logs?", b.noun(len(b.ids)))
+	}
+	if b.skipped > 0 {
+		prompt += fmt.Sprintf(" (%d selected skipped, status doesn't allow it)", b.skipped)
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF87")).Bold(true).Render(prompt + " [y/N]")
+}
+
+// renderBulkResults lists the outcome of every item of the last bulk action
+func (m model) renderBulkResults() string {
+	msg := m.bulkResults
+	results := append([]core.BulkResult(nil), msg.results...)
+	// Failures first, they are what needs attention
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Err != nil && results[j].Err == nil })
+
+	lines := []string{lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("%s results:", action{kind: msg.action.kind}.verb()))}
+	const maxLines = 10
+	for i, r := range results {
+		if i == maxLines {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(results)-maxLines))
+			break
+		}
+		if r.Err != nil {
+			lines = append(lines, failedStyle.Render(fmt.Sprintf("  ✗ #%d: %v", r.ID, r.Err)))
+		} else {
+			id := fmt.Sprintf("#%d", r.ID)
+			if r.NewID != 0 && r.NewID != r.ID {
+				id += fmt.Sprintf(" → #%d", r.NewID)
+			}
+			lines = append(lines, successStyle.Render(fmt.Sprintf("  ✓ %s %s", id, r.Status)))
+		}
+	}
+	lines = append(lines, lipgloss.NewStyle().Faint(true).Render("  Press any key to dismiss"))
+	return strings.Join(lines, "\n")
+}
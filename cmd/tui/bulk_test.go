@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+func TestApplyBulkResult_Jobs(t *testing.T) {
+	tests := []struct {
+		name       string
+		kind       actionKind
+		result     core.BulkResult
+		wantID     int
+		wantStatus string
+	}{
+		{"retry takes the new job's ID", retryJobAction, core.BulkResult{ID: 1, NewID: 9, Status: "pending"}, 9, "pending"},
+		{"cancel keeps the ID", cancelJobAction, core.BulkResult{ID: 1, Status: "canceled"}, 1, "canceled"},
+		{"unknown status changes nothing", retryJobAction, core.BulkResult{ID: 1}, 1, "failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model{
+				jobs:    []core.Job{{ID: 1, Status: "failed"}, {ID: 2, Status: "success"}},
+				changed: make(map[string]time.Time),
+			}
+			m.applyBulkResult(tt.kind, tt.result, time.Now())
+
+			if job := m.jobs[0]; job.ID != tt.wantID || job.Status != tt.wantStatus {
+				t.Errorf("job = #%d %s, want #%d %s", job.ID, job.Status, tt.wantID, tt.wantStatus)
+			}
+			if m.jobs[1].ID != 2 {
+				t.Errorf("other job changed to #%d", m.jobs[1].ID)
+			}
+		})
+	}
+}
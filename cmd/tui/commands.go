@@ -0,0 +1,235 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// All GitLab I/O of the TUI runs in these commands, off the Update loop.
+// Each one answers with a typed message carrying its result or error.
+
+type pipelinesMsg struct {
+	pipelines []core.Pipeline
+	err       error
+}
+
+type dashboardMsg struct {
+	rows []core.DashboardRow
+	err  error
+}
+
+type mergeRequestsMsg struct {
+	mrs []core.MergeRequest
+	err error
+}
+
+type mrPipelinesMsg struct {
+	iid       int
+	pipelines []core.Pipeline
+	err       error
+}
+
+type jobsMsg struct {
+	service    *core.Service // Identifies the request, answers for a pipeline we left are dropped
+	pipelineID int
+	jobs       []core.Job
+	err        error
+}
+
+type treeMsg struct {
+	service    *core.Service
+	pipelineID int
+	tree       *core.PipelineNode
+	err        error
+}
+
+type treeJobsMsg struct {
+	pipelineID int
+	jobs       []core.Job
+	err        error
+}
+
+type needsMsg struct {
+	service    *core.Service
+	pipelineID int
+	needs      map[string][]string
+	err        error
+}
+
+func fetchPipelines(service *core.Service) tea.Cmd {
+	return func() tea.Msg {
+		pipelines, err := service.ListPipelines(context.Background())
+		return pipelinesMsg{pipelines: pipelines, err: err}
+	}
+}
+
+func fetchDashboard(service *core.Service) tea.Cmd {
+	return func() tea.Msg {
+		rows, err := service.Dashboard(context.Background())
+		return dashboardMsg{rows: rows, err: err}
+	}
+}
+
+// fetchMergeRequests loads the merge requests, reusing the pipelines and
+// approvals of known ones that haven't changed
+func fetchMergeRequests(service *core.Service, known []core.MergeRequest) tea.Cmd {
+	return func() tea.Msg {
+		mrs, err := service.RefreshMergeRequests(context.Background(), known)
+		return mergeRequestsMsg{mrs: mrs, err: err}
+	}
+}
+
+func fetchMRPipelines(service *core.Service, iid int) tea.Cmd {
+	return func() tea.Msg {
+		pipelines, err := service.MergeRequestPipelines(context.Background(), iid)
+		return mrPipelinesMsg{iid: iid, pipelines: pipelines, err: err}
+	}
+}
+
+func fetchJobs(service *core.Service, pipelineID int) tea.Cmd {
+	return func() tea.Msg {
+		jobs, err := service.ListJobs(context.Background(), pipelineID)
+		return jobsMsg{service: service, pipelineID: pipelineID, jobs: jobs, err: err}
+	}
+}
+
+func fetchTree(service *core.Service, pipelineID int) tea.Cmd {
+	return func() tea.Msg {
+		tree, err := service.PipelineTree(context.Background(), pipelineID)
+		return treeMsg{service: service, pipelineID: pipelineID, tree: tree, err: err}
+	}
+}
+
+func fetchTreeJobs(service *core.Service, pipelineID int) tea.Cmd {
+	return func() tea.Msg {
+		jobs, err := service.ListJobs(context.Background(), pipelineID)
+		return treeJobsMsg{pipelineID: pipelineID, jobs: jobs, err: err}
+	}
+}
+
+func fetchNeeds(service *core.Service, pipelineID int) tea.Cmd {
+	return func() tea.Msg {
+		needs, err := service.JobNeeds(context.Background(), pipelineID)
+		return needsMsg{service: service, pipelineID: pipelineID, needs: needs, err: err}
+	}
+}
+
+// loadStatus is the loading state of one view
+type loadStatus struct {
+	loading    bool
+	background bool   // Periodic refresh, data stays visible meanwhile
+	label      string // What is being loaded
+	err        error  // Error of the last load
+
+	// What the load is for in views showing one thing at a time, e.g. a
+	// pipelineTarget for the jobs of a pipeline; nil otherwise
+	target interface{}
+}
+
+// pipelineTarget is the pipeline a job list or test report is loaded for
+type pipelineTarget struct {
+	service    *core.Service
+	pipelineID int
+}
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+type spinnerMsg struct{}
+
+func spinnerTick() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg { return spinnerMsg{} })
+}
+
+// startLoading marks a view as loading and starts the spinner if needed
+func (m *model) startLoading(view viewMode, label string) tea.Cmd {
+	m.loads[view] = loadStatus{loading: true, label: label}
+	return m.spin()
+}
+
+// startLoadingFor is startLoading for one target of a view. A load still
+// running for another target doesn't hold it up, its reply is dropped.
+func (m *model) startLoadingFor(view viewMode, target interface{}, label string) tea.Cmd {
+	cmd := m.startLoading(view, label)
+	m.setLoadTarget(view, target)
+	return cmd
+}
+
+// startBackgroundFor is startBackground for one target of a view
+func (m *model) startBackgroundFor(view viewMode, target interface{}) tea.Cmd {
+	cmd := m.startBackground(view)
+	m.setLoadTarget(view, target)
+	return cmd
+}
+
+func (m *model) setLoadTarget(view viewMode, target interface{}) {
+	status := m.loads[view]
+	status.target = target
+	m.loads[view] = status
+}
+
+// loadingFor reports whether a view is loading target already
+func (m model) loadingFor(view viewMode, target interface{}) bool {
+	status := m.loads[view]
+	return status.loading && status.target == target
+}
+
+// finishLoading records the outcome of a view's load
+func (m *model) finishLoading(view viewMode, err error) {
+	m.loads[view] = loadStatus{err: err}
+}
+
+// dropStale handles a reply for a target the view no longer shows. The view
+// only stops loading if that reply is the one it was waiting for.
+func (m *model) dropStale(view viewMode, target interface{}) {
+	if m.loadingFor(view, target) {
+		m.finishLoading(view, nil)
+	}
+}
+
+// spin starts the spinner unless it is already ticking
+func (m *model) spin() tea.Cmd {
+	if m.spinning {
+		return nil
+	}
+	m.spinning = true
+	return spinnerTick()
+}
+
+// busy reports whether anything is still loading
+func (m model) busy() bool {
+	for _, status := range m.loads {
+		if status.loading {
+			return true
+		}
+	}
+	if f := m.runForm; f != nil && (f.loading || f.submitting) {
+		return true
+	}
+	return len(m.treePending) > 0
+}
+
+func (m model) spinner() string {
+	return spinnerFrames[m.spinnerFrame%len(spinnerFrames)]
+}
+
+// renderLoadStatus shows the spinner or last error of a view, "" if neither
+func (m model) renderLoadStatus(view viewMode) string {
+	status := m.loads[view]
+	switch {
+	case status.loading && status.background:
+		return ""
+	case status.loading:
+		return pendingStyle.Render(m.spinner()+" "+status.label+"...") + "\n"
+	case status.err != nil:
+		s := failedStyle.Render("❌ " + status.err.Error())
+		if view == m.homeView || view == jobView || view == mergeRequestView || view == mrPipelineView {
+			s += lipgloss.NewStyle().Faint(true).Render(" (press 'r' to retry)")
+		}
+		return s + "\n"
+	}
+	return ""
+}
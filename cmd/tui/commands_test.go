@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+func TestReloadJobsPerPipeline(t *testing.T) {
+	service := core.NewMockService(nil)
+	m := newModel(service, true, pipelineView)
+
+	if m.openJobs(service, 1) == nil {
+		t.Fatal("opening pipeline #1 fetched nothing")
+	}
+	if m.reloadJobs() != nil {
+		t.Error("reload refetched pipeline #1 while it was loading")
+	}
+	if m.openJobs(service, 2) == nil {
+		t.Fatal("opening pipeline #2 waited for pipeline #1")
+	}
+
+	next, _ := m.Update(jobsMsg{service: service, pipelineID: 1})
+	m = next.(model)
+	if !m.loads[jobView].loading {
+		t.Fatal("reply for pipeline #1 ended the load of pipeline #2")
+	}
+
+	next, _ = m.Update(jobsMsg{service: service, pipelineID: 2, jobs: []core.Job{{ID: 7}}})
+	m = next.(model)
+	if m.loads[jobView].loading {
+		t.Error("reply for pipeline #2 left the view loading")
+	}
+	if len(m.jobs) != 1 || m.jobs[0].ID != 7 {
+		t.Errorf("jobs = %v, want job #7", m.jobs)
+	}
+}
+
+func TestDropStale(t *testing.T) {
+	service := core.NewMockService(nil)
+	m := newModel(service, true, pipelineView)
+	m.startLoadingFor(jobView, pipelineTarget{service, 1}, "")
+
+	m.dropStale(jobView, pipelineTarget{service, 2})
+	if !m.loads[jobView].loading {
+		t.Error("reply for another pipeline ended the load")
+	}
+	m.dropStale(jobView, pipelineTarget{service, 1})
+	if m.loads[jobView].loading {
+		t.Error("dropped reply for the awaited pipeline left the view loading")
+	}
+}
+
+func TestReloadMRPipelinesPerMergeRequest(t *testing.T) {
+	service := core.NewMockService(nil)
+	m := newModel(service, true, pipelineView)
+
+	if m.openMRPipelines(core.MergeRequest{IID: 1}) == nil {
+		t.Fatal("opening !1 fetched nothing")
+	}
+	if m.reloadMRPipelines() != nil {
+		t.Error("reload refetched !1 while it was loading")
+	}
+	if m.openMRPipelines(core.MergeRequest{IID: 2}) == nil {
+		t.Fatal("opening !2 waited for !1")
+	}
+
+	next, _ := m.Update(mrPipelinesMsg{iid: 1})
+	m = next.(model)
+	if !m.loads[mrPipelineView].loading {
+		t.Fatal("reply for !1 ended the load of !2")
+	}
+
+	next, _ = m.Update(mrPipelinesMsg{iid: 2, pipelines: []core.Pipeline{{ID: 7}}})
+	m = next.(model)
+	if m.loads[mrPipelineView].loading {
+		t.Error("reply for !2 left the view loading")
+	}
+	if len(m.mrPipelines) != 1 || m.mrPipelines[0].ID != 7 {
+		t.Errorf("pipelines = %v, want pipeline #7", m.mrPipelines)
+	}
+}
+
+func TestReloadTestReportPerPipeline(t *testing.T) {
+	service := core.NewMockService(nil)
+	m := newModel(service, true, pipelineView)
+
+	m.jobService, m.selectedPipelineID = service, 1
+	if m.openTestReport() == nil {
+		t.Fatal("opening the report of pipeline #1 fetched nothing")
+	}
+	if m.reloadTestReport() != nil {
+		t.Error("reload refetched pipeline #1 while it was loading")
+	}
+	m.selectedPipelineID = 2
+	if m.openTestReport() == nil {
+		t.Fatal("opening the report of pipeline #2 waited for pipeline #1")
+	}
+
+	m.applyTestReport(testReportMsg{service: service, pipelineID: 1})
+	if !m.loads[testReportView].loading {
+		t.Fatal("report of pipeline #1 ended the load of pipeline #2")
+	}
+
+	m.applyTestReport(testReportMsg{service: service, pipelineID: 2, report: &core.TestReport{}})
+	if m.loads[testReportView].loading {
+		t.Error("report of pipeline #2 left the view loading")
+	}
+	if m.testReport == nil {
+		t.Error("report of pipeline #2 not shown")
+	}
+}
@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/config"
+	"github.com/rkristelijn/glab-tui/internal/core"
+	"github.com/rkristelijn/glab-tui/internal/gitlab"
+)
+
+// StartDashboard starts the TUI on the group dashboard
+func StartDashboard() error {
+	fmt.Println("🚀 Starting GitLab TUI Dashboard...")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	service, err := gitlab.NewGroupService(cfg)
+	if err != nil {
+		fmt.Printf("❌ Could not connect to GitLab: %v\n", err)
+		return err
+	}
+
+	return start(service, false, dashboardView)
+}
+
+// StartDashboardWithMockData starts the dashboard over the mock projects
+func StartDashboardWithMockData() error {
+	fmt.Println("🚀 Starting GitLab TUI Dashboard Demo Mode...")
+
+	return start(core.NewMockService(MockDashboardConfig()), true, dashboardView)
+}
+
+// MockDashboardConfig is the configuration demo dashboards run with
+func MockDashboardConfig() *config.Config {
+	return &config.Config{
+		GitLab: config.GitLabConfig{GroupPath: "company", MaxProjects: 50},
+		UI:     config.UIConfig{MaxPipelinesPerProject: 10},
+	}
+}
+
+// setDashboard applies loaded dashboard rows, keeping the cursor in bounds
+func (m *model) setDashboard(rows []core.DashboardRow) {
+	m.markChanges(dashboardStatuses(m.dashboard), dashboardStatuses(rows))
+	m.dashboard = rows
+	m.lastRefresh = time.Now()
+	if m.dashboardCursor >= len(m.dashboard) {
+		m.dashboardCursor = max(len(m.dashboard)-1, 0)
+	}
+}
+
+// selectedDashboardRow returns the row under the cursor if it has a pipeline
+func (m model) selectedDashboardRow() (core.DashboardRow, bool) {
+	if m.dashboardCursor >= len(m.dashboard) {
+		return core.DashboardRow{}, false
+	}
+	row := m.dashboard[m.dashboardCursor]
+	return row, row.Pipeline != nil
+}
+
+// dashboardService returns a service bound to the project of a row
+func (m model) dashboardService(row core.DashboardRow) *core.Service {
+	if row.Project.PathWithNamespace != "" {
+		return m.service.ForProject(row.Project.PathWithNamespace)
+	}
+	return m.service.ForProject(core.ProjectRef(row.Project.ID))
+}
+
+// updateDashboard handles keys in the dashboard view
+func (m model) updateDashboard(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "up", "k":
+		if m.dashboardCursor > 0 {
+			m.dashboardCursor--
+		}
+	case "down", "j":
+		if m.dashboardCursor < len(m.dashboard)-1 {
+			m.dashboardCursor++
+		}
+	case "g":
+		m.dashboardCursor = 0
+	case "G":
+		m.dashboardCursor = max(len(m.dashboard)-1, 0)
+	case "ctrl+u":
+		m.dashboardCursor = max(m.dashboardCursor-5, 0)
+	case "ctrl+d":
+		m.dashboardCursor = max(min(m.dashboardCursor+5, len(m.dashboard)-1), 0)
+	case "r":
+		return m, m.refresh()
+	case "enter":
+		if row, ok := m.selectedDashboardRow(); ok {
+			return m, tea.Batch(tea.ClearScreen, m.openJobs(m.dashboardService(row), row.Pipeline.ID))
+		}
+	}
+	return m, nil
+}
+
+func (m model) renderDashboardView(title string) string {
+	header := headerStyle.Render("📊 Group Dashboard")
+
+	projects := make(map[int]bool)
+	for _, row := range m.dashboard {
+		projects[row.Project.ID] = true
+	}
+	statusLine := fmt.Sprintf("📁 %d projects | %d project/ref rows", len(projects), len(m.dashboard))
+
+	s := title + "\n"
+	s += header + "\n"
+	s += lipgloss.NewStyle().Faint(true).Render(statusLine) + "\n"
+	s += m.renderLoadStatus(dashboardView) + "\n"
+
+	if len(m.dashboard) == 0 {
+		if m.loads[dashboardView].loading || m.loads[dashboardView].err != nil {
+			return s
+		}
+		s += "No projects match the dashboard filters.\n"
+		s += lipgloss.NewStyle().Faint(true).Render("Check GITLAB_GROUP_PATH, GITLAB_PROJECT_PATTERN and MIN_ACTIVITY_DAYS, then press 'r'") + "\n"
+		return s
+	}
+
+	footer := "\n" + m.renderHelp("Navigation: ↑/↓ or j/k | Ctrl+U/D: page up/down | g/G: first/last | Enter: view jobs | t: tree | R: retry | C: cancel | r: refresh | q: quit")
+	start, end := m.scrollList(dashboardView, m.dashboardCursor, len(m.dashboard), m.listHeight(s, footer))
+
+	for i := start; i < end; i++ {
+		row := m.dashboard[i]
+
+		cursor := "  "
+		if i == m.dashboardCursor {
+			cursor = "▶ "
+		}
+
+		project := row.Project.PathWithNamespace
+		if project == "" {
+			project = row.Project.Name
+		}
+
+		var line string
+		switch {
+		case row.Err != nil:
+			line = fmt.Sprintf("%s%s %-35s %s", cursor, failedStyle.Render("!"), truncateString(project, 35), truncateString(row.Err.Error(), 60))
+		case row.Pipeline == nil:
+			line = fmt.Sprintf("%s%s %-35s %-25s %s", cursor, pendingStyle.Render("○"), truncateString(project, 35), truncateString(getBetterBranchName(row.Ref), 25), "no pipelines")
+		default:
+			pipeline := row.Pipeline
+			when := pipeline.Duration
+			if age := core.FormatAge(pipeline.UpdatedAt); age != "" {
+				when = age
+			}
+			line = fmt.Sprintf("%s%s %-35s %-25s #%-11d %-10s %s",
+				cursor,
+				getStyledStatus(pipeline.Status, getStatusIcon(pipeline.Status)),
+				truncateString(project, 35),
+				truncateString(getBetterBranchName(row.Ref), 25),
+				pipeline.ID,
+				pipeline.Status,
+				when)
+		}
+
+		s += m.highlight(line, dashboardChangeKey(row), i == m.dashboardCursor) + "\n"
+	}
+
+	s += footer
+	return s
+}
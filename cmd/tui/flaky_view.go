@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/analysis"
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+type flakyMsg struct {
+	ref    string
+	report *analysis.Report
+	err    error
+}
+
+// fetchFlaky analyzes the pipeline history of a ref, the default ref if
+// empty
+func fetchFlaky(service *core.Service, ref string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if ref == "" {
+			ref = service.DefaultRef(ctx)
+		}
+		report, err := analysis.Analyze(ctx, service, analysis.Options{Ref: ref, Tests: true})
+		return flakyMsg{ref: ref, report: report, err: err}
+	}
+}
+
+// openFlaky switches to the flaky report of the selected pipeline's ref
+func (m *model) openFlaky() tea.Cmd {
+	m.flakyRef = ""
+	if m.pipelineCursor < len(m.pipelines) {
+		m.flakyRef = m.pipelines[m.pipelineCursor].Ref
+	}
+	m.flakyReport = nil
+	m.flakyCursor = 0
+	m.currentView = flakyView
+	return m.analyzeFlaky()
+}
+
+// reloadFlaky analyzes the pipeline history again unless it is already
+// being analyzed
+func (m *model) reloadFlaky() tea.Cmd {
+	if m.loads[flakyView].loading {
+		return nil
+	}
+	return m.analyzeFlaky()
+}
+
+// analyzeFlaky starts the analysis of the selected ref. An analysis still
+// running for another ref is ignored when it finishes.
+func (m *model) analyzeFlaky() tea.Cmd {
+	label := fmt.Sprintf("Analyzing the last %d pipelines", analysis.DefaultLast)
+	if m.flakyRef != "" {
+		label += " of " + m.flakyRef
+	}
+	return tea.Batch(m.startLoading(flakyView, label), fetchFlaky(m.service, m.flakyRef))
+}
+
+// applyFlaky shows a finished analysis, keeping the cursor in bounds
+func (m *model) applyFlaky(msg flakyMsg) {
+	if m.flakyRef != "" && msg.ref != m.flakyRef {
+		return
+	}
+	m.finishLoading(flakyView, msg.err)
+	if msg.err != nil {
+		return
+	}
+	m.flakyRef = msg.ref
+	m.flakyReport = msg.report
+	m.lastRefresh = time.Now()
+	if m.flakyCursor >= len(msg.report.Flaky) {
+		m.flakyCursor = max(len(msg.report.Flaky)-1, 0)
+	}
+}
+
+// updateFlaky handles keys in the flaky report
+func (m model) updateFlaky(key string) (tea.Model, tea.Cmd) {
+	var flaky []analysis.Flaky
+	if m.flakyReport != nil {
+		flaky = m.flakyReport.Flaky
+	}
+	switch key {
+	case "up", "k":
+		if m.flakyCursor > 0 {
+			m.flakyCursor--
+		}
+	case "down", "j":
+		if m.flakyCursor < len(flaky)-1 {
+			m.flakyCursor++
+		}
+	case "g":
+		m.flakyCursor = 0
+	case "G":
+		m.flakyCursor = max(len(flaky)-1, 0)
+	case "ctrl+u":
+		m.flakyCursor = max(m.flakyCursor-5, 0)
+	case "ctrl+d":
+		m.flakyCursor = max(min(m.flakyCursor+5, len(flaky)-1), 0)
+	case "r":
+		return m, m.reloadFlaky()
+	case "enter":
+		// Open the newest pipeline where it flipped
+		if m.flakyCursor < len(flaky) && len(flaky[m.flakyCursor].Pipelines) > 0 {
+			return m, tea.Batch(tea.ClearScreen, m.openJobs(m.service, flaky[m.flakyCursor].Pipelines[0]))
+		}
+	case "esc":
+		m.currentView = m.homeView
+		return m, tea.ClearScreen
+	}
+	return m, nil
+}
+
+func (m model) renderFlakyView(title string) string {
+	ref := m.flakyRef
+	if ref == "" {
+		ref = "default branch"
+	}
+	header := headerStyle.Render(fmt.Sprintf("🔍 Flaky Jobs and Tests (%s)", ref))
+
+	s := title + "\n"
+	s += header + "\n"
+	r := m.flakyReport
+	if r != nil {
+		statusLine := fmt.Sprintf("📊 %d finished pipelines | %d commits | %d test reports compared | %d flaky",
+			r.Pipelines, r.Commits, r.TestReports, len(r.Flaky))
+		s += lipgloss.NewStyle().Faint(true).Render(statusLine) + "\n"
+	}
+	s += m.renderLoadStatus(flakyView) + "\n"
+
+	if r != nil && len(r.Flaky) == 0 {
+		s += successStyle.Render("✅ No job or test both passed and failed on the same code.") + "\n"
+		if r.Pipelines > 0 && r.Pipelines == r.Commits {
+			s += lipgloss.NewStyle().Faint(true).Render("Every pipeline ran a different commit, so only retries could show flakiness.") + "\n"
+		}
+	}
+
+	if r != nil && len(r.Flaky) > 0 {
+		s += lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("  %4s %-4s %-60s %-14s %-12s %s", "#", "Kind", "Name", "Flips", "Failed", "Pipelines")) + "\n"
+	}
+
+	footer := "\n" + m.renderHelp("Flips: ↻ passed on retry, ≡ passed and failed on the same commit")
+	footer += "\n" + m.renderHelp("Navigation: ↑/↓ or j/k | g/G: first/last | Enter: jobs of the newest flipped pipeline | r: refresh | Esc: back")
+	n := 0
+	if r != nil {
+		n = len(r.Flaky)
+	}
+	start, end := m.scrollList(flakyView, m.flakyCursor, n, m.listHeight(s, footer))
+
+	for i := start; i < end; i++ {
+		f := r.Flaky[i]
+
+		cursor := "  "
+		if i == m.flakyCursor {
+			cursor = "▶ "
+		}
+
+		pipelines := make([]string, 0, len(f.Pipelines))
+		for _, id := range f.Pipelines {
+			pipelines = append(pipelines, "#"+strconv.Itoa(id))
+		}
+
+		line := fmt.Sprintf("%s%3d. %-4s %-60s %-14s %-12s %s",
+			cursor,
+			i+1,
+			f.Kind,
+			truncateString(f.Name, 60),
+			fmt.Sprintf("%d (%d↻ %d≡)", f.Flips(), f.RetryFlips, f.CommitFlips),
+			fmt.Sprintf("%d/%d %.0f%%", f.Failures, f.Runs, f.FailureRate()*100),
+			truncateString(strings.Join(pipelines, " "), 40))
+		if i == m.flakyCursor {
+			line = selectedStyle.Render(line)
+		}
+		s += line + "\n"
+	}
+
+	s += footer
+	return s
+}
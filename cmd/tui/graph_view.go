@@ -0,0 +1,304 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+const (
+	graphColumnWidth = 26  // Width of a stage column
+	graphGapWidth    = 5   // Width of the edge lane between two columns
+	graphMaxWidth    = 120 // Width used until the terminal reports its own
+)
+
+// Directions a box-drawing cell connects to
+const (
+	edgeUp uint8 = 1 << iota
+	edgeDown
+	edgeLeft
+	edgeRight
+	edgeDashed // Edge from a stage further left than the neighbouring one
+)
+
+var boxChars = map[uint8]string{
+	edgeLeft | edgeRight:                     "─",
+	edgeLeft:                                 "─",
+	edgeRight:                                "─",
+	edgeUp | edgeDown:                        "│",
+	edgeUp:                                   "│",
+	edgeDown:                                 "│",
+	edgeDown | edgeRight:                     "┌",
+	edgeDown | edgeLeft:                      "┐",
+	edgeUp | edgeRight:                       "└",
+	edgeUp | edgeLeft:                        "┘",
+	edgeUp | edgeDown | edgeRight:            "├",
+	edgeUp | edgeDown | edgeLeft:             "┤",
+	edgeDown | edgeLeft | edgeRight:          "┬",
+	edgeUp | edgeLeft | edgeRight:            "┴",
+	edgeUp | edgeDown | edgeLeft | edgeRight: "┼",
+}
+
+// openGraph switches the job view to the stage-column graph, loading the
+// needs: relations of the pipeline if the backend can read them
+func (m *model) openGraph() tea.Cmd {
+	m.graphNeeds = nil
+	m.graphNeedsErr = nil
+	m.graphCol = 0
+	m.graphRow = 0
+	m.currentView = graphView
+	return tea.Batch(m.startLoading(graphView, "Loading job dependencies"), fetchNeeds(m.jobService, m.selectedPipelineID))
+}
+
+// graphStages returns the stage columns of the current pipeline
+func (m model) graphStages() []*treeStage {
+	return pipelineStages(m.jobs, nil)
+}
+
+// selectedGraphJob returns the job under the graph cursor
+func (m model) selectedGraphJob() (core.Job, bool) {
+	stages := m.graphStages()
+	if m.graphCol >= len(stages) || m.graphRow >= len(stages[m.graphCol].jobs) {
+		return core.Job{}, false
+	}
+	return stages[m.graphCol].jobs[m.graphRow], true
+}
+
+// updateGraph handles keys in the graph view
+func (m model) updateGraph(key string) (tea.Model, tea.Cmd) {
+	stages := m.graphStages()
+	if len(stages) == 0 {
+		if key == "v" {
+			m.currentView = jobView
+		}
+		return m, nil
+	}
+
+	switch key {
+	case "v":
+		m.currentView = jobView
+		return m, tea.ClearScreen
+	case "left", "h":
+		if m.graphCol > 0 {
+			m.graphCol--
+		}
+	case "right", "l":
+		if m.graphCol < len(stages)-1 {
+			m.graphCol++
+		}
+	case "up", "k":
+		if m.graphRow > 0 {
+			m.graphRow--
+		}
+	case "down", "j":
+		m.graphRow++
+	case "enter":
+		if job, ok := m.selectedGraphJob(); ok {
+			m.logParent = graphView
+			return m, tea.Batch(tea.ClearScreen, m.loadLogs(job))
+		}
+	}
+
+	// Keep the cursor on a job of the current stage
+	if rows := len(stages[m.graphCol].jobs); m.graphRow >= rows {
+		m.graphRow = rows - 1
+	}
+	return m, nil
+}
+
+func (m model) renderGraphView(title string) string {
+	header := headerStyle.Render(fmt.Sprintf("📈 Pipeline Graph (Pipeline #%d)", m.selectedPipelineID))
+	stages := m.graphStages()
+
+	statusLine := fmt.Sprintf("📊 %d stages | %d jobs", len(stages), len(m.jobs))
+	for _, stage := range stages {
+		if status := stage.status(); status != "success" && status != "skipped" && status != "manual" {
+			statusLine += fmt.Sprintf(" | blocking stage: %s (%s)", stage.name, status)
+			break
+		}
+	}
+
+	s := title + "\n"
+	s += header + "\n"
+	s += lipgloss.NewStyle().Faint(true).Render(statusLine) + "\n"
+	s += m.renderLoadStatus(graphView) + "\n"
+
+	if len(stages) == 0 {
+		return s + "No jobs in this pipeline\n"
+	}
+
+	// Scroll horizontally so the selected stage is visible
+	width := graphMaxWidth
+	if m.width > 0 {
+		width = m.width
+	}
+	visible := max((width+graphGapWidth)/(graphColumnWidth+graphGapWidth), 1)
+	first := 0
+	if m.graphCol >= visible {
+		first = m.graphCol - visible + 1
+	}
+	last := min(first+visible, len(stages))
+
+	position := make(map[string][2]int) // Job name -> stage, row
+	rows := 0
+	for col, stage := range stages {
+		for row, job := range stage.jobs {
+			position[job.Name] = [2]int{col, row}
+		}
+		rows = max(rows, len(stage.jobs))
+	}
+
+	// Stage headers
+	var line strings.Builder
+	for col := first; col < last; col++ {
+		status := stages[col].status()
+		name := truncateString(stages[col].name, graphColumnWidth-3)
+		line.WriteString(getStyledStatus(status, getStatusIcon(status)) + " ")
+		line.WriteString(lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("%-*s", graphColumnWidth-2, name)))
+		if col < last-1 {
+			line.WriteString(strings.Repeat(" ", graphGapWidth))
+		}
+	}
+	s += line.String() + "\n"
+
+	// Scroll vertically so the selected job is visible
+	footer := "\n" + m.graphDetails()
+	footer += "\n" + m.renderHelp("Navigation: ←/→ or h/l: stage | ↑/↓ or j/k: job | Enter: view logs | R/C/p: retry/cancel/play | a: artifacts | T: tests | v: job list | Esc: back")
+	top, bottom := m.scrollList(graphView, m.graphRow, rows, m.listHeight(s, footer))
+
+	lanes := make([][][]uint8, len(stages))
+	for col := first + 1; col < last; col++ {
+		lanes[col] = m.edgeLane(stages, col, rows, position)
+	}
+
+	for row := top; row < bottom; row++ {
+		line.Reset()
+		for col := first; col < last; col++ {
+			if col > first {
+				line.WriteString(renderLane(lanes[col][row]))
+			}
+			line.WriteString(m.graphCell(stages[col], col, row))
+		}
+		s += strings.TrimRight(line.String(), " ") + "\n"
+	}
+
+	s += footer
+	return s
+}
+
+// graphCell renders one job of a stage column, padded to the column width
+func (m model) graphCell(stage *treeStage, col, row int) string {
+	if row >= len(stage.jobs) {
+		return strings.Repeat(" ", graphColumnWidth)
+	}
+	job := stage.jobs[row]
+
+	nameWidth := graphColumnWidth - 3
+	duration := ""
+	if job.Duration != "" && len(job.Duration)+8 < nameWidth {
+		duration = job.Duration
+		nameWidth -= len(duration) + 1
+	}
+	text := fmt.Sprintf("%-*s", nameWidth, truncateString(job.Name, nameWidth))
+	if duration != "" {
+		text += " " + lipgloss.NewStyle().Faint(true).Render(duration)
+	}
+
+	text = m.highlight(text, jobChangeKey(job.ID), col == m.graphCol && row == m.graphRow)
+	return getStyledStatus(job.Status, getStatusIcon(job.Status)) + " " + text + " "
+}
+
+// edgeLane draws the needs: edges arriving at stage col into the lane left
+// of it. Edges from the neighbouring stage are routed with box characters;
+// edges from further left are drawn dashed on the target row.
+func (m model) edgeLane(stages []*treeStage, col, rows int, position map[string][2]int) [][]uint8 {
+	lane := make([][]uint8, rows)
+	for i := range lane {
+		lane[i] = make([]uint8, graphGapWidth)
+	}
+	mid := graphGapWidth / 2
+
+	for target, job := range stages[col].jobs {
+		for _, need := range m.graphNeeds[job.Name] {
+			from, ok := position[need]
+			if !ok || from[0] >= col {
+				continue
+			}
+			if from[0] < col-1 {
+				for x := 0; x < graphGapWidth; x++ {
+					lane[target][x] |= edgeDashed
+				}
+				continue
+			}
+
+			source := from[1]
+			for x := 0; x < mid; x++ {
+				lane[source][x] |= edgeLeft | edgeRight
+			}
+			for x := mid + 1; x < graphGapWidth; x++ {
+				lane[target][x] |= edgeLeft | edgeRight
+			}
+			switch {
+			case source == target:
+				lane[source][mid] |= edgeLeft | edgeRight
+			case source < target:
+				lane[source][mid] |= edgeLeft | edgeDown
+				for y := source + 1; y < target; y++ {
+					lane[y][mid] |= edgeUp | edgeDown
+				}
+				lane[target][mid] |= edgeUp | edgeRight
+			default:
+				lane[source][mid] |= edgeLeft | edgeUp
+				for y := target + 1; y < source; y++ {
+					lane[y][mid] |= edgeUp | edgeDown
+				}
+				lane[target][mid] |= edgeDown | edgeRight
+			}
+		}
+	}
+	return lane
+}
+
+// renderLane turns one row of an edge lane into box characters. Solid
+// edges win over dashed ones sharing a cell.
+func renderLane(cells []uint8) string {
+	var b strings.Builder
+	for _, cell := range cells {
+		solid := cell &^ edgeDashed
+		switch {
+		case solid != 0:
+			b.WriteString(boxChars[solid])
+		case cell == edgeDashed:
+			b.WriteString("╌")
+		default:
+			b.WriteString(" ")
+		}
+	}
+	return lipgloss.NewStyle().Faint(true).Render(b.String())
+}
+
+// graphDetails describes the selected job and what it needs
+func (m model) graphDetails() string {
+	job, ok := m.selectedGraphJob()
+	if !ok {
+		return ""
+	}
+
+	details := fmt.Sprintf("%s %s — %s", getStyledStatus(job.Status, getStatusIcon(job.Status)), job.Name, job.Status)
+	if job.Duration != "" {
+		details += " — " + job.Duration
+	}
+
+	switch {
+	case m.graphNeedsErr != nil:
+		details += lipgloss.NewStyle().Faint(true).Render(fmt.Sprintf("\n   needs: unavailable (%v)", m.graphNeedsErr))
+	case len(m.graphNeeds[job.Name]) > 0:
+		details += "\n   needs: " + strings.Join(m.graphNeeds[job.Name], ", ")
+	default:
+		details += "\n   needs: previous stages"
+	}
+	return details + "\n"
+}
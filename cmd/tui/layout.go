@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// defaultHeight is the terminal height assumed until the terminal reports
+// its size
+const defaultHeight = 24
+
+// minListHeight keeps lists usable in very small terminals
+const minListHeight = 3
+
+// screenHeight returns the height of the terminal
+func (m model) screenHeight() int {
+	if m.height <= 0 {
+		return defaultHeight
+	}
+	return m.height
+}
+
+// listHeight returns how many one-line rows of a list fit on the screen
+// between what a view renders above and below the list
+func (m model) listHeight(above, below string) int {
+	used := strings.Count(above, "\n") + strings.Count(below, "\n") + 1
+	used += strings.Count(m.renderBottom(), "\n")
+	return max(m.screenHeight()-used, minListHeight)
+}
+
+// renderHelp renders the key help of a view, wrapped to the terminal width
+// so no key falls off the screen
+func (m model) renderHelp(help string) string {
+	if m.width > 0 {
+		help = ansi.Wrap(help, m.width, "")
+	}
+	return lipgloss.NewStyle().Faint(true).Render(help)
+}
+
+// renderBottom renders what goes below every view: a prompt or summary, if
+// any, and the status bar
+func (m model) renderBottom() string {
+	var s string
+	switch {
+	case m.pendingAction != nil:
+		s += "\n" + m.renderAction()
+	case m.runForm != nil:
+		s += "\n" + m.renderRunForm()
+	case m.pendingBulk != nil:
+		s += "\n" + m.renderBulkPrompt()
+	case m.bulkResults != nil:
+		s += "\n" + m.renderBulkResults()
+	}
+	return s + "\n" + m.renderStatusBar()
+}
+
+// scrollList returns the part [start, end) of a view's list of n rows that
+// fits in size rows. The list stays where it was scrolled to until the
+// cursor leaves it, and the view remembers where that is.
+func (m model) scrollList(view viewMode, cursor, n, size int) (int, int) {
+	start, end := listWindow(m.listOffsets[view], cursor, n, size)
+	if m.listOffsets != nil {
+		m.listOffsets[view] = start
+	}
+	return start, end
+}
+
+// listWindow returns the part [start, end) of a list of n rows that fits in
+// size rows, starting at offset unless that leaves the cursor out or rows
+// blank at the end
+func listWindow(offset, cursor, n, size int) (int, int) {
+	start := min(offset, cursor)
+	if cursor >= start+size {
+		start = cursor - size + 1
+	}
+	start = max(min(start, n-size), 0)
+	return start, min(start+size, n)
+}
@@ -0,0 +1,51 @@
+package tui
+
+import "testing"
+
+func TestListWindow(t *testing.T) {
+	tests := []struct {
+		name               string
+		offset, cursor, n  int
+		size               int
+		wantStart, wantEnd int
+	}{
+		{"fits", 0, 3, 5, 10, 0, 5},
+		{"cursor in the window", 0, 4, 20, 10, 0, 10},
+		{"cursor below scrolls down", 0, 12, 20, 10, 3, 13},
+		{"cursor above scrolls up", 8, 5, 20, 10, 5, 15},
+		{"moving up inside the window stays", 10, 15, 20, 10, 10, 20},
+		{"list shrank", 15, 4, 8, 5, 3, 8},
+		{"empty list", 4, 0, 0, 5, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := listWindow(tt.offset, tt.cursor, tt.n, tt.size)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("listWindow(%d, %d, %d, %d) = %d, %d, want %d, %d",
+					tt.offset, tt.cursor, tt.n, tt.size, start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestScrollList_KeepsWindowScrollingBack(t *testing.T) {
+	m := model{listOffsets: make(map[viewMode]int)}
+	const n, size = 50, 10
+
+	// Down to the bottom, then back up a few rows
+	for cursor := 0; cursor < n; cursor++ {
+		m.scrollList(jobView, cursor, n, size)
+	}
+	for cursor := n - 1; cursor >= n-size; cursor-- {
+		if start, _ := m.scrollList(jobView, cursor, n, size); start != n-size {
+			t.Fatalf("cursor %d: window starts at %d, want it to stay at %d", cursor, start, n-size)
+		}
+	}
+	if start, _ := m.scrollList(jobView, n-size-1, n, size); start != n-size-1 {
+		t.Errorf("window starts at %d, want %d once the cursor leaves it", start, n-size-1)
+	}
+
+	if start, _ := m.scrollList(pipelineView, 0, n, size); start != 0 {
+		t.Errorf("other view starts at %d, want its own offset 0", start)
+	}
+}
@@ -0,0 +1,261 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/joblog"
+)
+
+// maxSearchHistory is how many past queries the log search remembers
+const maxSearchHistory = 50
+
+// logSearch is the search of the log view. Matches are highlighted where the
+// lines are, or shown on their own in filter mode.
+type logSearch struct {
+	editing bool   // The query is being typed
+	query   string // Empty when not searching
+	regex   bool   // The query is a regular expression, not plain text
+	filter  bool   // Show only the matching lines
+
+	pattern *regexp.Regexp // Compiled query, nil without one
+	err     error          // Why the regex doesn't compile, searched for as text then
+	matches []int          // Matching lines, in order
+
+	history []string // Past queries, oldest first
+	recall  int      // Position in history while editing, len(history) for the new query
+	saved   string   // Query before editing, restored on Esc
+	origin  int      // Cursor before editing, where the search starts
+}
+
+// compileSearch compiles the query and finds its matches in the log
+func (m *model) compileSearch() {
+	s := &m.search
+	s.pattern, s.err = nil, nil
+	if s.query != "" {
+		s.pattern, s.err = joblog.CompileQuery(s.query, s.regex)
+	}
+	m.search.matches = nil
+	m.findMatches(0)
+}
+
+// findMatches finds the lines from first on matching the search, on the
+// text as shown so colors and progress overwrites don't get in the way
+func (m *model) findMatches(first int) {
+	m.search.matches = m.search.matches[:sort.SearchInts(m.search.matches, first)]
+	if m.search.pattern == nil {
+		return
+	}
+	for i := first; i < len(m.logLines); i++ {
+		if m.search.pattern.MatchString(m.logLines[i].Text) {
+			m.search.matches = append(m.search.matches, i)
+		}
+	}
+}
+
+// startSearch opens the search input with an empty query
+func (m *model) startSearch() {
+	s := &m.search
+	s.editing = true
+	s.saved = s.query
+	s.origin = m.logCursor
+	s.recall = len(s.history)
+	s.query = ""
+	m.compileSearch()
+}
+
+// editSearch changes the query being typed and moves to its first match
+// from where the search started
+func (m *model) editSearch(query string) {
+	m.search.query = query
+	m.compileSearch()
+	m.logCursor = m.search.origin
+	if len(m.search.matches) > 0 {
+		m.jumpToMatch(true, true)
+	}
+}
+
+// commitSearch ends editing, keeping the query and adding it to the history
+func (m *model) commitSearch() {
+	s := &m.search
+	s.editing = false
+	if s.query == "" {
+		return
+	}
+	for i, past := range s.history {
+		if past == s.query {
+			s.history = append(s.history[:i], s.history[i+1:]...)
+			break
+		}
+	}
+	s.history = append(s.history, s.query)
+	if len(s.history) > maxSearchHistory {
+		s.history = s.history[len(s.history)-maxSearchHistory:]
+	}
+}
+
+// cancelSearch ends editing and goes back to the search before it
+func (m *model) cancelSearch() {
+	m.search.editing = false
+	m.search.query = m.search.saved
+	m.compileSearch()
+	m.logCursor = m.search.origin
+}
+
+// clearSearch drops the query, leaving the modes and the history
+func (m *model) clearSearch() {
+	m.search.query = ""
+	m.compileSearch()
+}
+
+// jumpToMatch moves the cursor to the next or previous match, wrapping
+// around, and unfolds the sections it is in. The match under the cursor
+// counts if here is set.
+func (m *model) jumpToMatch(forward, here bool) {
+	matches := m.search.matches
+	if len(matches) == 0 {
+		return
+	}
+	from := m.logCursor + 1
+	if here {
+		from = m.logCursor
+	}
+	target := matches[sort.SearchInts(matches, from)%len(matches)]
+	if !forward {
+		prev := sort.SearchInts(matches, m.logCursor) - 1
+		if prev < 0 {
+			prev = len(matches) - 1
+		}
+		target = matches[prev]
+	}
+	m.revealLogLine(target)
+	m.logCursor = target
+}
+
+// updateSearchInput handles keys while the query is typed
+func (m model) updateSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := &m.search
+	switch msg.String() {
+	case "enter":
+		m.commitSearch()
+	case "esc":
+		m.cancelSearch()
+	case "ctrl+c":
+		m.stopFollowingLogs()
+		return m, tea.Quit
+	case "backspace":
+		if s.query != "" {
+			_, size := utf8.DecodeLastRuneInString(s.query)
+			m.editSearch(s.query[:len(s.query)-size])
+		}
+	case "ctrl+u":
+		m.editSearch("")
+	case "up":
+		// Older queries from the history
+		if s.recall > 0 {
+			s.recall--
+			m.editSearch(s.history[s.recall])
+		}
+	case "down":
+		if s.recall < len(s.history)-1 {
+			s.recall++
+			m.editSearch(s.history[s.recall])
+		} else if s.recall < len(s.history) {
+			s.recall = len(s.history)
+			m.editSearch("")
+		}
+	case "ctrl+r":
+		s.regex = !s.regex
+		m.editSearch(s.query)
+	case "ctrl+f":
+		s.filter = !s.filter
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.editSearch(s.query + string(msg.Runes))
+		}
+	}
+	return m, nil
+}
+
+// updateLogSearch handles the search keys of the log view outside the
+// search input, and reports whether key was one of them
+func (m *model) updateLogSearch(key string) bool {
+	switch key {
+	case "/":
+		m.startSearch()
+	case "n", "N":
+		if m.search.query == "" {
+			return false
+		}
+		m.jumpToMatch(key == "n", false)
+	case "ctrl+r":
+		m.search.regex = !m.search.regex
+		m.compileSearch()
+	case "ctrl+f":
+		m.search.filter = !m.search.filter
+	case "esc":
+		// The first Esc ends the search, the next one leaves the log
+		if m.search.query == "" {
+			return false
+		}
+		m.clearSearch()
+	default:
+		return false
+	}
+	return true
+}
+
+// matchPosition returns which match the cursor is on, counting from 1, or
+// 0 when it is between matches
+func (m model) matchPosition() int {
+	i := sort.SearchInts(m.search.matches, m.logCursor)
+	if i < len(m.search.matches) && m.search.matches[i] == m.logCursor {
+		return i + 1
+	}
+	return 0
+}
+
+// renderSearchBar draws the query with its modes and match counter, empty
+// when not searching
+func (m model) renderSearchBar() string {
+	s := m.search
+	if !s.editing && s.query == "" {
+		return ""
+	}
+
+	query := s.query
+	if s.editing {
+		query += "▏"
+	}
+	bar := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Render("🔍 /" + query)
+
+	var modes []string
+	if s.regex {
+		modes = append(modes, "regex")
+	}
+	if s.filter {
+		modes = append(modes, "filter")
+	}
+	for _, mode := range modes {
+		bar += " " + lipgloss.NewStyle().Reverse(true).Render(" "+mode+" ")
+	}
+
+	if s.err != nil {
+		bar += " " + failedStyle.Render("invalid regex, searching as text: "+s.err.Error())
+	}
+	switch {
+	case s.query != "" && len(s.matches) == 0:
+		bar += " " + failedStyle.Render("no matches")
+	case s.query != "":
+		position := "-"
+		if n := m.matchPosition(); n > 0 {
+			position = fmt.Sprint(n)
+		}
+		bar += " " + fmt.Sprintf("%s/%d", position, len(s.matches))
+	}
+	return bar + "\n"
+}
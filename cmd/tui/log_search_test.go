@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLogSearchModes(t *testing.T) {
+	const log = "Running tests\nok pkg/a\nFAIL pkg/b\n\nok pkg/c\nfail: pkg/d (exit 1)\n"
+
+	tests := []struct {
+		name        string
+		query       string
+		regex       bool
+		filter      bool
+		wantMatches []int
+		wantRows    []int
+		wantErr     bool
+	}{
+		{"highlight keeps every line", "fail", false, false, []int{2, 5}, []int{0, 1, 2, 4, 5}, false},
+		{"filter shows only matches", "fail", false, true, []int{2, 5}, []int{2, 5}, false},
+		{"smart case", "FAIL", false, true, []int{2}, []int{2}, false},
+		{"regex", `^ok pkg/[ac]$`, true, true, []int{1, 4}, []int{1, 4}, false},
+		{"invalid regex searched as text", "(exit", true, true, []int{5}, []int{5}, true},
+		{"invalid regex without matches", "pkg/(", true, true, nil, nil, true},
+		{"no matches while filtering", "panic", false, true, nil, nil, false},
+		{"filter without a query", "", false, true, nil, []int{0, 1, 2, 4, 5}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m model
+			m.setLogs(log)
+			m.search.query, m.search.regex, m.search.filter = tt.query, tt.regex, tt.filter
+			m.compileSearch()
+
+			if (m.search.err != nil) != tt.wantErr {
+				t.Errorf("search error = %v, wantErr %v", m.search.err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(m.search.matches, tt.wantMatches) {
+				t.Errorf("matches = %v, want %v", m.search.matches, tt.wantMatches)
+			}
+			if got := m.logRows(); !reflect.DeepEqual(got, tt.wantRows) {
+				t.Errorf("logRows() = %v, want %v", got, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestLogSearchFollowsGrowingLog(t *testing.T) {
+	var m model
+	m.setLogs("error one\n")
+	m.search.query = "error"
+	m.compileSearch()
+
+	m.setLogs("error one\nfine\nerr")
+	m.setLogs("error one\nfine\nerror two\n")
+	if want := []int{0, 2}; !reflect.DeepEqual(m.search.matches, want) {
+		t.Errorf("matches = %v, want %v", m.search.matches, want)
+	}
+}
@@ -0,0 +1,374 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/core"
+	"github.com/rkristelijn/glab-tui/internal/joblog"
+)
+
+// logScrollStep is how many columns long log lines scroll sideways per key
+const logScrollStep = 10
+
+// searchHighlight is how search matches are drawn over the log's own colors
+var searchHighlight = joblog.Style{Foreground: "#000000", Background: "#FFFF00"}
+
+// setLogs replaces the text of the log view. Text that only adds to the
+// log is parsed as it grows, and sections keep the state they were folded
+// in.
+func (m *model) setLogs(text string) {
+	if m.logParsed == nil || !strings.HasPrefix(text, m.logs) {
+		m.logs = ""
+		m.logParsed = &joblog.Trace{}
+		m.logFolds = nil
+		m.logScroll = 0
+		m.logFailures = nil
+		m.search.matches = nil
+	}
+	m.extendLogs(text[len(m.logs):])
+}
+
+// extendLogs adds text to the end of the log, finding failures and search
+// matches only in the lines that changed
+func (m *model) extendLogs(data string) {
+	m.logs += data
+	first := m.logParsed.Append(data)
+	log := m.logParsed.Log()
+	m.logLines = log.Lines
+	m.logSections = log.Sections
+
+	m.logFailures = m.logFailures[:sort.SearchInts(m.logFailures, first)]
+	for i := first; i < len(m.logLines); i++ {
+		if m.errorMatcher.Match(m.logLines[i].Text) {
+			m.logFailures = append(m.logFailures, i)
+		}
+	}
+	m.findMatches(first)
+	m.layoutLog()
+}
+
+// sectionCollapsed reports whether section i of the log is folded, by the
+// user or by default
+func (m model) sectionCollapsed(i int) bool {
+	section := m.logSections[i]
+	if collapsed, ok := m.logFolds[section.Header]; ok {
+		return collapsed
+	}
+	return section.StartsCollapsed()
+}
+
+// logRows are the indexes of the log lines on screen: the search matches
+// when filtering, otherwise the lines outside collapsed sections
+func (m model) logRows() []int {
+	if m.search.filter && m.search.pattern != nil {
+		return m.search.matches
+	}
+	return m.logVisible
+}
+
+// layoutLog finds the lines outside collapsed sections, leaving out blank
+// lines except for the title of a section. It runs when the log or its
+// folds change rather than on every key or redraw, which keeps long logs
+// fast.
+func (m *model) layoutLog() {
+	m.logVisible = m.logVisible[:0]
+	skip, next := -1, 0 // Lines up to skip are in a collapsed section
+	for i, line := range m.logLines {
+		hidden, header := i <= skip, false
+		for ; next < len(m.logSections) && m.logSections[next].Header == i; next++ {
+			header = true
+			if !hidden && m.sectionCollapsed(next) {
+				skip = max(skip, m.logSections[next].Last(len(m.logLines)))
+			}
+		}
+		if !hidden && (line.Text != "" || header) {
+			m.logVisible = append(m.logVisible, i)
+		}
+	}
+}
+
+// logRow returns the row the cursor is on: the row of its line, or the one
+// before it when the line is hidden, e.g. in a collapsed section
+func logRow(rows []int, line int) int {
+	return max(sort.SearchInts(rows, line+1)-1, 0)
+}
+
+// moveLogCursor moves the cursor by delta rows, staying on the screen
+func (m *model) moveLogCursor(delta int) {
+	rows := m.logRows()
+	if len(rows) == 0 {
+		m.logCursor = 0
+		return
+	}
+	row := min(max(logRow(rows, m.logCursor)+delta, 0), len(rows)-1)
+	m.logCursor = rows[row]
+}
+
+// sectionHeader returns the outermost section whose title is on a line, -1
+// if none. Sections are in the order they start, so by header line.
+func (m model) sectionHeader(line int) int {
+	i := sort.Search(len(m.logSections), func(i int) bool { return m.logSections[i].Header >= line })
+	if i < len(m.logSections) && m.logSections[i].Header == line {
+		return i
+	}
+	return -1
+}
+
+// sectionAt returns the innermost section containing a line, -1 if none
+func (m model) sectionAt(line int) int {
+	for i := len(m.logSections) - 1; i >= 0; i-- {
+		s := m.logSections[i]
+		if s.Header <= line && line <= s.Last(len(m.logLines)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// updateLogOutline handles the keys that fold sections and jump between
+// sections and failures, and reports whether key was one of them
+func (m *model) updateLogOutline(key string) bool {
+	switch key {
+	case " ":
+		// Fold or unfold the section under the cursor
+		i := m.sectionAt(m.logCursor)
+		if i < 0 {
+			return true
+		}
+		if m.logFolds == nil {
+			m.logFolds = make(map[int]bool)
+		}
+		collapse := !m.sectionCollapsed(i)
+		m.logFolds[m.logSections[i].Header] = collapse
+		if collapse {
+			m.logCursor = m.logSections[i].Header
+		}
+		m.layoutLog()
+	case "]", "[":
+		// Jump to the title of the next or previous section on screen
+		rows := m.logRows()
+		row := logRow(rows, m.logCursor)
+		for {
+			if key == "[" {
+				row--
+			} else {
+				row++
+			}
+			if row < 0 || row >= len(rows) {
+				break
+			}
+			if m.sectionHeader(rows[row]) >= 0 {
+				m.logCursor = rows[row]
+				break
+			}
+		}
+	case "+", "-":
+		// Expand or collapse every section
+		m.logFolds = make(map[int]bool, len(m.logSections))
+		for _, s := range m.logSections {
+			m.logFolds[s.Header] = key == "-"
+		}
+		m.layoutLog()
+		m.moveLogCursor(0)
+	case "e":
+		m.jumpToFailure(true)
+	case "E":
+		m.jumpToFailure(false)
+	default:
+		return false
+	}
+	return true
+}
+
+// updateLogViewport handles the keys that wrap long lines or scroll them
+// sideways, and reports whether key was one of them
+func (m *model) updateLogViewport(key string) bool {
+	switch key {
+	case "w":
+		m.logWrap = !m.logWrap
+		m.logScroll = 0
+	case "right", "l":
+		if !m.logWrap {
+			m.logScroll += logScrollStep
+		}
+	case "left", "h":
+		m.logScroll = max(m.logScroll-logScrollStep, 0)
+	case "0":
+		m.logScroll = 0
+	default:
+		return false
+	}
+	return true
+}
+
+// jumpToFailure moves the cursor to the next or previous failure line,
+// wrapping around, and unfolds the sections it is in
+func (m *model) jumpToFailure(forward bool) {
+	if len(m.logFailures) == 0 {
+		return
+	}
+	next := sort.SearchInts(m.logFailures, m.logCursor+1)
+	target := m.logFailures[next%len(m.logFailures)]
+	if !forward {
+		prev := sort.SearchInts(m.logFailures, m.logCursor) - 1
+		if prev < 0 {
+			prev = len(m.logFailures) - 1
+		}
+		target = m.logFailures[prev]
+	}
+	m.revealLogLine(target)
+	m.logCursor = target
+}
+
+// revealLogLine unfolds the sections containing a line
+func (m *model) revealLogLine(line int) {
+	changed := false
+	for i, section := range m.logSections {
+		if section.Header < line && line <= section.Last(len(m.logLines)) && m.sectionCollapsed(i) {
+			if m.logFolds == nil {
+				m.logFolds = make(map[int]bool)
+			}
+			m.logFolds[section.Header] = false
+			changed = true
+		}
+	}
+	if changed {
+		m.layoutLog()
+	}
+}
+
+// isLogFailure reports whether a log line reports a failure
+func (m model) isLogFailure(line int) bool {
+	i := sort.SearchInts(m.logFailures, line)
+	return i < len(m.logFailures) && m.logFailures[i] == line
+}
+
+// renderSectionTitle draws the duration of a section after its title, and
+// its name when the title is blank
+func renderSectionTitle(section joblog.Section, blank bool) string {
+	faint := lipgloss.NewStyle().Faint(true)
+	s := ""
+	if blank {
+		s = faint.Render(section.Name)
+	}
+	if section.Open() {
+		return s
+	}
+	duration := core.FormatDuration(section.Duration.Seconds())
+	if duration == "" {
+		duration = "0s"
+	}
+	return s + " " + faint.Render("("+duration+")")
+}
+
+// logWindow returns the rows [start, end) of a log of n rows that fit in
+// height screen lines, with the cursor row in the middle unless the log
+// starts or ends on screen. Rows can take more than one line when wrapped.
+func logWindow(n, cursor, height int, rowHeight func(row int) int) (int, int) {
+	if n == 0 {
+		return 0, 0
+	}
+	start, end := cursor, cursor+1
+	used := rowHeight(cursor)
+	for start > 0 && used+rowHeight(start-1) <= height/2 {
+		start--
+		used += rowHeight(start)
+	}
+	for end < n && used+rowHeight(end) <= height {
+		used += rowHeight(end)
+		end++
+	}
+	for start > 0 && used+rowHeight(start-1) <= height {
+		start--
+		used += rowHeight(start)
+	}
+	return start, end
+}
+
+// logGutterWidth returns the width of what is drawn before a log line: the
+// failure mark, cursor, line number and fold marker
+func (m model) logGutterWidth(line int) int {
+	width := 2 + len(fmt.Sprintf("%4d: ", line+1))
+	if len(m.logFailures) > 0 {
+		width += 2
+	}
+	if len(m.logSections) > 0 {
+		width += 2
+	}
+	return width
+}
+
+// logLineParts cuts a log line into the screen lines it takes in width
+// columns: every part of it when wrapping, otherwise the part scrolled to.
+// The line isn't cut while the terminal width isn't known.
+func (m model) logLineParts(line joblog.Line, width int) []joblog.Line {
+	switch {
+	case width <= 0:
+		if m.logScroll > 0 && !m.logWrap {
+			return []joblog.Line{line.Cut(m.logScroll, math.MaxInt)}
+		}
+		return []joblog.Line{line}
+	case m.logWrap:
+		total := line.Width()
+		parts := make([]joblog.Line, 0, total/width+1)
+		for left := 0; left == 0 || left < total; left += width {
+			parts = append(parts, line.Cut(left, left+width))
+		}
+		return parts
+	}
+	return []joblog.Line{line.Cut(m.logScroll, m.logScroll+width)}
+}
+
+// logRowHeight returns how many screen lines a log line takes
+func (m model) logRowHeight(line int) int {
+	width := m.width - m.logGutterWidth(line)
+	if !m.logWrap || m.width <= 0 || width <= 0 {
+		return 1
+	}
+	return max((m.logLines[line].Width()+width-1)/width, 1)
+}
+
+// renderLogLine draws a log line in its own colors. The line under the
+// cursor is drawn bold, in the selection color where the log doesn't set
+// one.
+func renderLogLine(line joblog.Line, selected bool) string {
+	base := lipgloss.NewStyle()
+	if selected {
+		base = selectedStyle
+	}
+	var b strings.Builder
+	for _, span := range line.Spans {
+		b.WriteString(logStyle(base, span.Style).Render(span.Text))
+	}
+	return b.String()
+}
+
+// logStyle applies the style of a log span on top of base
+func logStyle(base lipgloss.Style, s joblog.Style) lipgloss.Style {
+	if s.Foreground != "" {
+		base = base.Foreground(lipgloss.Color(s.Foreground))
+	}
+	if s.Background != "" {
+		base = base.Background(lipgloss.Color(s.Background))
+	}
+	if s.Bold {
+		base = base.Bold(true)
+	}
+	if s.Faint {
+		base = base.Faint(true)
+	}
+	if s.Italic {
+		base = base.Italic(true)
+	}
+	if s.Underline {
+		base = base.Underline(true)
+	}
+	if s.Reverse {
+		base = base.Reverse(true)
+	}
+	return base
+}
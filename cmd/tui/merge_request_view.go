@@ -0,0 +1,252 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+var draftStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")).Italic(true)
+
+// openMergeRequests switches to the merge request list and loads it
+func (m *model) openMergeRequests() tea.Cmd {
+	m.currentView = mergeRequestView
+	return m.reloadMergeRequests()
+}
+
+// reloadMergeRequests refetches the open merge requests of the project with
+// all their pipelines and approvals
+func (m *model) reloadMergeRequests() tea.Cmd {
+	if m.loads[mergeRequestView].loading {
+		return nil
+	}
+	return tea.Batch(m.startLoading(mergeRequestView, "Loading merge requests"), fetchMergeRequests(m.service, nil))
+}
+
+// setMergeRequests applies a loaded merge request list, keeping the cursor
+// in bounds
+func (m *model) setMergeRequests(mrs []core.MergeRequest) {
+	m.markChanges(mergeRequestStatuses(m.mergeRequests), mergeRequestStatuses(mrs))
+	m.mergeRequests = mrs
+	m.lastRefresh = time.Now()
+	if m.mrCursor >= len(m.mergeRequests) {
+		m.mrCursor = max(len(m.mergeRequests)-1, 0)
+	}
+}
+
+// openMRPipelines switches to the pipelines of a merge request
+func (m *model) openMRPipelines(mr core.MergeRequest) tea.Cmd {
+	m.selectedMR = mr
+	m.mrPipelines = nil
+	m.mrPipelineCursor = 0
+	m.currentView = mrPipelineView
+	return m.reloadMRPipelines()
+}
+
+// reloadMRPipelines refetches the pipelines of the selected merge request,
+// unless they are loading already
+func (m *model) reloadMRPipelines() tea.Cmd {
+	iid := m.selectedMR.IID
+	if m.loadingFor(mrPipelineView, iid) {
+		return nil
+	}
+	label := fmt.Sprintf("Loading pipelines of !%d", iid)
+	return tea.Batch(m.startLoadingFor(mrPipelineView, iid, label), fetchMRPipelines(m.service, iid))
+}
+
+// mrPipelineService returns a service bound to the project a merge request
+// pipeline ran in, which is the fork for merge requests from forks
+func (m model) mrPipelineService(pipeline core.Pipeline) *core.Service {
+	if pipeline.ProjectID != 0 && pipeline.ProjectID != m.selectedMR.ProjectID {
+		return m.service.ForProject(core.ProjectRef(pipeline.ProjectID))
+	}
+	return m.service
+}
+
+// updateMergeRequests handles keys in the merge request list
+func (m model) updateMergeRequests(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "up", "k":
+		if m.mrCursor > 0 {
+			m.mrCursor--
+		}
+	case "down", "j":
+		if m.mrCursor < len(m.mergeRequests)-1 {
+			m.mrCursor++
+		}
+	case "g":
+		m.mrCursor = 0
+	case "G":
+		m.mrCursor = max(len(m.mergeRequests)-1, 0)
+	case "ctrl+u":
+		m.mrCursor = max(m.mrCursor-5, 0)
+	case "ctrl+d":
+		m.mrCursor = max(min(m.mrCursor+5, len(m.mergeRequests)-1), 0)
+	case "r":
+		return m, m.reloadMergeRequests()
+	case "enter":
+		if m.mrCursor < len(m.mergeRequests) {
+			return m, tea.Batch(tea.ClearScreen, m.openMRPipelines(m.mergeRequests[m.mrCursor]))
+		}
+	case "esc":
+		m.currentView = m.homeView
+		return m, tea.ClearScreen
+	}
+	return m, nil
+}
+
+// updateMRPipelines handles keys in the pipeline list of a merge request
+func (m model) updateMRPipelines(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "up", "k":
+		if m.mrPipelineCursor > 0 {
+			m.mrPipelineCursor--
+		}
+	case "down", "j":
+		if m.mrPipelineCursor < len(m.mrPipelines)-1 {
+			m.mrPipelineCursor++
+		}
+	case "g":
+		m.mrPipelineCursor = 0
+	case "G":
+		m.mrPipelineCursor = max(len(m.mrPipelines)-1, 0)
+	case "r":
+		return m, m.reloadMRPipelines()
+	case "enter":
+		if m.mrPipelineCursor < len(m.mrPipelines) {
+			pipeline := m.mrPipelines[m.mrPipelineCursor]
+			return m, tea.Batch(tea.ClearScreen, m.openJobs(m.mrPipelineService(pipeline), pipeline.ID))
+		}
+	case "esc":
+		m.currentView = mergeRequestView
+		return m, tea.ClearScreen
+	}
+	return m, nil
+}
+
+func (m model) renderMergeRequestView(title string) string {
+	header := headerStyle.Render("🔀 Open Merge Requests")
+
+	drafts, failing := 0, 0
+	for _, mr := range m.mergeRequests {
+		if mr.Draft {
+			drafts++
+		}
+		if mr.HeadPipeline != nil && mr.HeadPipeline.Status == "failed" {
+			failing++
+		}
+	}
+	statusLine := fmt.Sprintf("📊 %d open | 📝 %d draft | ❌ %d failing pipelines", len(m.mergeRequests), drafts, failing)
+
+	s := title + "\n"
+	s += header + "\n"
+	s += lipgloss.NewStyle().Faint(true).Render(statusLine) + "\n"
+	s += m.renderLoadStatus(mergeRequestView) + "\n"
+
+	if len(m.mergeRequests) == 0 {
+		if !m.loads[mergeRequestView].loading && m.loads[mergeRequestView].err == nil {
+			s += "No open merge requests.\n"
+		}
+		return s
+	}
+
+	footer := "\n" + m.renderHelp("Navigation: ↑/↓ or j/k | Ctrl+U/D: page up/down | g/G: first/last | Enter: view pipelines | r: refresh | Esc: back | q: quit")
+	start, end := m.scrollList(mergeRequestView, m.mrCursor, len(m.mergeRequests), m.listHeight(s, footer))
+
+	for i := start; i < end; i++ {
+		mr := m.mergeRequests[i]
+
+		cursor := "  "
+		if i == m.mrCursor {
+			cursor = "▶ "
+		}
+
+		pipeline := pendingStyle.Render("○") + " no pipeline"
+		if p := mr.HeadPipeline; p != nil {
+			pipeline = fmt.Sprintf("%s %s", getStyledStatus(p.Status, getStatusIcon(p.Status)), p.Status)
+		}
+
+		approvals := "👍 ?"
+		if a := mr.Approvals; a != nil {
+			approvals = "👍 " + a.String()
+			if a.Approved() {
+				approvals = successStyle.Render(approvals)
+			}
+		}
+
+		mrTitle := truncateString(mr.Title, 40)
+		if mr.Draft {
+			mrTitle = draftStyle.Render(fmt.Sprintf("%-40s", mrTitle))
+		} else {
+			mrTitle = fmt.Sprintf("%-40s", mrTitle)
+		}
+
+		line := fmt.Sprintf("%s!%-5d %s %-12s %-30s %-8s %s",
+			cursor,
+			mr.IID,
+			mrTitle,
+			truncateString("@"+mr.Author, 12),
+			truncateString(mr.SourceBranch+" → "+mr.TargetBranch, 30),
+			approvals,
+			pipeline)
+
+		s += m.highlight(line, mergeRequestChangeKey(mr.IID), i == m.mrCursor) + "\n"
+	}
+
+	s += footer
+	return s
+}
+
+func (m model) renderMRPipelineView(title string) string {
+	mr := m.selectedMR
+	header := headerStyle.Render(fmt.Sprintf("🔀 !%d %s", mr.IID, truncateString(mr.Title, 60)))
+
+	info := fmt.Sprintf("@%s | %s → %s", mr.Author, mr.SourceBranch, mr.TargetBranch)
+	if mr.Draft {
+		info += " | Draft"
+	}
+	if a := mr.Approvals; a != nil {
+		info += " | Approvals " + a.String()
+	}
+
+	s := title + "\n"
+	s += header + "\n"
+	s += lipgloss.NewStyle().Faint(true).Render(info) + "\n"
+	s += m.renderLoadStatus(mrPipelineView) + "\n"
+
+	if len(m.mrPipelines) == 0 && !m.loads[mrPipelineView].loading && m.loads[mrPipelineView].err == nil {
+		s += "This merge request has no pipelines.\n"
+	}
+
+	footer := "\n" + m.renderHelp("Navigation: ↑/↓ or j/k | g/G: first/last | Enter: view jobs | R: retry | C: cancel | r: refresh | Esc: back | q: quit")
+	start, end := m.scrollList(mrPipelineView, m.mrPipelineCursor, len(m.mrPipelines), m.listHeight(s, footer))
+
+	for i := start; i < end; i++ {
+		pipeline := m.mrPipelines[i]
+		cursor := "  "
+		if i == m.mrPipelineCursor {
+			cursor = "▶ "
+		}
+
+		when := pipeline.Duration
+		if age := core.FormatAge(pipeline.UpdatedAt); age != "" {
+			when = age
+		}
+
+		line := fmt.Sprintf("%s%s #%-11d %-10s %-25s %s",
+			cursor,
+			getStyledStatus(pipeline.Status, getStatusIcon(pipeline.Status)),
+			pipeline.ID,
+			pipeline.Status,
+			truncateString(getBetterBranchName(pipeline.Ref), 25),
+			when)
+
+		s += m.highlight(line, pipelineChangeKey(pipeline.ID), i == m.mrPipelineCursor) + "\n"
+	}
+
+	s += footer
+	return s
+}
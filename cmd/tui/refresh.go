@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// highlightDuration is how long a row stays marked after its status changed
+const highlightDuration = 3 * time.Second
+
+var changedStyle = lipgloss.NewStyle().
+	Background(lipgloss.Color("#5A4A00")).
+	Bold(true)
+
+// refreshMsg asks the visible view to poll for new data
+type refreshMsg struct{}
+
+func refreshTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg { return refreshMsg{} })
+}
+
+// clockMsg redraws what changes with time between refreshes: highlights
+// fading and the age of the last refresh
+type clockMsg struct{}
+
+func clockTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return clockMsg{} })
+}
+
+// poll reloads the data of the visible view in the background. The log view
+// isn't polled here, its trace follower runs at the same interval.
+func (m *model) poll() tea.Cmd {
+	switch m.currentView {
+	case pipelineView, dashboardView:
+		if m.loads[m.homeView].loading {
+			return nil
+		}
+		_, fetch := m.homeLoad()
+		return tea.Batch(m.startBackground(m.homeView), fetch)
+	case jobView, graphView:
+		if m.jobService == nil || m.loads[jobView].loading {
+			return nil
+		}
+		target := pipelineTarget{m.jobService, m.selectedPipelineID}
+		return tea.Batch(m.startBackgroundFor(jobView, target), fetchJobs(m.jobService, m.selectedPipelineID))
+	case mergeRequestView:
+		if m.loads[mergeRequestView].loading {
+			return nil
+		}
+		return tea.Batch(m.startBackground(mergeRequestView), fetchMergeRequests(m.service, m.mergeRequests))
+	case mrPipelineView:
+		if m.loads[mrPipelineView].loading {
+			return nil
+		}
+		iid := m.selectedMR.IID
+		return tea.Batch(m.startBackgroundFor(mrPipelineView, iid), fetchMRPipelines(m.service, iid))
+	case testReportView:
+		// Only running jobs add results; the job list tells when they're done
+		if !m.testJobsRunning() || m.loads[testReportView].loading || m.loads[jobView].loading {
+			return nil
+		}
+		target := pipelineTarget{m.testService, m.testPipelineID}
+		return tea.Batch(m.startBackgroundFor(testReportView, target), fetchTestReport(m.testService, m.testPipelineID),
+			m.startBackgroundFor(jobView, target), fetchJobs(m.jobService, m.selectedPipelineID))
+	case treeView:
+		if m.tree == nil || m.loads[treeView].loading {
+			return nil
+		}
+		return tea.Batch(m.startBackground(treeView), fetchTree(m.treeService, m.treePipelineID))
+	}
+	return nil
+}
+
+// startBackground marks a view as refreshing without a loading line, the
+// status bar shows the spinner instead
+func (m *model) startBackground(view viewMode) tea.Cmd {
+	m.loads[view] = loadStatus{loading: true, background: true}
+	return m.spin()
+}
+
+// refreshing reports whether a background refresh is in flight
+func (m model) refreshing() bool {
+	for _, status := range m.loads {
+		if status.loading && status.background {
+			return true
+		}
+	}
+	return false
+}
+
+// markChanges remembers the keys whose status differs between two loads.
+// Nothing is marked on a first load, when there is nothing to compare with.
+func (m *model) markChanges(old, current map[string]string) {
+	m.expireChanges()
+	if len(old) == 0 {
+		return
+	}
+	now := time.Now()
+	for key, status := range current {
+		if old[key] != status {
+			m.changed[key] = now
+		}
+	}
+}
+
+// expireChanges forgets the changes highlighted long enough
+func (m *model) expireChanges() {
+	for key, at := range m.changed {
+		if time.Since(at) > highlightDuration {
+			delete(m.changed, key)
+		}
+	}
+}
+
+// changedRecently reports whether a row changed status in the last refresh
+func (m model) changedRecently(key string) bool {
+	at, ok := m.changed[key]
+	return ok && time.Since(at) <= highlightDuration
+}
+
+// highlight styles a row: the cursor wins over a recent change
+func (m model) highlight(line, key string, selected bool) string {
+	switch {
+	case selected:
+		return selectedStyle.Render(line)
+	case m.changedRecently(key):
+		return changedStyle.Render(line)
+	}
+	return line
+}
+
+func pipelineChangeKey(id int) string {
+	return fmt.Sprintf("pipeline:%d", id)
+}
+
+func jobChangeKey(id int) string {
+	return fmt.Sprintf("job:%d", id)
+}
+
+func mergeRequestChangeKey(iid int) string {
+	return fmt.Sprintf("mr:%d", iid)
+}
+
+func dashboardChangeKey(row core.DashboardRow) string {
+	return fmt.Sprintf("dashboard:%d:%s", row.Project.ID, row.Ref)
+}
+
+func pipelineStatuses(pipelines []core.Pipeline) map[string]string {
+	statuses := make(map[string]string, len(pipelines))
+	for _, p := range pipelines {
+		statuses[pipelineChangeKey(p.ID)] = p.Status
+	}
+	return statuses
+}
+
+func jobStatuses(jobs []core.Job) map[string]string {
+	statuses := make(map[string]string, len(jobs))
+	for _, job := range jobs {
+		statuses[jobChangeKey(job.ID)] = job.Status
+	}
+	return statuses
+}
+
+// mergeRequestStatuses tracks the head pipeline, so a new pipeline counts as
+// a change even if its status is the same as the previous one
+func mergeRequestStatuses(mrs []core.MergeRequest) map[string]string {
+	statuses := make(map[string]string, len(mrs))
+	for _, mr := range mrs {
+		if p := mr.HeadPipeline; p != nil {
+			statuses[mergeRequestChangeKey(mr.IID)] = fmt.Sprintf("%d %s", p.ID, p.Status)
+		}
+	}
+	return statuses
+}
+
+// dashboardStatuses also tracks the pipeline ID, so a new pipeline on a ref
+// counts as a change even if its status is the same as the previous one
+func dashboardStatuses(rows []core.DashboardRow) map[string]string {
+	statuses := make(map[string]string, len(rows))
+	for _, row := range rows {
+		if row.Pipeline != nil {
+			statuses[dashboardChangeKey(row)] = fmt.Sprintf("%d %s", row.Pipeline.ID, row.Pipeline.Status)
+		}
+	}
+	return statuses
+}
+
+func treeStatuses(tree *core.PipelineNode) map[string]string {
+	statuses := make(map[string]string)
+	if tree != nil {
+		tree.Walk(func(node *core.PipelineNode, _ int) {
+			statuses[pipelineChangeKey(node.Pipeline.ID)] = node.Pipeline.Status
+		})
+	}
+	return statuses
+}
+
+// refreshAge describes how long ago data was loaded, to the second for the
+// first minute
+func refreshAge(t time.Time) string {
+	if d := time.Since(t); d < time.Minute {
+		return fmt.Sprintf("%ds ago", int(d.Seconds()))
+	}
+	return core.FormatAge(t)
+}
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMarkChanges(t *testing.T) {
+	m := model{changed: make(map[string]time.Time)}
+
+	m.markChanges(nil, map[string]string{"a": "running"})
+	if len(m.changed) != 0 {
+		t.Fatalf("first load marked %v, want nothing", m.changed)
+	}
+
+	m.markChanges(map[string]string{"a": "running", "b": "running"}, map[string]string{"a": "success", "b": "running", "c": "pending"})
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if got := m.changedRecently(key); got != want {
+			t.Errorf("changedRecently(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestClockExpiresHighlights(t *testing.T) {
+	m := model{changed: map[string]time.Time{
+		"old": time.Now().Add(-highlightDuration - time.Second),
+		"new": time.Now(),
+	}}
+
+	next, cmd := m.Update(clockMsg{})
+	changed := next.(model).changed
+	if _, ok := changed["old"]; ok {
+		t.Error("expired highlight kept")
+	}
+	if _, ok := changed["new"]; !ok {
+		t.Error("recent highlight dropped")
+	}
+	if cmd == nil {
+		t.Error("clock stopped ticking")
+	}
+}
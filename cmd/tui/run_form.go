@@ -0,0 +1,245 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// maxRunFormBranches is how many matching branches the ref picker lists
+const maxRunFormBranches = 8
+
+const (
+	refField = iota
+	variablesField
+)
+
+// runForm collects the ref and variables of a new pipeline
+type runForm struct {
+	service    *core.Service
+	defaultRef string        // Current git branch, or the project's default branch
+	branches   []core.Branch // Branches to pick from
+	filter     string        // Typed ref, filters the branches
+	pick       int           // Index of the picked branch in matches(), -1 for the typed ref
+	variables  string        // KEY=value pairs, space separated
+	field      int           // Field with the focus
+	loading    bool
+	submitting bool
+	err        error
+}
+
+// runDefaultsMsg delivers the default ref and branches for the form
+type runDefaultsMsg struct {
+	service    *core.Service
+	defaultRef string
+	branches   []core.Branch
+	err        error
+}
+
+// pipelineRunMsg reports the pipeline created by the form
+type pipelineRunMsg struct {
+	service  *core.Service
+	pipeline *core.Pipeline
+	err      error
+}
+
+func fetchRunDefaults(service *core.Service) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		branches, err := service.ListBranches(ctx, "")
+		return runDefaultsMsg{service: service, defaultRef: service.DefaultRef(ctx), branches: branches, err: err}
+	}
+}
+
+func runPipeline(service *core.Service, ref string, variables []core.JobVariable) tea.Cmd {
+	return func() tea.Msg {
+		pipeline, err := service.CreatePipeline(context.Background(), ref, variables)
+		return pipelineRunMsg{service: service, pipeline: pipeline, err: err}
+	}
+}
+
+// matches returns the branches containing the typed ref
+func (f runForm) matches() []core.Branch {
+	var matches []core.Branch
+	for _, b := range f.branches {
+		if strings.Contains(strings.ToLower(b.Name), strings.ToLower(f.filter)) {
+			matches = append(matches, b)
+		}
+	}
+	return matches
+}
+
+// ref is the ref the pipeline will run on: the picked branch, else the
+// typed one, else the default
+func (f runForm) ref() string {
+	if matches := f.matches(); f.pick >= 0 && f.pick < len(matches) {
+		return matches[f.pick].Name
+	}
+	if f.filter != "" {
+		return f.filter
+	}
+	return f.defaultRef
+}
+
+// openRunForm opens the form for a new pipeline of the service's project
+func (m *model) openRunForm(service *core.Service) tea.Cmd {
+	m.runForm = &runForm{service: service, pick: -1, loading: true}
+	return tea.Batch(m.spin(), fetchRunDefaults(service))
+}
+
+// applyRunDefaults fills in the form once its defaults are loaded
+func (m *model) applyRunDefaults(msg runDefaultsMsg) {
+	f := m.runForm
+	if f == nil || f.service != msg.service {
+		return
+	}
+	f.loading = false
+	f.defaultRef = msg.defaultRef
+	f.branches = msg.branches
+	f.err = msg.err // The ref can still be typed
+}
+
+// applyPipelineRun jumps into the job view of the new pipeline
+func (m *model) applyPipelineRun(msg pipelineRunMsg) tea.Cmd {
+	f := m.runForm
+	if f == nil || f.service != msg.service {
+		return nil
+	}
+	if msg.err != nil {
+		f.submitting = false
+		f.err = msg.err
+		return nil
+	}
+
+	m.runForm = nil
+	m.setNotice(fmt.Sprintf("✅ Pipeline #%d created for %s", msg.pipeline.ID, msg.pipeline.Ref), false)
+	return tea.Batch(tea.ClearScreen, m.openJobs(msg.service, msg.pipeline.ID))
+}
+
+// updateRunForm handles keys while the form is open. Tab switches fields,
+// ↑/↓ pick a branch and Enter runs the pipeline.
+func (m model) updateRunForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := m.runForm
+	if f.submitting {
+		if msg.String() == "ctrl+c" {
+			m.stopFollowingLogs()
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.stopFollowingLogs()
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.runForm = nil
+	case tea.KeyTab, tea.KeyShiftTab:
+		f.field = 1 - f.field
+	case tea.KeyUp:
+		if f.field == refField && f.pick >= 0 {
+			f.pick--
+		}
+	case tea.KeyDown:
+		if f.field == refField && f.pick < min(len(f.matches()), maxRunFormBranches)-1 {
+			f.pick++
+		}
+	case tea.KeyEnter:
+		variables, err := core.ParseJobVariables(f.variables)
+		if err != nil {
+			f.err = err
+			return m, nil
+		}
+		f.submitting = true
+		f.err = nil
+		return m, tea.Batch(m.spin(), runPipeline(f.service, f.ref(), variables))
+	case tea.KeyBackspace:
+		f.edit(func(s string) string {
+			if len(s) == 0 {
+				return s
+			}
+			return s[:len(s)-1]
+		})
+	case tea.KeySpace:
+		// Refs can't contain spaces, variables are separated by them
+		if f.field == variablesField {
+			f.variables += " "
+		}
+	case tea.KeyRunes:
+		f.edit(func(s string) string { return s + string(msg.Runes) })
+	}
+	return m, nil
+}
+
+// edit changes the text of the focused field
+func (f *runForm) edit(change func(string) string) {
+	if f.field == variablesField {
+		f.variables = change(f.variables)
+		return
+	}
+	f.filter = change(f.filter)
+	f.pick = -1
+	f.err = nil
+}
+
+// renderRunForm shows the form for a new pipeline
+func (m model) renderRunForm() string {
+	f := m.runForm
+	promptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF87")).Bold(true)
+	faint := lipgloss.NewStyle().Faint(true)
+
+	cursor := func(field int) string {
+		if f.field == field {
+			return "█"
+		}
+		return ""
+	}
+
+	lines := []string{promptStyle.Render(fmt.Sprintf("▶ Run pipeline for %s", f.service.Project()))}
+
+	ref := f.filter
+	if ref == "" && f.pick < 0 {
+		ref = faint.Render(f.defaultRef)
+	}
+	if f.pick >= 0 {
+		ref = f.ref()
+	}
+	lines = append(lines, "  Branch or tag: "+ref+cursor(refField))
+
+	switch {
+	case f.loading:
+		lines = append(lines, pendingStyle.Render("    "+m.spinner()+" Loading branches..."))
+	case f.field == refField:
+		matches := f.matches()
+		for i, b := range matches {
+			if i == maxRunFormBranches {
+				lines = append(lines, faint.Render(fmt.Sprintf("    ... %d more, type to filter", len(matches)-maxRunFormBranches)))
+				break
+			}
+			name := b.Name
+			if b.Default {
+				name += faint.Render(" (default)")
+			}
+			if i == f.pick {
+				lines = append(lines, selectedStyle.Render("  ▶ ")+name)
+			} else {
+				lines = append(lines, "    "+name)
+			}
+		}
+	}
+
+	lines = append(lines, "  Variables (KEY=value or KEY=\"with spaces\", space separated, optional): "+f.variables+cursor(variablesField))
+
+	switch {
+	case f.submitting:
+		lines = append(lines, pendingStyle.Render(fmt.Sprintf("  %s Creating pipeline for %s...", m.spinner(), f.ref())))
+	case f.err != nil:
+		lines = append(lines, failedStyle.Render("  ❌ "+f.err.Error()))
+	}
+	lines = append(lines, faint.Render("  ↑/↓: pick branch | Tab: next field | Enter: run | Esc: cancel"))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/analysis"
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// trendWidth is how many pipelines a sparkline shows
+const trendWidth = 30
+
+type statsMsg struct {
+	ref    string
+	report *analysis.DurationReport
+	err    error
+}
+
+// fetchStats times the pipeline history of a ref, the default ref if empty
+func fetchStats(service *core.Service, ref string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if ref == "" {
+			ref = service.DefaultRef(ctx)
+		}
+		report, err := analysis.AnalyzeDurations(ctx, service, analysis.Options{Ref: ref})
+		return statsMsg{ref: ref, report: report, err: err}
+	}
+}
+
+// openStats switches to the duration stats of the selected pipeline's ref
+func (m *model) openStats() tea.Cmd {
+	m.statsRef = ""
+	if m.pipelineCursor < len(m.pipelines) {
+		m.statsRef = m.pipelines[m.pipelineCursor].Ref
+	}
+	m.statsReport = nil
+	m.statsCursor = 0
+	m.currentView = statsView
+	return m.analyzeStats()
+}
+
+// reloadStats times the pipeline history again unless it is already being
+// timed
+func (m *model) reloadStats() tea.Cmd {
+	if m.loads[statsView].loading {
+		return nil
+	}
+	return m.analyzeStats()
+}
+
+// analyzeStats starts timing the selected ref. Stats still loading for
+// another ref are ignored when they arrive.
+func (m *model) analyzeStats() tea.Cmd {
+	label := fmt.Sprintf("Timing the last %d pipelines", analysis.DefaultLast)
+	if m.statsRef != "" {
+		label += " of " + m.statsRef
+	}
+	return tea.Batch(m.startLoading(statsView, label), fetchStats(m.service, m.statsRef))
+}
+
+// applyStats shows loaded duration stats, keeping the cursor in bounds
+func (m *model) applyStats(msg statsMsg) {
+	if m.statsRef != "" && msg.ref != m.statsRef {
+		return
+	}
+	m.finishLoading(statsView, msg.err)
+	if msg.err != nil {
+		return
+	}
+	m.statsRef = msg.ref
+	m.statsReport = msg.report
+	m.lastRefresh = time.Now()
+	if m.statsCursor >= len(msg.report.Jobs) {
+		m.statsCursor = max(len(msg.report.Jobs)-1, 0)
+	}
+}
+
+// updateStats handles keys in the duration stats view
+func (m model) updateStats(key string) (tea.Model, tea.Cmd) {
+	var jobs []analysis.JobDurations
+	if m.statsReport != nil {
+		jobs = m.statsReport.Jobs
+	}
+	switch key {
+	case "up", "k":
+		if m.statsCursor > 0 {
+			m.statsCursor--
+		}
+	case "down", "j":
+		if m.statsCursor < len(jobs)-1 {
+			m.statsCursor++
+		}
+	case "g":
+		m.statsCursor = 0
+	case "G":
+		m.statsCursor = max(len(jobs)-1, 0)
+	case "ctrl+u":
+		m.statsCursor = max(m.statsCursor-5, 0)
+	case "ctrl+d":
+		m.statsCursor = max(min(m.statsCursor+5, len(jobs)-1), 0)
+	case "r":
+		return m, m.reloadStats()
+	case "esc":
+		m.currentView = m.homeView
+		return m, tea.ClearScreen
+	}
+	return m, nil
+}
+
+func (m model) renderStatsView(title string) string {
+	ref := m.statsRef
+	if ref == "" {
+		ref = "default branch"
+	}
+	header := headerStyle.Render(fmt.Sprintf("⏱️  Pipeline Durations (%s)", ref))
+	faint := lipgloss.NewStyle().Faint(true)
+
+	s := title + "\n"
+	s += header + "\n"
+	r := m.statsReport
+	if r != nil {
+		statusLine := fmt.Sprintf("📊 %d finished pipelines | %d successful | p50 %s | p90 %s | queued p50 %s",
+			r.Pipelines, r.Pipeline.Runs, formatSeconds(r.Pipeline.P50), formatSeconds(r.Pipeline.P90), formatSeconds(r.Queued.P50))
+		s += faint.Render(statusLine) + "\n"
+	}
+	s += m.renderLoadStatus(statsView) + "\n"
+
+	if r == nil {
+		return s + faint.Render("Navigation: r: refresh | Esc: back")
+	}
+	if len(r.Jobs) == 0 {
+		s += "No successful jobs to time in these pipelines.\n"
+		return s + "\n" + faint.Render("Navigation: r: refresh | Esc: back")
+	}
+
+	if r.Pipeline.Runs > 0 {
+		s += fmt.Sprintf("  %-43s %-9s %-9s %-9s %s\n", "Pipeline", formatSeconds(r.Pipeline.P50), formatSeconds(r.Pipeline.P90),
+			formatSeconds(r.Pipeline.Max), pendingStyle.Render(analysis.Sparkline(r.Pipeline.Trend, trendWidth)))
+	}
+	s += lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("  %-43s %-9s %-9s %-9s %s", "Stage", "p50", "p90", "max", "Trend")) + "\n"
+	for _, stage := range r.Stages {
+		s += fmt.Sprintf("  %-43s %-9s %-9s %-9s %s\n", truncateString(stage.Name, 43), formatSeconds(stage.P50), formatSeconds(stage.P90),
+			formatSeconds(stage.Max), pendingStyle.Render(analysis.Sparkline(stage.Trend, trendWidth)))
+	}
+
+	s += "\n" + lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("  %-30s %-12s %-9s %-9s %-9s %-9s %s", "Job", "Stage", "p50", "p90", "max", "queued", "Trend")) + "\n"
+
+	footer := "\n" + m.renderHelp("Slowest p90 first; only successful runs are timed, trends are oldest to newest")
+	footer += "\n" + m.renderHelp("Navigation: ↑/↓ or j/k | g/G: first/last | r: refresh | Esc: back")
+	start, end := m.scrollList(statsView, m.statsCursor, len(r.Jobs), m.listHeight(s, footer))
+
+	for i := start; i < end; i++ {
+		job := r.Jobs[i]
+
+		cursor := "  "
+		if i == m.statsCursor {
+			cursor = "▶ "
+		}
+
+		line := fmt.Sprintf("%s%-30s %-12s %-9s %-9s %-9s %-9s %s",
+			cursor,
+			truncateString(job.Name, 30),
+			truncateString(job.Stage, 12),
+			formatSeconds(job.P50),
+			formatSeconds(job.P90),
+			formatSeconds(job.Max),
+			formatSeconds(job.Queued.P50),
+			analysis.Sparkline(job.Trend, trendWidth))
+		if i == m.statsCursor {
+			line = selectedStyle.Render(line)
+		}
+		s += line + "\n"
+	}
+
+	s += footer
+	return s
+}
+
+// formatSeconds renders a duration, "-" when there is none
+func formatSeconds(seconds float64) string {
+	if d := core.FormatDuration(seconds); d != "" {
+		return d
+	}
+	return "-"
+}
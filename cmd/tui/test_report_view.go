@@ -0,0 +1,304 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+type testReportMsg struct {
+	service    *core.Service
+	pipelineID int
+	report     *core.TestReport
+	err        error
+}
+
+func fetchTestReport(service *core.Service, pipelineID int) tea.Cmd {
+	return func() tea.Msg {
+		report, err := service.TestReport(context.Background(), pipelineID)
+		return testReportMsg{service: service, pipelineID: pipelineID, report: report, err: err}
+	}
+}
+
+// testRow is a line of the test report view: a suite, or one of its cases
+type testRow struct {
+	suite    *core.TestSuite
+	testCase *core.TestCase // nil for the suite line
+}
+
+// openTestReport switches to the test report of the selected pipeline
+func (m *model) openTestReport() tea.Cmd {
+	if m.currentView != testReportView {
+		m.testParent = m.currentView
+	}
+	m.testReport = nil
+	m.testService = m.jobService
+	m.testPipelineID = m.selectedPipelineID
+	m.testCursor = 0
+	m.currentView = testReportView
+	return m.reloadTestReport()
+}
+
+// reloadTestReport refetches the test report, unless it is loading already
+func (m *model) reloadTestReport() tea.Cmd {
+	target := pipelineTarget{m.testService, m.testPipelineID}
+	if m.loadingFor(testReportView, target) {
+		return nil
+	}
+	label := fmt.Sprintf("Loading test report of pipeline #%d", m.testPipelineID)
+	return tea.Batch(m.startLoadingFor(testReportView, target, label), fetchTestReport(m.testService, m.testPipelineID))
+}
+
+// applyTestReport shows a loaded test report, keeping the cursor in bounds
+func (m *model) applyTestReport(msg testReportMsg) {
+	if msg.service != m.testService || msg.pipelineID != m.testPipelineID {
+		m.dropStale(testReportView, pipelineTarget{msg.service, msg.pipelineID})
+		return
+	}
+	m.finishLoading(testReportView, msg.err)
+	if msg.err != nil {
+		return
+	}
+	m.testReport = msg.report
+	m.lastRefresh = time.Now()
+	if rows := m.testRows(); m.testCursor >= len(rows) {
+		m.testCursor = max(len(rows)-1, 0)
+	}
+}
+
+// testRows lists the suites with their failed cases, or with all cases
+func (m model) testRows() []testRow {
+	if m.testReport == nil {
+		return nil
+	}
+	var rows []testRow
+	for i := range m.testReport.Suites {
+		suite := &m.testReport.Suites[i]
+		rows = append(rows, testRow{suite: suite})
+		for j := range suite.Cases {
+			if m.testAllCases || suite.Cases[j].Failed() {
+				rows = append(rows, testRow{suite: suite, testCase: &suite.Cases[j]})
+			}
+		}
+	}
+	return rows
+}
+
+// testJobsRunning reports whether jobs of the pipeline may still add test
+// results, which is when the report is worth polling
+func (m model) testJobsRunning() bool {
+	if m.selectedPipelineID != m.testPipelineID {
+		return false
+	}
+	for _, job := range m.jobs {
+		if core.IsActive(job.Status) {
+			return true
+		}
+	}
+	return false
+}
+
+// showTestCase opens the stack trace and output of a test case in the log
+// viewer
+func (m *model) showTestCase(suite *core.TestSuite, c *core.TestCase) tea.Cmd {
+	m.stopFollowingLogs()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Status:  %s\n", c.Status)
+	if c.Classname != "" {
+		fmt.Fprintf(&b, "Class:   %s\n", c.Classname)
+	}
+	if c.File != "" {
+		fmt.Fprintf(&b, "File:    %s\n", c.File)
+	}
+	if c.Time > 0 {
+		fmt.Fprintf(&b, "Time:    %.2fs\n", c.Time)
+	}
+	if c.StackTrace != "" {
+		fmt.Fprintf(&b, "\n── Stack trace ──\n%s\n", strings.TrimRight(c.StackTrace, "\n"))
+	}
+	if c.SystemOutput != "" {
+		fmt.Fprintf(&b, "\n── System output ──\n%s\n", strings.TrimRight(c.SystemOutput, "\n"))
+	}
+	if c.StackTrace == "" && c.SystemOutput == "" {
+		b.WriteString("\nThe report has no output for this test case.\n")
+	}
+
+	m.setLogs(b.String())
+	m.logSource = suite.Name + " › " + c.Name
+	m.selectedJobID = 0
+	if len(suite.JobIDs) > 0 {
+		m.selectedJobID = suite.JobIDs[0]
+	}
+	m.logCursor = 0
+	m.logParent = testReportView
+	m.currentView = logView
+	return tea.ClearScreen
+}
+
+// updateTestReport handles keys in the test report view
+func (m model) updateTestReport(key string) (tea.Model, tea.Cmd) {
+	rows := m.testRows()
+	switch key {
+	case "up", "k":
+		if m.testCursor > 0 {
+			m.testCursor--
+		}
+	case "down", "j":
+		if m.testCursor < len(rows)-1 {
+			m.testCursor++
+		}
+	case "g":
+		m.testCursor = 0
+	case "G":
+		m.testCursor = max(len(rows)-1, 0)
+	case "ctrl+u":
+		m.testCursor = max(m.testCursor-5, 0)
+	case "ctrl+d":
+		m.testCursor = max(min(m.testCursor+5, len(rows)-1), 0)
+	case "f":
+		// Toggle between failed cases only and every case, keeping the
+		// cursor on the same suite
+		var suite *core.TestSuite
+		if m.testCursor < len(rows) {
+			suite = rows[m.testCursor].suite
+		}
+		m.testAllCases = !m.testAllCases
+		m.testCursor = 0
+		for i, row := range m.testRows() {
+			if row.suite == suite && row.testCase == nil {
+				m.testCursor = i
+				break
+			}
+		}
+	case "r":
+		return m, m.reloadTestReport()
+	case "enter":
+		if m.testCursor >= len(rows) {
+			break
+		}
+		row := rows[m.testCursor]
+		if row.testCase != nil {
+			return m, m.showTestCase(row.suite, row.testCase)
+		}
+		// A suite opens the log of the job that reported it
+		if len(row.suite.JobIDs) > 0 {
+			m.logParent = testReportView
+			return m, tea.Batch(tea.ClearScreen, m.loadLogs(core.Job{ID: row.suite.JobIDs[0], Name: row.suite.Name}))
+		}
+	case "esc":
+		m.currentView = m.testParent
+		return m, tea.ClearScreen
+	}
+	return m, nil
+}
+
+// testJobNames names the jobs that reported a suite, e.g. "test-unit
+// #11100002", using the loaded job list where it has them
+func (m model) testJobNames(ids []int) string {
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		name := "#" + strconv.Itoa(id)
+		if m.selectedPipelineID == m.testPipelineID {
+			for _, job := range m.jobs {
+				if job.ID == id {
+					name = job.Name + " " + name
+					break
+				}
+			}
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func (m model) renderTestReportView(title string) string {
+	header := headerStyle.Render(fmt.Sprintf("🧪 Test Report (Pipeline #%d)", m.testPipelineID))
+
+	s := title + "\n"
+	s += header + "\n"
+	if r := m.testReport; r != nil {
+		statusLine := fmt.Sprintf("📊 %s | %d suites", r.TestCounts, len(r.Suites))
+		if d := core.FormatDuration(r.Time); d != "" {
+			statusLine += " | ⏱️ " + d
+		}
+		if m.testAllCases {
+			statusLine += " | all cases"
+		} else {
+			statusLine += " | failures only"
+		}
+		s += lipgloss.NewStyle().Faint(true).Render(statusLine) + "\n"
+	}
+	s += m.renderLoadStatus(testReportView) + "\n"
+
+	if r := m.testReport; r != nil && len(r.Suites) == 0 {
+		s += "This pipeline has no test report. Jobs publish one with artifacts:reports:junit.\n"
+	}
+
+	rows := m.testRows()
+	footer := "\n" + m.renderHelp("Navigation: ↑/↓ or j/k | g/G: first/last | Enter: test output / job log | f: failures only / all cases | r: refresh | Esc: back")
+	start, end := m.scrollList(testReportView, m.testCursor, len(rows), m.listHeight(s, footer))
+
+	for i := start; i < end; i++ {
+		row := rows[i]
+
+		cursor := "  "
+		if i == m.testCursor {
+			cursor = "▶ "
+		}
+
+		var line string
+		if c := row.testCase; c != nil {
+			name := c.Name
+			if c.Classname != "" {
+				name = c.Classname + " › " + c.Name
+			}
+			line = fmt.Sprintf("%s    %s %-60s %7.2fs", cursor, testCaseIcon(c.Status), truncateString(name, 60), c.Time)
+		} else {
+			suite := row.suite
+			status := "success"
+			if !suite.Passed() || suite.SuiteError != "" {
+				status = "failed"
+			}
+			line = fmt.Sprintf("%s%s %-30s %-40s %8s",
+				cursor,
+				getStyledStatus(status, getStatusIcon(status)),
+				truncateString(suite.Name, 30),
+				suite.TestCounts,
+				core.FormatDuration(suite.Time))
+			if len(suite.JobIDs) > 0 {
+				line += "  " + lipgloss.NewStyle().Faint(true).Render(m.testJobNames(suite.JobIDs))
+			}
+			if suite.SuiteError != "" {
+				line += "\n      " + failedStyle.Render("⚠ "+suite.SuiteError)
+			}
+		}
+
+		if i == m.testCursor {
+			line = selectedStyle.Render(line)
+		}
+		s += line + "\n"
+	}
+
+	s += footer
+	return s
+}
+
+// testCaseIcon styles the status of a test case
+func testCaseIcon(status string) string {
+	switch status {
+	case "success":
+		return successStyle.Render("✓")
+	case "failed":
+		return failedStyle.Render("✗")
+	case "error":
+		return failedStyle.Render("⚠")
+	}
+	return pendingStyle.Render("○")
+}
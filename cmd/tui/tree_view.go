@@ -0,0 +1,312 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+type treeRowKind int
+
+const (
+	pipelineRow treeRowKind = iota
+	stageRow
+	jobRow
+)
+
+// treeRow is one visible line of the pipeline tree
+type treeRow struct {
+	kind   treeRowKind
+	key    string // Stable identity for the expanded state
+	depth  int
+	node   *core.PipelineNode // Pipeline the row belongs to
+	stage  string
+	job    core.Job
+	status string
+}
+
+// treeStage is a stage of a pipeline with its jobs and the downstream
+// pipelines its bridge jobs triggered
+type treeStage struct {
+	name       string
+	jobs       []core.Job
+	downstream []*core.PipelineNode
+}
+
+// openTree shows the tree view and loads the downstream tree of a pipeline
+func (m *model) openTree(service *core.Service, pipelineID int) tea.Cmd {
+	m.tree = nil
+	m.treeService = service
+	m.treePipelineID = pipelineID
+	m.treeJobs = make(map[int][]core.Job)
+	m.treePending = make(map[int]bool)
+	m.treeExpanded = make(map[string]bool)
+	m.treeCursor = 0
+	m.treeParent = m.currentView
+	m.currentView = treeView
+
+	return tea.Batch(m.startLoading(treeView, "Loading downstream pipelines"), fetchTree(service, pipelineID))
+}
+
+// toggleTreeNode expands or collapses a pipeline, loading its jobs the
+// first time it is opened
+func (m *model) toggleTreeNode(node *core.PipelineNode) tea.Cmd {
+	key := pipelineKey(node)
+	if m.treeExpanded[key] {
+		m.treeExpanded[key] = false
+		return nil
+	}
+	m.treeExpanded[key] = true
+
+	id := node.Pipeline.ID
+	if _, ok := m.treeJobs[id]; ok || m.treePending[id] {
+		return nil
+	}
+	m.treePending[id] = true
+	return tea.Batch(m.spin(), fetchTreeJobs(m.treeService.ForProject(node.Project), id))
+}
+
+// reloadTreeJobs refetches the jobs of every expanded pipeline after the
+// tree was refreshed. The old jobs stay visible until the new ones arrive.
+func (m *model) reloadTreeJobs() tea.Cmd {
+	var cmds []tea.Cmd
+	m.tree.Walk(func(node *core.PipelineNode, _ int) {
+		id := node.Pipeline.ID
+		if !m.treeExpanded[pipelineKey(node)] || m.treePending[id] {
+			return
+		}
+		m.treePending[id] = true
+		cmds = append(cmds, fetchTreeJobs(m.treeService.ForProject(node.Project), id))
+	})
+	if len(cmds) == 0 {
+		return nil
+	}
+	return tea.Batch(append(cmds, m.spin())...)
+}
+
+// treeRows flattens the expanded part of the tree into visible rows
+func (m model) treeRows() []treeRow {
+	if m.tree == nil {
+		return nil
+	}
+
+	var rows []treeRow
+	var addPipeline func(node *core.PipelineNode, depth int)
+	addPipeline = func(node *core.PipelineNode, depth int) {
+		key := pipelineKey(node)
+		rows = append(rows, treeRow{kind: pipelineRow, key: key, depth: depth, node: node, status: node.AggregateStatus()})
+		if !m.treeExpanded[key] {
+			return
+		}
+
+		for _, stage := range pipelineStages(m.treeJobs[node.Pipeline.ID], node.Children) {
+			stageKey := key + "/" + stage.name
+			rows = append(rows, treeRow{kind: stageRow, key: stageKey, depth: depth + 1, node: node, stage: stage.name, status: stage.status()})
+			if !m.treeExpanded[stageKey] {
+				continue
+			}
+			for _, job := range stage.jobs {
+				rows = append(rows, treeRow{kind: jobRow, key: fmt.Sprintf("%s/%d", stageKey, job.ID), depth: depth + 2, node: node, stage: stage.name, job: job, status: job.Status})
+			}
+			for _, child := range stage.downstream {
+				addPipeline(child, depth+2)
+			}
+		}
+	}
+	addPipeline(m.tree, 0)
+	return rows
+}
+
+// pipelineStages groups jobs by stage in pipeline order and attaches each
+// downstream pipeline to the stage of the bridge that triggered it
+func pipelineStages(jobs []core.Job, children []*core.PipelineNode) []*treeStage {
+	// GitLab lists jobs newest first; creation order follows stage order
+	ordered := append([]core.Job(nil), jobs...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].ID < ordered[j].ID })
+
+	var stages []*treeStage
+	byName := make(map[string]*treeStage)
+	stageFor := func(name string) *treeStage {
+		if stage, ok := byName[name]; ok {
+			return stage
+		}
+		stage := &treeStage{name: name}
+		byName[name] = stage
+		stages = append(stages, stage)
+		return stage
+	}
+
+	for _, job := range ordered {
+		stage := stageFor(job.Stage)
+		stage.jobs = append(stage.jobs, job)
+	}
+	for _, child := range children {
+		name := "downstream"
+		if child.Trigger != nil && child.Trigger.Stage != "" {
+			name = child.Trigger.Stage
+		}
+		stage := stageFor(name)
+		stage.downstream = append(stage.downstream, child)
+	}
+	return stages
+}
+
+// status aggregates the jobs and downstream pipelines of a stage
+func (s *treeStage) status() string {
+	node := &core.PipelineNode{Children: s.downstream}
+	for _, job := range s.jobs {
+		node.Children = append(node.Children, &core.PipelineNode{Pipeline: core.Pipeline{Status: job.Status}})
+	}
+	return node.AggregateStatus()
+}
+
+func pipelineKey(node *core.PipelineNode) string {
+	return fmt.Sprintf("%s#%d", node.Project, node.Pipeline.ID)
+}
+
+// updateTree handles keys in the tree view
+func (m model) updateTree(key string) (tea.Model, tea.Cmd) {
+	rows := m.treeRows()
+	if len(rows) == 0 {
+		return m, nil
+	}
+	if m.treeCursor >= len(rows) {
+		m.treeCursor = len(rows) - 1
+	}
+	row := rows[m.treeCursor]
+
+	switch key {
+	case "up", "k":
+		if m.treeCursor > 0 {
+			m.treeCursor--
+		}
+	case "down", "j":
+		if m.treeCursor < len(rows)-1 {
+			m.treeCursor++
+		}
+	case "g":
+		m.treeCursor = 0
+	case "G":
+		m.treeCursor = len(rows) - 1
+	case "right", "l":
+		switch row.kind {
+		case pipelineRow:
+			if !m.treeExpanded[row.key] {
+				return m, m.toggleTreeNode(row.node)
+			}
+		case stageRow:
+			m.treeExpanded[row.key] = true
+		}
+	case "left", "h":
+		if row.kind != jobRow && m.treeExpanded[row.key] {
+			m.treeExpanded[row.key] = false
+			return m, nil
+		}
+		// Jump to the enclosing row
+		for i := m.treeCursor - 1; i >= 0; i-- {
+			if rows[i].depth < row.depth {
+				m.treeCursor = i
+				break
+			}
+		}
+	case " ":
+		switch row.kind {
+		case pipelineRow:
+			return m, m.toggleTreeNode(row.node)
+		case stageRow:
+			m.treeExpanded[row.key] = !m.treeExpanded[row.key]
+		}
+	case "enter":
+		service := m.treeService.ForProject(row.node.Project)
+		switch row.kind {
+		case jobRow:
+			m.jobService = service
+			m.logParent = treeView
+			return m, tea.Batch(tea.ClearScreen, m.loadLogs(row.job))
+		case pipelineRow:
+			return m, tea.Batch(tea.ClearScreen, m.openJobs(service, row.node.Pipeline.ID))
+		case stageRow:
+			m.treeExpanded[row.key] = !m.treeExpanded[row.key]
+		}
+	}
+	return m, nil
+}
+
+func (m model) renderTreeView(title string) string {
+	header := headerStyle.Render(fmt.Sprintf("🌳 Pipeline Tree (Pipeline #%d)", m.treePipelineID))
+	help := m.renderHelp("Navigation: ↑/↓ or j/k | →/l: expand | ←/h: collapse/parent | Space: toggle | Enter: job logs/pipeline jobs | Esc: back")
+
+	if m.tree == nil {
+		return title + "\n" + header + "\n" + m.renderLoadStatus(treeView) + "\n" + help
+	}
+
+	downstream := -1 // Don't count the root
+	m.tree.Walk(func(*core.PipelineNode, int) { downstream++ })
+	statusLine := fmt.Sprintf("📊 %d downstream pipelines | overall %s", downstream, m.tree.AggregateStatus())
+
+	s := title + "\n"
+	s += header + "\n"
+	s += lipgloss.NewStyle().Faint(true).Render(statusLine) + "\n\n"
+
+	rows := m.treeRows()
+	start, end := m.scrollList(treeView, m.treeCursor, len(rows), m.listHeight(s, "\n"+help))
+
+	for i := start; i < end; i++ {
+		row := rows[i]
+
+		cursor := "  "
+		if i == m.treeCursor {
+			cursor = "▶ "
+		}
+
+		var label string
+		switch row.kind {
+		case pipelineRow:
+			label = fmt.Sprintf("%s %s #%d %s", m.expandMarker(row.key), row.node.Pipeline.ProjectName, row.node.Pipeline.ID, getBetterBranchName(row.node.Pipeline.Ref))
+			if row.node.Trigger != nil {
+				label = fmt.Sprintf("%s %s → %s #%d", m.expandMarker(row.key), row.node.Trigger.Name, row.node.Pipeline.ProjectName, row.node.Pipeline.ID)
+			}
+			if row.node.MultiProject {
+				label += " (multi-project)"
+			}
+			if row.node.Err != nil {
+				label += " ⚠️"
+			}
+			if m.treePending[row.node.Pipeline.ID] {
+				label += " " + m.spinner()
+			}
+		case stageRow:
+			label = fmt.Sprintf("%s %s", m.expandMarker(row.key), row.stage)
+		case jobRow:
+			label = fmt.Sprintf("  %s", row.job.Name)
+			if row.job.Duration != "" {
+				label += lipgloss.NewStyle().Faint(true).Render("  " + row.job.Duration)
+			}
+		}
+
+		changeKey := pipelineChangeKey(row.node.Pipeline.ID)
+		switch row.kind {
+		case stageRow:
+			changeKey = ""
+		case jobRow:
+			changeKey = jobChangeKey(row.job.ID)
+		}
+
+		line := fmt.Sprintf("%s%s%s %s", cursor, strings.Repeat("  ", row.depth), getStyledStatus(row.status, getStatusIcon(row.status)), label)
+		s += m.highlight(line, changeKey, i == m.treeCursor) + "\n"
+	}
+
+	s += "\n" + help
+	return s
+}
+
+func (m model) expandMarker(key string) string {
+	if m.treeExpanded[key] {
+		return "▾"
+	}
+	return "▸"
+}
@@ -1,9 +1,8 @@
 package tui
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/url"
 	"os/exec"
 	"strconv"
 	"strings"
@@ -11,8 +10,11 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/rkristelijn/glab-tui/internal/analysis"
+	"github.com/rkristelijn/glab-tui/internal/config"
 	"github.com/rkristelijn/glab-tui/internal/core"
 	"github.com/rkristelijn/glab-tui/internal/gitlab"
+	"github.com/rkristelijn/glab-tui/internal/joblog"
 )
 
 var (
@@ -49,18 +51,20 @@ func Run() error {
 	fmt.Println("🚀 GitLab TUI - Pipeline Monitor")
 	fmt.Println("⚡ Loading pipeline data...")
 
-	// Auto-detect current project
-	projectPath, err := getCurrentProjectPath()
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Auto-detect current project and pick a backend
+	service, err := gitlab.NewService(cfg, "")
 	if err != nil {
 		fmt.Printf("❌ Could not detect GitLab project: %v\n", err)
 		fmt.Println("💡 Make sure you're in a GitLab repository and authenticated with 'glab auth login'")
 		return err
 	}
 
-	model := initialModel(projectPath)
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	_, err = p.Run()
-	return err
+	return start(service, false, pipelineView)
 }
 
 type viewMode int
@@ -69,21 +73,38 @@ const (
 	pipelineView viewMode = iota
 	jobView
 	logView
+	treeView
+	graphView
+	dashboardView
+	mergeRequestView
+	mrPipelineView
+	artifactView
+	testReportView
+	flakyView
+	statsView
 )
 
-// Timer message for real-time updates
-type tickMsg time.Time
+// traceMsg delivers the next chunk of a followed job log
+type traceMsg struct {
+	jobID  int
+	chunk  core.TraceChunk
+	closed bool // The follower stopped, no more chunks for this job
+}
 
-func tickCmd() tea.Cmd {
-	return tea.Tick(time.Second*3, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
+// waitForTrace reads the next chunk from a log follower
+func waitForTrace(jobID int, trace <-chan core.TraceChunk) tea.Cmd {
+	return func() tea.Msg {
+		chunk, ok := <-trace
+		return traceMsg{jobID: jobID, chunk: chunk, closed: !ok}
+	}
 }
 
 type model struct {
 	// View state
 	currentView viewMode
+	homeView    viewMode // pipelineView, or dashboardView in dashboard mode
 	projectPath string
+	demo        bool // Mock data, no real GitLab connection
 
 	// Pipeline view
 	pipelines        []core.Pipeline
@@ -94,558 +115,626 @@ type model struct {
 	jobs               []core.Job
 	jobCursor          int
 	selectedPipelineID int
+	jobService         *core.Service // Scoped to the project of the selected pipeline
+	jobParent          viewMode      // View to return to on Esc
+	jobSelected        map[int]struct{}
+
+	// Graph view of the selected pipeline
+	graphNeeds    map[string][]string
+	graphNeedsErr error
+	graphCol      int
+	graphRow      int
 
 	// Log view
 	logs          string
 	selectedJobID int
-	logCursor     int    // For navigating through log lines
-	searchMode    bool   // Whether we're in search mode
-	searchQuery   string // Current search query
+	logCursor     int // Log line under the cursor
+	search        logSearch
+	logTrace      <-chan core.TraceChunk
+	stopLogTrace  context.CancelFunc
+	logParent     viewMode      // View to return to on Esc
+	logSource     string        // Label of an artifact or test output shown instead of a job log
+	logParsed     *joblog.Trace // m.logs parsed so far, extended as the trace grows
+	logLines      []joblog.Line // m.logs as the terminal would show it
+	logSections   []joblog.Section
+	logFolds      map[int]bool // Sections folded or unfolded by the user, by header line
+	logVisible    []int        // Lines outside collapsed sections, see layoutLog
+	logFailures   []int        // Lines reporting failures, in order
+	logAtFailure  bool         // Move to the first failure once the log arrives
+	logWrap       bool         // Wrap long lines instead of scrolling sideways
+	logScroll     int          // Columns scrolled sideways
+
+	// Failure detection in logs, LOG_ERROR_SETS and LOG_ERROR_PATTERN
+	errorMatcher    *joblog.Matcher
+	errorMatcherErr error
+
+	// Tree view
+	tree           *core.PipelineNode
+	treeService    *core.Service
+	treePipelineID int
+	treeJobs       map[int][]core.Job // Jobs per pipeline, loaded on first expand
+	treePending    map[int]bool       // Pipelines whose jobs are being loaded
+	treeExpanded   map[string]bool
+	treeCursor     int
+	treeParent     viewMode
+
+	// Dashboard view
+	dashboard       []core.DashboardRow
+	dashboardCursor int
+
+	// Merge request views
+	mergeRequests    []core.MergeRequest
+	mrCursor         int
+	selectedMR       core.MergeRequest
+	mrPipelines      []core.Pipeline
+	mrPipelineCursor int
+
+	// Artifacts view of a job
+	artifacts        *core.Artifacts
+	artifactFiles    []core.ArtifactFile
+	artifactCursor   int
+	artifactSelected map[string]struct{}
+	artifactJob      core.Job
+	artifactService  *core.Service
+	artifactParent   viewMode
+
+	// Test report of the selected pipeline
+	testReport     *core.TestReport
+	testService    *core.Service
+	testPipelineID int
+	testCursor     int
+	testAllCases   bool // Show passed and skipped cases, not only failures
+	testParent     viewMode
+
+	// Flaky jobs and tests in the pipeline history of a ref
+	flakyReport *analysis.Report
+	flakyRef    string
+	flakyCursor int
+
+	// Duration stats of the pipeline history of a ref
+	statsReport *analysis.DurationReport
+	statsRef    string
+	statsCursor int
+
+	// Pipeline and job actions
+	pendingAction *action // Waiting for confirmation
+	notice        string  // Outcome of the last action
+	noticeErr     bool
+	noticeAt      time.Time
+	pendingBulk   *bulkAction // Bulk action on the selection, waiting for confirmation
+	bulkResults   *bulkMsg    // Per-item outcome of the last bulk action
+	runForm       *runForm    // Form for a new pipeline, nil when closed
+
+	// Background loading
+	loads        map[viewMode]loadStatus
+	spinning     bool
+	spinnerFrame int
+	changed      map[string]time.Time // Rows whose status changed, by change key
+	listOffsets  map[viewMode]int     // First row shown of each list view
+
+	// Data access, shared with the CLI
+	service     *core.Service
+	lastRefresh time.Time
+
+	// Terminal size, 0 until the terminal reports it
+	width  int
+	height int
+}
 
-	// GitLab wrapper
-	gitlab *gitlab.GlabWrapper
+func newModel(service *core.Service, demo bool, home viewMode) model {
+	m := model{
+		currentView:      home,
+		homeView:         home,
+		projectPath:      service.Project(),
+		demo:             demo,
+		pipelineCursor:   0,
+		pipelineSelected: make(map[int]struct{}),
+		jobSelected:      make(map[int]struct{}),
+		loads:            make(map[viewMode]loadStatus),
+		changed:          make(map[string]time.Time),
+		listOffsets:      make(map[viewMode]int),
+		service:          service,
+	}
+
+	// A broken pattern shouldn't hide the failures the defaults would find
+	m.errorMatcher, m.errorMatcherErr = service.ErrorMatcher()
+	if m.errorMatcherErr != nil {
+		m.errorMatcher = joblog.DefaultMatcher()
+	}
+
+	// Init fetches the data and ticks the spinner
+	label, _ := m.homeLoad()
+	m.startLoading(home, label)
+
+	return m
 }
 
-func initialModel(projectPath string) model {
-	// Try to get real data using the same approach as CLI
-	pipelines, err := getProjectPipelinesViaGlab(projectPath)
-	if err != nil {
-		// Fall back to mock data
-		pipelines = core.GetMockPipelines()
+// homeLoad returns what loading the home view shows and the command doing it
+func (m model) homeLoad() (string, tea.Cmd) {
+	if m.homeView == dashboardView {
+		return "Loading dashboard", fetchDashboard(m.service)
 	}
+	return "Loading pipelines", fetchPipelines(m.service)
+}
 
-	return model{
-		currentView:      pipelineView,
-		projectPath:      projectPath,
-		pipelines:        pipelines,
-		pipelineCursor:   0,
-		pipelineSelected: make(map[int]struct{}),
-		gitlab:           gitlab.NewGlabWrapper(projectPath),
+// refresh reloads the data of the home view in the background
+func (m *model) refresh() tea.Cmd {
+	if m.loads[m.homeView].loading {
+		return nil
 	}
+	label, fetch := m.homeLoad()
+	return tea.Batch(m.startLoading(m.homeView, label), fetch)
 }
 
 func (m model) Init() tea.Cmd {
-	return nil
+	_, fetch := m.homeLoad()
+	return tea.Batch(spinnerTick(), fetch, refreshTick(m.service.RefreshInterval()), clockTick())
+}
+
+// start runs the TUI on top of a service, opening the given view
+func start(service *core.Service, demo bool, home viewMode) error {
+	m := newModel(service, demo, home)
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	_, err := p.Run()
+	return err
 }
 
 // StartWithMockData starts the TUI with mock data for demo purposes
 func StartWithMockData() error {
 	fmt.Println("🚀 Starting GitLab TUI Demo Mode...")
 
-	// Create model with mock data
-	m := model{
-		currentView:      pipelineView,
-		projectPath:      "demo-project",
-		pipelines:        core.GetMockPipelines(),
-		pipelineCursor:   0,
-		pipelineSelected: make(map[int]struct{}),
-		gitlab:           nil, // No real GitLab connection in demo mode
-	}
-
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
-	return err
+	return start(core.NewMockService(nil), true, pipelineView)
 }
 
 // StartWithRemoteProject starts the TUI with a remote GitLab project
 func StartWithRemoteProject(projectPath string) error {
 	fmt.Printf("🚀 Starting GitLab TUI for remote project: %s\n", projectPath)
 
-	// Try to get pipelines for the remote project
-	pipelines, err := getRemoteProjectPipelines(projectPath)
+	cfg, err := config.Load()
 	if err != nil {
-		fmt.Printf("❌ Could not load pipelines from remote project: %v\n", err)
-		fmt.Println("💡 Make sure you're authenticated with 'glab auth login'")
-		return err
-	}
-
-	// Create model with remote data
-	m := model{
-		currentView:      pipelineView,
-		projectPath:      projectPath,
-		pipelines:        pipelines,
-		pipelineCursor:   0,
-		pipelineSelected: make(map[int]struct{}),
-		gitlab:           nil, // Use nil for remote mode, handle in Update()
+		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err = p.Run()
-	return err
-}
-
-// getRemoteProjectPipelines gets pipelines for a remote GitLab project
-func getRemoteProjectPipelines(projectPath string) ([]core.Pipeline, error) {
-	fmt.Printf("📡 Fetching real pipelines for %s...\n", projectPath)
-
-	// Use glab ci list with remote project (correct command)
-	cmd := exec.Command("glab", "ci", "list", "--repo", projectPath, "--per-page", "10")
-	output, err := cmd.Output()
+	service, err := gitlab.NewService(cfg, projectPath)
 	if err != nil {
-		fmt.Printf("❌ Failed to get pipelines: %v\n", err)
+		fmt.Printf("❌ Could not connect to remote project: %v\n", err)
 		fmt.Println("💡 Make sure you're authenticated with 'glab auth login'")
-		fmt.Println("📝 Falling back to mock data for demonstration")
-		return core.GetMockPipelines(), nil
-	}
-
-	fmt.Printf("📝 Pipeline data received:\n%s\n", string(output))
-
-	// Parse glab ci list output
-	pipelines := parseGlabCIListOutput(string(output))
-	if len(pipelines) == 0 {
-		fmt.Println("📝 No pipelines found, using mock data for demonstration")
-		return core.GetMockPipelines(), nil
+		return err
 	}
 
-	fmt.Printf("✅ Loaded %d real pipelines from %s\n", len(pipelines), projectPath)
-	return pipelines, nil
+	return start(service, false, pipelineView)
 }
 
-// parseGlabCIListOutput parses the output from 'glab ci list'
-func parseGlabCIListOutput(output string) []core.Pipeline {
-	var pipelines []core.Pipeline
-	lines := strings.Split(output, "\n")
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "Showing") || strings.HasPrefix(line, "State") || strings.HasPrefix(line, "---") {
-			continue
-		}
-
-		// Parse line format: (status) • #ID (#IID) ref (time ago)
-		if strings.Contains(line, "•") && strings.Contains(line, "#") {
-			// Extract status
-			statusStart := strings.Index(line, "(")
-			statusEnd := strings.Index(line, ")")
-			if statusStart == -1 || statusEnd == -1 {
-				continue
-			}
-			status := line[statusStart+1 : statusEnd]
-
-			// Extract pipeline ID
-			idStart := strings.Index(line, "#")
-			if idStart == -1 {
-				continue
-			}
-			idPart := line[idStart+1:]
-			idEnd := strings.Index(idPart, "\t")
-			if idEnd == -1 {
-				continue
-			}
-			idStr := idPart[:idEnd]
-			id, err := strconv.Atoi(idStr)
-			if err != nil {
-				continue
-			}
-
-			// Extract ref (branch/tag) and time
-			parts := strings.Split(line, "\t")
-			if len(parts) < 4 {
-				continue
-			}
-
-			iidPart := strings.TrimSpace(parts[1]) // (#IID)
-			ref := strings.TrimSpace(parts[2])
-			timeAgo := strings.TrimSpace(parts[3])
-
-			// Clean up time format
-			timeAgo = strings.TrimPrefix(timeAgo, "(")
-			timeAgo = strings.TrimSuffix(timeAgo, ")")
-
-			// Create pipeline with better info
-			pipeline := core.Pipeline{
-				ID:          id,
-				Status:      status,
-				Ref:         ref,
-				ProjectName: iidPart, // Use IID as project identifier
-				Jobs:        "loading...",
-				Duration:    timeAgo, // Use actual time instead of "unknown"
-			}
-
-			pipelines = append(pipelines, pipeline)
-		}
+// setPipelines applies a loaded pipeline list, keeping the cursor in bounds
+func (m *model) setPipelines(pipelines []core.Pipeline) {
+	m.markChanges(pipelineStatuses(m.pipelines), pipelineStatuses(pipelines))
+	m.pipelines = pipelines
+	m.lastRefresh = time.Now()
+	listed := make(map[int]bool, len(pipelines))
+	for _, p := range pipelines {
+		listed[p.ID] = true
+	}
+	pruneSelection(m.pipelineSelected, listed)
+	if len(m.pipelines) == 0 {
+		m.pipelineCursor = 0
+	} else if m.pipelineCursor >= len(m.pipelines) {
+		m.pipelineCursor = len(m.pipelines) - 1
 	}
-
-	return pipelines
 }
 
-// getRemotePipelineJobsWithChildren gets jobs and child pipelines for a pipeline
-func getRemotePipelineJobsWithChildren(projectPath string, pipelineID int) ([]core.Job, error) {
-	fmt.Printf("📡 Fetching jobs and child pipelines for pipeline %d in %s...\n", pipelineID, projectPath)
+// openJobs switches to the job view of a pipeline and loads its jobs. The
+// service decides the project, so downstream pipelines in other projects
+// can be opened too.
+func (m *model) openJobs(service *core.Service, pipelineID int) tea.Cmd {
+	if m.currentView != jobView {
+		m.jobParent = m.currentView
+	}
+	m.jobs = nil
+	m.jobSelected = make(map[int]struct{})
+	m.jobService = service
+	m.jobCursor = 0
+	m.selectedPipelineID = pipelineID
+	m.currentView = jobView
+	return m.reloadJobs()
+}
 
-	// First get regular jobs
-	jobs, err := getRemotePipelineJobs(projectPath, pipelineID)
-	if err != nil {
-		return jobs, err
+// reloadJobs refetches the jobs of the selected pipeline, unless they are
+// loading already. Jobs still loading for another pipeline don't count.
+func (m *model) reloadJobs() tea.Cmd {
+	target := pipelineTarget{m.jobService, m.selectedPipelineID}
+	if m.loadingFor(jobView, target) {
+		return nil
 	}
+	label := fmt.Sprintf("Loading jobs of pipeline #%d", m.selectedPipelineID)
+	return tea.Batch(m.startLoadingFor(jobView, target, label), fetchJobs(m.jobService, m.selectedPipelineID))
+}
 
-	// Then try to find child pipelines (triggered by this pipeline)
-	childPipelines, err := getRecentChildPipelines(projectPath, pipelineID)
-	if err == nil && len(childPipelines) > 0 {
-		fmt.Printf("🔗 Found %d child pipelines, adding them as jobs\n", len(childPipelines))
-
-		// Convert child pipelines to job-like entries
-		for _, pipeline := range childPipelines {
-			// Use the real app name from logs if available
-			appName := pipeline.ProjectName
-			if appName == "" {
-				// Fallback to smart detection if no app name from logs
-				branchName := getBetterBranchName(pipeline.Ref)
-				if strings.Contains(branchName, "frontend") {
-					appName = "frontend-app"
-				} else if strings.Contains(branchName, "backend") {
-					appName = "backend-api"
-				} else {
-					appName = "unknown-app"
-				}
-			}
-
-			// Create descriptive name with status indicator and real app name
-			statusIcon := getStatusIcon(pipeline.Status)
-			childName := fmt.Sprintf("🔗 %s %s #%d", statusIcon, appName, pipeline.ID)
+// loadLogs opens the log view for a job and starts following its trace
+func (m *model) loadLogs(job core.Job) tea.Cmd {
+	m.stopFollowingLogs()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.logTrace = m.jobService.FollowJobLogs(ctx, job.ID, m.service.RefreshInterval())
+	m.stopLogTrace = cancel
+
+	m.setLogs("")
+	m.logSource = ""
+	m.selectedJobID = job.ID
+	m.currentView = logView
+	m.logCursor = 0 // Reset log cursor
+	m.logAtFailure = job.Status == "failed"
+	return tea.Batch(m.startLoading(logView, "Loading job log"), waitForTrace(job.ID, m.logTrace))
+}
 
-			childJob := core.Job{
-				ID:     pipeline.ID,
-				Name:   childName,
-				Status: pipeline.Status,
-				Stage:  "child-pipeline",
-			}
-			jobs = append(jobs, childJob)
-		}
+// stopFollowingLogs cancels the trace follower of the log view, if any
+func (m *model) stopFollowingLogs() {
+	if m.stopLogTrace != nil {
+		m.stopLogTrace()
 	}
-
-	return jobs, nil
+	m.logTrace = nil
+	m.stopLogTrace = nil
+	delete(m.loads, logView)
 }
 
-// getRecentChildPipelines tries to find child pipelines by looking for recent pipelines
-func getRecentChildPipelines(projectPath string, parentPipelineID int) ([]core.Pipeline, error) {
-	// First try to get child pipeline info from parent job logs
-	childPipelinesFromLogs, err := getChildPipelinesFromLogs(projectPath, parentPipelineID)
-	if err == nil && len(childPipelinesFromLogs) > 0 {
-		return childPipelinesFromLogs, nil
+// appendLogs applies a trace chunk to the log view
+func (m *model) appendLogs(chunk core.TraceChunk) {
+	switch {
+	case chunk.Err != nil:
+		if m.logs == "" {
+			m.setLogs(fmt.Sprintf("❌ Failed to get logs for job %d: %v\n\n💡 Try using: glab-tui logs %d", m.selectedJobID, chunk.Err, m.selectedJobID))
+		}
+		return
+	case chunk.Done:
+		return
 	}
 
-	// Fallback to heuristic method
-	cmd := exec.Command("glab", "ci", "list", "--repo", projectPath, "--per-page", "20")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, err
+	oldLines := len(m.logLines)
+	if chunk.Reset {
+		m.setLogs(chunk.Data)
+	} else {
+		m.extendLogs(chunk.Data)
 	}
+	newLines := len(m.logLines)
 
-	allPipelines := parseGlabCIListOutput(string(output))
-	var childPipelines []core.Pipeline
-
-	// Look for pipelines created after the parent pipeline
-	for _, pipeline := range allPipelines {
-		// Skip the parent pipeline itself
-		if pipeline.ID == parentPipelineID {
-			continue
-		}
-
-		// If pipeline ID is higher (newer) and from similar timeframe, it might be a child
-		if pipeline.ID > parentPipelineID && pipeline.ID < parentPipelineID+50000 {
-			childPipelines = append(childPipelines, pipeline)
+	// Open a failed job at its first error
+	if m.logAtFailure && m.logs != "" {
+		m.logAtFailure = false
+		if len(m.logFailures) > 0 {
+			m.revealLogLine(m.logFailures[0])
+			m.logCursor = m.logFailures[0]
+			return
 		}
 	}
 
-	// Limit to first 5 potential child pipelines to avoid clutter
-	if len(childPipelines) > 5 {
-		childPipelines = childPipelines[:5]
+	// Keep following the end if the cursor was near it
+	if chunk.Reset && m.logCursor >= newLines {
+		m.logCursor = max(newLines-1, 0)
+	} else if m.logCursor > 0 && m.logCursor >= oldLines-5 {
+		m.logCursor = newLines - 1
 	}
-
-	return childPipelines, nil
 }
 
-// getChildPipelinesFromLogs extracts child pipeline info from parent job logs
-func getChildPipelinesFromLogs(projectPath string, parentPipelineID int) ([]core.Pipeline, error) {
-	// Get jobs for the parent pipeline
-	jobs, err := getRemotePipelineJobs(projectPath, parentPipelineID)
-	if err != nil {
-		return nil, err
-	}
-
-	var childPipelines []core.Pipeline
-
-	// Look for the nx-mono-repo-affected job or similar trigger job
-	for _, job := range jobs {
-		if strings.Contains(strings.ToLower(job.Name), "mono-repo") ||
-			strings.Contains(strings.ToLower(job.Name), "affected") ||
-			strings.Contains(strings.ToLower(job.Name), "nx") {
-			// Get logs for this job
-			logs, err := getRemoteJobLogs(projectPath, job.ID)
-			if err != nil {
-				continue
-			}
-
-			// Parse logs for triggered pipeline information
-			childPipelines = parseTriggeredPipelinesFromLogs(logs)
-			if len(childPipelines) > 0 {
-				break
-			}
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case refreshMsg:
+		return m, tea.Batch(refreshTick(m.service.RefreshInterval()), m.poll())
+	case clockMsg:
+		m.expireChanges()
+		return m, clockTick()
+	case spinnerMsg:
+		if !m.busy() {
+			m.spinning = false
+			return m, nil
 		}
-	}
-
-	return childPipelines, nil
-}
-
-// parseTriggeredPipelinesFromLogs parses logs to find triggered child pipelines
-func parseTriggeredPipelinesFromLogs(logs string) []core.Pipeline {
-	var childPipelines []core.Pipeline
-	lines := strings.Split(logs, "\n")
-
-	for _, line := range lines {
-		// Look for lines like: "▶️ Triggered pipeline for internal-demo-application on path apps/internal-demo-application: https://gitlab.com/theapsgroup/agility/frontend-apps/-/pipelines/1997363434"
-		// Also handle lines without emoji: "Triggered pipeline for internal-demo-application on path apps/internal-demo-application: https://gitlab.com/theapsgroup/agility/frontend-apps/-/pipelines/1997363434"
-		if strings.Contains(line, "Triggered pipeline for") && strings.Contains(line, "https://") {
-			// Extract app name and pipeline ID
-			parts := strings.Split(line, " ")
-			var appName string
-			var pipelineID int
-
-			// Find app name (after "for" and before "on")
-			for i, part := range parts {
-				if part == "for" && i+1 < len(parts) {
-					appName = parts[i+1]
-					break
-				}
+		m.spinnerFrame++
+		return m, spinnerTick()
+	case pipelinesMsg:
+		m.finishLoading(pipelineView, msg.err)
+		if msg.err == nil {
+			m.setPipelines(msg.pipelines)
+		}
+		return m, nil
+	case dashboardMsg:
+		m.finishLoading(dashboardView, msg.err)
+		if msg.err == nil {
+			m.setDashboard(msg.rows)
+		}
+		return m, nil
+	case mergeRequestsMsg:
+		m.finishLoading(mergeRequestView, msg.err)
+		if msg.err == nil {
+			m.setMergeRequests(msg.mrs)
+		}
+		return m, nil
+	case mrPipelinesMsg:
+		if msg.iid != m.selectedMR.IID {
+			m.dropStale(mrPipelineView, msg.iid)
+			return m, nil
+		}
+		m.finishLoading(mrPipelineView, msg.err)
+		if msg.err == nil {
+			m.markChanges(pipelineStatuses(m.mrPipelines), pipelineStatuses(msg.pipelines))
+			m.mrPipelines = msg.pipelines
+			m.lastRefresh = time.Now()
+			if m.mrPipelineCursor >= len(m.mrPipelines) {
+				m.mrPipelineCursor = max(len(m.mrPipelines)-1, 0)
 			}
-
-			// Extract pipeline ID from URL
-			if urlStart := strings.Index(line, "https://"); urlStart != -1 {
-				url := line[urlStart:]
-				if pipelineStart := strings.LastIndex(url, "/"); pipelineStart != -1 {
-					pipelineIDStr := url[pipelineStart+1:]
-					if id, err := strconv.Atoi(pipelineIDStr); err == nil {
-						pipelineID = id
-					}
-				}
+		}
+		return m, nil
+	case jobsMsg:
+		// Ignore answers for a pipeline we already left
+		if msg.service != m.jobService || msg.pipelineID != m.selectedPipelineID {
+			m.dropStale(jobView, pipelineTarget{msg.service, msg.pipelineID})
+			return m, nil
+		}
+		m.finishLoading(jobView, msg.err)
+		if msg.err == nil {
+			m.markChanges(jobStatuses(m.jobs), jobStatuses(msg.jobs))
+			m.jobs = msg.jobs
+			m.lastRefresh = time.Now()
+			listed := make(map[int]bool, len(msg.jobs))
+			for _, job := range msg.jobs {
+				listed[job.ID] = true
 			}
-
-			if appName != "" && pipelineID != 0 {
-				childPipeline := core.Pipeline{
-					ID:          pipelineID,
-					ProjectName: appName,
-					Ref:         "triggered",
-					Status:      "running",
-				}
-				childPipelines = append(childPipelines, childPipeline)
+			pruneSelection(m.jobSelected, listed)
+			if m.jobCursor >= len(m.jobs) {
+				m.jobCursor = max(len(m.jobs)-1, 0)
 			}
 		}
-	}
-
-	return childPipelines
-}
-func getRemotePipelineJobs(projectPath string, pipelineID int) ([]core.Job, error) {
-	fmt.Printf("📡 Fetching jobs for pipeline %d in %s...\n", pipelineID, projectPath)
-
-	// Use glab API to get pipeline jobs
-	cmd := exec.Command("glab", "api", fmt.Sprintf("projects/%s/pipelines/%d/jobs", url.QueryEscape(projectPath), pipelineID))
-	output, err := cmd.Output()
-	if err != nil {
-		fmt.Printf("❌ API call failed: %v\n", err)
-		return nil, fmt.Errorf("failed to get jobs: %w", err)
-	}
-
-	fmt.Printf("📝 API Response: %s\n", string(output))
-
-	// Check if response is empty array
-	responseStr := strings.TrimSpace(string(output))
-	if responseStr == "[]" || responseStr == "" {
-		fmt.Printf("⚠️  No jobs found for pipeline %d\n", pipelineID)
-		fmt.Println("💡 This could mean:")
-		fmt.Println("   • Pipeline is still starting")
-		fmt.Println("   • Pipeline has no jobs defined")
-		fmt.Println("   • You don't have access to view pipeline details")
-		fmt.Println("   • Pipeline ID doesn't exist")
-		fmt.Println("")
-		fmt.Println("🎯 Showing mock jobs to demonstrate the interface:")
-		fmt.Println("   In a project where you have access, you'd see real jobs here.")
-		return core.GetMockJobs(), nil
-	}
-
-	// Parse jobs from API response
-	jobs := parseJobsFromAPI(string(output))
-	if len(jobs) == 0 {
-		fmt.Println("📝 Could not parse jobs from API response, using mock data")
-		return core.GetMockJobs(), nil
-	}
-
-	fmt.Printf("✅ Found %d jobs\n", len(jobs))
-	return jobs, nil
-}
-
-// getRemoteJobLogs gets logs for a job in remote project
-func getRemoteJobLogs(projectPath string, jobID int) (string, error) {
-	fmt.Printf("📡 Fetching logs for job %d in %s...\n", jobID, projectPath)
-
-	// Use glab API to get job logs
-	cmd := exec.Command("glab", "api", fmt.Sprintf("projects/%s/jobs/%d/trace", url.QueryEscape(projectPath), jobID))
-	output, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("failed to get job logs: %w", err)
-	}
-
-	return string(output), nil
-}
-
-// parseJobsFromAPI parses jobs from GitLab API response
-func parseJobsFromAPI(jsonResponse string) []core.Job {
-	var jobs []core.Job
-
-	// Try to parse as JSON array first
-	var jsonJobs []map[string]interface{}
-	if err := json.Unmarshal([]byte(jsonResponse), &jsonJobs); err != nil {
-		fmt.Printf("❌ Failed to parse JSON: %v\n", err)
-		fmt.Printf("📝 Raw response (first 200 chars): %s...\n", truncateString(jsonResponse, 200))
-		return jobs
-	}
-
-	// Convert JSON objects to Job structs
-	for _, jsonJob := range jsonJobs {
-		job := core.Job{}
-
-		if id, ok := jsonJob["id"].(float64); ok {
-			job.ID = int(id)
+		return m, nil
+	case treeMsg:
+		if msg.service != m.treeService || msg.pipelineID != m.treePipelineID {
+			return m, nil
 		}
-
-		if name, ok := jsonJob["name"].(string); ok {
-			job.Name = name
+		m.finishLoading(treeView, msg.err)
+		if msg.err != nil {
+			return m, nil
 		}
-
-		if status, ok := jsonJob["status"].(string); ok {
-			job.Status = status
+		if m.tree == nil {
+			m.tree = msg.tree
+			// Start with the root pipeline open
+			return m, m.toggleTreeNode(m.tree)
 		}
-
-		if stage, ok := jsonJob["stage"].(string); ok {
-			job.Stage = stage
+		m.markChanges(treeStatuses(m.tree), treeStatuses(msg.tree))
+		m.tree = msg.tree
+		m.lastRefresh = time.Now()
+		return m, m.reloadTreeJobs()
+	case treeJobsMsg:
+		if !m.treePending[msg.pipelineID] {
+			return m, nil
 		}
-
-		// Only add job if it has required fields
-		if job.ID != 0 && job.Name != "" {
-			jobs = append(jobs, job)
+		delete(m.treePending, msg.pipelineID)
+		m.markChanges(jobStatuses(m.treeJobs[msg.pipelineID]), jobStatuses(msg.jobs))
+		m.treeJobs[msg.pipelineID] = msg.jobs // nil if loading failed
+		return m, nil
+	case needsMsg:
+		if msg.service != m.jobService || msg.pipelineID != m.selectedPipelineID {
+			return m, nil
 		}
-	}
-
-	return jobs
-}
-
-// extractNumber extracts a number from a JSON line
-func extractNumber(line, key string) int {
-	parts := strings.Split(line, key)
-	if len(parts) > 1 {
-		numPart := strings.TrimSpace(parts[1])
-		numPart = strings.Split(numPart, ",")[0]
-		numPart = strings.TrimSpace(numPart)
-		if num, err := strconv.Atoi(numPart); err == nil {
-			return num
+		m.finishLoading(graphView, nil)
+		m.graphNeeds = msg.needs
+		m.graphNeedsErr = msg.err
+		return m, nil
+	case actionMsg:
+		return m, m.applyAction(msg)
+	case bulkMsg:
+		return m, m.applyBulk(msg)
+	case runDefaultsMsg:
+		m.applyRunDefaults(msg)
+		return m, nil
+	case pipelineRunMsg:
+		return m, m.applyPipelineRun(msg)
+	case artifactsMsg:
+		m.applyArtifacts(msg)
+		return m, nil
+	case artifactPreviewMsg:
+		return m, m.applyArtifactPreview(msg)
+	case artifactDownloadMsg:
+		m.applyArtifactDownload(msg)
+		return m, nil
+	case testReportMsg:
+		m.applyTestReport(msg)
+		return m, nil
+	case flakyMsg:
+		m.applyFlaky(msg)
+		return m, nil
+	case statsMsg:
+		m.applyStats(msg)
+		return m, nil
+	case traceMsg:
+		// Ignore chunks from a follower we already left
+		if msg.jobID != m.selectedJobID || m.logTrace == nil {
+			return m, nil
 		}
-	}
-	return 0
-}
-
-// extractString extracts a string from a JSON line
-func extractString(line, key string) string {
-	parts := strings.Split(line, key)
-	if len(parts) > 1 {
-		strPart := strings.TrimSpace(parts[1])
-		strPart = strings.TrimPrefix(strPart, `"`)
-		strPart = strings.Split(strPart, `"`)[0]
-		return strPart
-	}
-	return ""
-}
-
-func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tickMsg:
-		// Real-time updates for log view
-		if m.currentView == logView && m.selectedJobID != 0 {
-			// Refresh logs for running jobs
-			if strings.Contains(m.projectPath, "/") {
-				// Remote mode - fetch fresh logs
-				logs, err := getRemoteJobLogs(m.projectPath, m.selectedJobID)
-				if err == nil && logs != m.logs {
-					// Keep cursor position relative to end if we were at the end
-					oldLines := strings.Split(m.logs, "\n")
-					newLines := strings.Split(logs, "\n")
-
-					// If cursor was near the end, move it to new end
-					if len(oldLines) > 0 && m.logCursor >= len(oldLines)-5 {
-						m.logCursor = len(newLines) - 1
-					}
-
-					m.logs = logs
-				}
-			}
+		m.finishLoading(logView, nil)
+		if msg.closed {
+			m.stopFollowingLogs()
+			return m, nil
 		}
-		return m, tickCmd() // Schedule next tick
+		m.appendLogs(msg.chunk)
+		return m, waitForTrace(msg.jobID, m.logTrace)
 	case tea.KeyMsg:
+		if m.pendingAction != nil {
+			return m.updateAction(msg)
+		}
+		if m.runForm != nil {
+			return m.updateRunForm(msg)
+		}
+		if m.pendingBulk != nil {
+			return m.updateBulk(msg)
+		}
+		if m.bulkResults != nil && msg.String() != "ctrl+c" {
+			// Any key dismisses the summary of a bulk action
+			m.bulkResults = nil
+			return m, nil
+		}
+		if m.currentView == logView && m.search.editing {
+			return m.updateSearchInput(msg)
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "q":
+			m.stopFollowingLogs()
 			return m, tea.Quit
-		case "/":
-			// Start search mode (only in log view)
-			if m.currentView == logView {
-				m.searchMode = true
-				m.searchQuery = ""
+		case "esc":
+			switch m.currentView {
+			case treeView:
+				m.currentView = m.treeParent
+				return m, tea.ClearScreen
+			case graphView:
+				m.currentView = jobView
+				return m, tea.ClearScreen
+			}
+		case "v":
+			// Toggle the stage-column graph of the job view
+			if m.currentView == jobView {
+				return m, tea.Batch(tea.ClearScreen, m.openGraph())
+			}
+		case "t":
+			// Open the parent/child/multi-project tree of a pipeline
+			switch m.currentView {
+			case dashboardView:
+				if row, ok := m.selectedDashboardRow(); ok {
+					return m, tea.Batch(tea.ClearScreen, m.openTree(m.dashboardService(row), row.Pipeline.ID))
+				}
+			case pipelineView:
+				if m.pipelineCursor < len(m.pipelines) {
+					return m, tea.Batch(tea.ClearScreen, m.openTree(m.service, m.pipelines[m.pipelineCursor].ID))
+				}
+			case jobView:
+				return m, tea.Batch(tea.ClearScreen, m.openTree(m.jobService, m.selectedPipelineID))
+			}
+		}
+
+		if m.currentView == logView && (m.updateLogSearch(msg.String()) || m.updateLogOutline(msg.String()) || m.updateLogViewport(msg.String())) {
+			return m, nil
+		}
+
+		switch msg.String() {
+		case " ":
+			// Toggle the selection of the row under the cursor
+			if m.currentView == pipelineView || m.currentView == jobView {
+				m.toggleSelection()
+				return m, nil
+			}
+		case "A":
+			// Select all rows, or none if all are selected
+			if m.currentView == pipelineView || m.currentView == jobView {
+				m.toggleAll()
+				return m, nil
+			}
+		case "m":
+			// Open the merge requests of the project
+			if m.currentView == pipelineView {
+				return m, tea.Batch(tea.ClearScreen, m.openMergeRequests())
 			}
 		case "n":
-			// Next search match (only in log view with active search)
-			if m.currentView == logView && m.searchMode && m.searchQuery != "" {
-				m.findNextMatch()
+			// Run a new pipeline
+			if m.currentView == pipelineView {
+				return m, m.openRunForm(m.service)
+			}
+		case "F":
+			// Find flaky jobs and tests on the ref of the selected pipeline
+			if m.currentView == pipelineView {
+				return m, tea.Batch(tea.ClearScreen, m.openFlaky())
+			}
+		case "S":
+			// Time the pipelines, stages and jobs on the ref of the
+			// selected pipeline
+			if m.currentView == pipelineView {
+				return m, tea.Batch(tea.ClearScreen, m.openStats())
+			}
+		case "D":
+			// Delete the selected finished pipelines
+			if m.currentView == pipelineView {
+				m.askBulkAction(deletePipelineAction)
+				return m, nil
+			}
+		case "R":
+			// Retry the failed items of a selection, the selected job, or
+			// the selected pipeline
+			if m.askBulkAction(retryPipelineAction) {
+				return m, nil
+			}
+			if job, ok := m.selectedJob(); ok {
+				m.askJobAction(retryJobAction, job)
+			} else if service, pipeline, ok := m.selectedPipeline(); ok {
+				m.askPipelineAction(retryPipelineAction, service, pipeline)
+			}
+			return m, nil
+		case "C":
+			// Cancel the active items of a selection, the selected job, or
+			// the selected pipeline
+			if m.askBulkAction(cancelPipelineAction) {
+				return m, nil
+			}
+			if job, ok := m.selectedJob(); ok {
+				m.askJobAction(cancelJobAction, job)
+			} else if service, pipeline, ok := m.selectedPipeline(); ok {
+				m.askPipelineAction(cancelPipelineAction, service, pipeline)
+			}
+			return m, nil
+		case "p":
+			// Play the selected manual job
+			if job, ok := m.selectedJob(); ok {
+				m.askJobAction(playJobAction, job)
 			}
+			return m, nil
+		case "a":
+			// Browse the artifacts of the selected job
+			if job, ok := m.selectedJob(); ok {
+				return m, tea.Batch(tea.ClearScreen, m.openArtifacts(job))
+			}
+		case "T":
+			// Open the test report of the pipeline
+			if m.currentView == jobView || m.currentView == graphView {
+				return m, tea.Batch(tea.ClearScreen, m.openTestReport())
+			}
+		}
+
+		switch m.currentView {
+		case dashboardView:
+			return m.updateDashboard(msg.String())
+		case mergeRequestView:
+			return m.updateMergeRequests(msg.String())
+		case mrPipelineView:
+			return m.updateMRPipelines(msg.String())
+		case artifactView:
+			return m.updateArtifacts(msg.String())
+		case testReportView:
+			return m.updateTestReport(msg.String())
+		case flakyView:
+			return m.updateFlaky(msg.String())
+		case statsView:
+			return m.updateStats(msg.String())
+		case treeView:
+			return m.updateTree(msg.String())
+		case graphView:
+			return m.updateGraph(msg.String())
+		}
+
+		switch msg.String() {
 		case "esc":
-			// Handle escape - exit search mode or go back
-			if m.searchMode {
-				m.searchMode = false
-				m.searchQuery = ""
-			} else {
-				// Go back to previous view
-				switch m.currentView {
-				case jobView:
-					m.currentView = pipelineView
-					return m, tea.ClearScreen
-				case logView:
-					m.currentView = jobView
-					return m, tea.ClearScreen
-				}
+			// Go back to previous view
+			switch m.currentView {
+			case jobView:
+				m.currentView = m.jobParent
+				return m, tea.ClearScreen
+			case logView:
+				m.stopFollowingLogs()
+				m.currentView = m.logParent
+				return m, tea.ClearScreen
 			}
 		case "r":
-			// Refresh pipelines
-			if m.currentView == pipelineView {
-				// Check if this is remote mode
-				if strings.Contains(m.projectPath, "/") && m.gitlab == nil {
-					// Remote mode - refresh from GitLab
-					pipelines, err := getRemoteProjectPipelines(m.projectPath)
-					if err == nil {
-						m.pipelines = pipelines
-						// Reset cursor to avoid out of bounds
-						if len(m.pipelines) == 0 {
-							m.pipelineCursor = 0
-						} else if m.pipelineCursor >= len(m.pipelines) {
-							m.pipelineCursor = len(m.pipelines) - 1
-						}
-					}
-				} else if m.gitlab == nil {
-					// Demo mode - refresh mock data
-					m.pipelines = core.GetMockPipelines()
-					if len(m.pipelines) == 0 {
-						m.pipelineCursor = 0
-					} else if m.pipelineCursor >= len(m.pipelines) {
-						m.pipelineCursor = len(m.pipelines) - 1
-					}
-				} else {
-					// Local GitLab mode
-					pipelines, err := getProjectPipelinesViaGlab(m.projectPath)
-					if err == nil {
-						m.pipelines = pipelines
-						if len(m.pipelines) == 0 {
-							m.pipelineCursor = 0
-						} else if m.pipelineCursor >= len(m.pipelines) {
-							m.pipelineCursor = len(m.pipelines) - 1
-						}
-					}
-				}
+			// Refresh pipelines or jobs
+			switch m.currentView {
+			case pipelineView:
+				return m, m.refresh()
+			case jobView:
+				return m, m.reloadJobs()
 			}
 		case "enter":
 			// Drill down to next view
@@ -654,107 +743,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Enter pipeline -> show jobs
 				if m.pipelineCursor < len(m.pipelines) {
 					selectedPipeline := m.pipelines[m.pipelineCursor]
-
-					// Handle demo mode (when gitlab is nil)
-					if m.gitlab == nil {
-						// Check if this is remote mode (has real project path)
-						if strings.Contains(m.projectPath, "/") {
-							// Remote mode - fetch real jobs and child pipelines
-							jobs, err := getRemotePipelineJobsWithChildren(m.projectPath, selectedPipeline.ID)
-							if err == nil {
-								m.jobs = jobs
-							} else {
-								// Fallback to mock jobs
-								m.jobs = core.GetMockJobs()
-							}
-						} else {
-							// Demo mode - use mock jobs
-							m.jobs = core.GetMockJobs()
-						}
-						m.jobCursor = 0
-						m.selectedPipelineID = selectedPipeline.ID
-						m.currentView = jobView
-						return m, tea.ClearScreen
-					} else {
-						// Real GitLab mode
-						jobs, err := m.gitlab.GetPipelineJobs(selectedPipeline.ID)
-						if err == nil {
-							m.jobs = jobs
-							m.jobCursor = 0
-							m.selectedPipelineID = selectedPipeline.ID
-							m.currentView = jobView
-							return m, tea.ClearScreen
-						}
-					}
+					return m, tea.Batch(tea.ClearScreen, m.openJobs(m.service, selectedPipeline.ID))
 				}
 			case jobView:
 				// Enter job -> show logs or navigate to child pipeline
 				if m.jobCursor < len(m.jobs) {
 					selectedJob := m.jobs[m.jobCursor]
 
-					// Check if this is a child pipeline (starts with 🔗)
-					if strings.HasPrefix(selectedJob.Name, "🔗 ") {
-						// Extract pipeline ID from the name (format: "🔗 ● app-name #12345")
-						parts := strings.Split(selectedJob.Name, "#")
-						if len(parts) >= 2 {
-							pipelineIDStr := strings.TrimSpace(parts[len(parts)-1])
-							if pipelineID, err := strconv.Atoi(pipelineIDStr); err == nil {
-								// Navigate to child pipeline jobs
-								if strings.Contains(m.projectPath, "/") {
-									jobs, err := getRemotePipelineJobsWithChildren(m.projectPath, pipelineID)
-									if err == nil {
-										m.jobs = jobs
-										m.jobCursor = 0
-										m.selectedPipelineID = pipelineID
-										return m, tea.ClearScreen
-									}
-								}
-							}
-						}
-						return m, nil // Don't proceed to log view for child pipelines
-					}
-
-					// Handle demo mode (when gitlab is nil)
-					if m.gitlab == nil {
-						// Check if this is remote mode
-						if strings.Contains(m.projectPath, "/") {
-							// Remote mode - fetch real logs
-							logs, err := getRemoteJobLogs(m.projectPath, selectedJob.ID)
-							if err == nil {
-								m.logs = logs
-							} else {
-								m.logs = fmt.Sprintf("❌ Failed to get logs for job %d: %v\n\n💡 This is a remote project.\nTry using: glab-tui logs %d", selectedJob.ID, err, selectedJob.ID)
-							}
-						} else {
-							// Demo mode - use mock logs
-							m.logs = "🎯 Demo Mode - Mock Job Logs\n\n" +
-								"📋 Job: " + selectedJob.Name + "\n" +
-								"📊 Status: " + selectedJob.Status + "\n" +
-								"🏗️  Stage: " + selectedJob.Stage + "\n\n" +
-								"Sample log output:\n" +
-								"[INFO] Starting job execution...\n" +
-								"[INFO] Installing dependencies...\n" +
-								"[INFO] Running tests...\n" +
-								"[SUCCESS] All tests passed!\n" +
-								"[INFO] Job completed successfully\n\n" +
-								"💡 In real GitLab projects, you'd see actual job logs here.\n" +
-								"🔥 Use 'l' key for real-time streaming in live projects!"
-						}
-						m.selectedJobID = selectedJob.ID
-						m.currentView = logView
-						m.logCursor = 0                                 // Reset log cursor
-						return m, tea.Batch(tea.ClearScreen, tickCmd()) // Start real-time updates
-					} else {
-						// Real GitLab mode
-						logs, err := m.gitlab.GetJobLogs(selectedJob.ID)
-						if err == nil {
-							m.logs = logs
-							m.selectedJobID = selectedJob.ID
-							m.currentView = logView
-							m.logCursor = 0                                 // Reset log cursor
-							return m, tea.Batch(tea.ClearScreen, tickCmd()) // Start real-time updates
-						}
-					}
+					m.logParent = jobView
+					return m, tea.Batch(tea.ClearScreen, m.loadLogs(selectedJob)) // Start real-time updates
 				}
 			}
 		case "l":
@@ -763,9 +760,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				selectedJob := m.jobs[m.jobCursor]
 
 				// Handle demo mode
-				if m.gitlab == nil {
+				if m.demo {
 					// Show demo message instead of trying to stream
-					m.logs = "🎯 Demo Mode - Real-time Streaming Preview\n\n" +
+					m.setLogs("🎯 Demo Mode - Real-time Streaming Preview\n\n" +
 						"📋 Job: " + selectedJob.Name + "\n" +
 						"🔥 In a real GitLab project, this would start:\n" +
 						"   glab-tui logs --follow " + strconv.Itoa(selectedJob.ID) + "\n\n" +
@@ -775,11 +772,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						"   • Graceful Ctrl+C exit\n" +
 						"   • Live job status monitoring\n\n" +
 						"💡 Try this in a real GitLab repository to see live streaming!\n" +
-						"📝 Example: cd /path/to/gitlab/project && glab-tui"
+						"📝 Example: cd /path/to/gitlab/project && glab-tui")
+					m.logSource = ""
 					m.selectedJobID = selectedJob.ID
+					m.logParent = jobView
 					m.currentView = logView
-					m.logCursor = 0                                 // Reset log cursor
-					return m, tea.Batch(tea.ClearScreen, tickCmd()) // Start real-time updates
+					m.logCursor = 0 // Reset log cursor
+					return m, tea.ClearScreen
 				} else {
 					// Real GitLab mode - exit TUI and start streaming
 					return m, tea.Sequence(
@@ -799,9 +798,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.jobCursor--
 				}
 			case logView:
-				if m.logCursor > 0 {
-					m.logCursor--
-				}
+				m.moveLogCursor(-1)
 			}
 		case "down", "j":
 			switch m.currentView {
@@ -814,10 +811,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.jobCursor++
 				}
 			case logView:
-				logLines := strings.Split(m.logs, "\n")
-				if m.logCursor < len(logLines)-1 {
-					m.logCursor++
-				}
+				m.moveLogCursor(1)
 			}
 		case "g":
 			// Go to first item (gg pattern)
@@ -827,7 +821,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case jobView:
 				m.jobCursor = 0
 			case logView:
-				m.logCursor = 0
+				m.moveLogCursor(-len(m.logLines))
 			}
 		case "G":
 			// Go to last item
@@ -841,10 +835,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.jobCursor = len(m.jobs) - 1
 				}
 			case logView:
-				logLines := strings.Split(m.logs, "\n")
-				if len(logLines) > 0 {
-					m.logCursor = len(logLines) - 1
-				}
+				m.moveLogCursor(len(m.logLines))
 			}
 		case "ctrl+u":
 			// Page up
@@ -860,10 +851,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.jobCursor = 0
 				}
 			case logView:
-				m.logCursor -= 10
-				if m.logCursor < 0 {
-					m.logCursor = 0
-				}
+				m.moveLogCursor(-m.screenHeight() / 2)
 			}
 		case "ctrl+d":
 			// Page down
@@ -879,95 +867,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.jobCursor = len(m.jobs) - 1
 				}
 			case logView:
-				logLines := strings.Split(m.logs, "\n")
-				m.logCursor += 10
-				if len(logLines) > 0 && m.logCursor >= len(logLines) {
-					m.logCursor = len(logLines) - 1
-				}
-			}
-		default:
-			// Handle search input
-			if m.searchMode && m.currentView == logView {
-				if msg.String() == "backspace" {
-					if len(m.searchQuery) > 0 {
-						m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
-					}
-				} else if len(msg.String()) == 1 {
-					// Add character to search query
-					m.searchQuery += msg.String()
-					// Reset cursor to first match
-					m.logCursor = 0
-				}
+				m.moveLogCursor(m.screenHeight() / 2)
 			}
 		}
 	}
 	return m, nil
 }
 
-// findNextMatch finds the next search match and moves cursor there
-func (m *model) findNextMatch() {
-	if m.searchQuery == "" {
-		return
-	}
-
-	logLines := strings.Split(m.logs, "\n")
-	query := strings.ToLower(m.searchQuery)
-
-	// Start searching from current cursor + 1
-	for i := m.logCursor + 1; i < len(logLines); i++ {
-		if strings.Contains(strings.ToLower(logLines[i]), query) {
-			m.logCursor = i
-			return
-		}
-	}
-
-	// If not found, wrap around to beginning
-	for i := 0; i <= m.logCursor; i++ {
-		if strings.Contains(strings.ToLower(logLines[i]), query) {
-			m.logCursor = i
-			return
-		}
-	}
-}
-
-// highlightSearchTerm highlights search terms in log lines
-func highlightSearchTerm(line, query string) string {
-	if query == "" {
-		return line
-	}
-
-	// Simple highlighting - replace with colored version
-	highlighted := strings.ReplaceAll(
-		line,
-		query,
-		lipgloss.NewStyle().Background(lipgloss.Color("#FFFF00")).Foreground(lipgloss.Color("#000000")).Render(query),
-	)
-
-	// Also try case insensitive
-	if highlighted == line {
-		lowerLine := strings.ToLower(line)
-		lowerQuery := strings.ToLower(query)
-		if strings.Contains(lowerLine, lowerQuery) {
-			// Find the actual case in the original line
-			index := strings.Index(lowerLine, lowerQuery)
-			if index >= 0 {
-				originalTerm := line[index : index+len(query)]
-				highlighted = strings.Replace(
-					line,
-					originalTerm,
-					lipgloss.NewStyle().Background(lipgloss.Color("#FFFF00")).Foreground(lipgloss.Color("#000000")).Render(originalTerm),
-					1,
-				)
-			}
-		}
-	}
-
-	return highlighted
-}
-
 func (m model) View() string {
 	// Enhanced title bar with more context
 	projectName := getProjectName(m.projectPath)
+	if (m.currentView == jobView || m.currentView == logView || m.currentView == graphView || m.currentView == artifactView || m.currentView == testReportView) && m.jobService != nil && m.jobService.Project() != "" {
+		// Pipelines opened from the dashboard belong to another project
+		projectName = getProjectName(m.jobService.Project())
+	}
 	var title string
 
 	switch m.currentView {
@@ -996,20 +909,145 @@ func (m model) View() string {
 	case logView:
 		title = titleStyle.Render(fmt.Sprintf("🚀 GitLab TUI - %s | Job #%d Logs",
 			projectName, m.selectedJobID))
+		switch {
+		case m.logSource != "" && m.logParent == testReportView:
+			title = titleStyle.Render(fmt.Sprintf("🚀 GitLab TUI - %s | Pipeline #%d Tests",
+				projectName, m.testPipelineID))
+		case m.logSource != "":
+			title = titleStyle.Render(fmt.Sprintf("🚀 GitLab TUI - %s | Job #%d Artifacts",
+				projectName, m.selectedJobID))
+		}
+	case treeView:
+		title = titleStyle.Render(fmt.Sprintf("🚀 GitLab TUI - %s | Pipeline #%d Tree",
+			projectName, m.treePipelineID))
+	case dashboardView:
+		failed, running := 0, 0
+		for _, row := range m.dashboard {
+			switch row.Status() {
+			case "failed":
+				failed++
+			case "running":
+				running++
+			}
+		}
+		title = titleStyle.Render(fmt.Sprintf("🚀 GitLab TUI - Dashboard | %d pipelines (%d failed, %d running)",
+			len(m.dashboard), failed, running))
+	case graphView:
+		title = titleStyle.Render(fmt.Sprintf("🚀 GitLab TUI - %s | Pipeline #%d Graph",
+			projectName, m.selectedPipelineID))
+	case mergeRequestView:
+		title = titleStyle.Render(fmt.Sprintf("🚀 GitLab TUI - %s | %d merge requests",
+			projectName, len(m.mergeRequests)))
+	case mrPipelineView:
+		title = titleStyle.Render(fmt.Sprintf("🚀 GitLab TUI - %s | !%d | %d pipelines",
+			projectName, m.selectedMR.IID, len(m.mrPipelines)))
+	case artifactView:
+		title = titleStyle.Render(fmt.Sprintf("🚀 GitLab TUI - %s | Job #%d | %d artifacts",
+			projectName, m.artifactJob.ID, len(m.artifactFiles)))
+	case testReportView:
+		title = titleStyle.Render(fmt.Sprintf("🚀 GitLab TUI - %s | Pipeline #%d Tests",
+			projectName, m.testPipelineID))
+	case flakyView:
+		title = titleStyle.Render(fmt.Sprintf("🚀 GitLab TUI - %s | Flaky on %s",
+			projectName, m.flakyRef))
+	case statsView:
+		title = titleStyle.Render(fmt.Sprintf("🚀 GitLab TUI - %s | Durations on %s",
+			projectName, m.statsRef))
 	default:
 		title = titleStyle.Render("🚀 GitLab TUI - " + projectName)
 	}
 
+	var s string
 	switch m.currentView {
-	case pipelineView:
-		return m.renderPipelineView(title)
 	case jobView:
-		return m.renderJobView(title)
+		s = m.renderJobView(title)
 	case logView:
-		return m.renderLogView(title)
+		s = m.renderLogView(title)
+	case treeView:
+		s = m.renderTreeView(title)
+	case graphView:
+		s = m.renderGraphView(title)
+	case dashboardView:
+		s = m.renderDashboardView(title)
+	case mergeRequestView:
+		s = m.renderMergeRequestView(title)
+	case mrPipelineView:
+		s = m.renderMRPipelineView(title)
+	case artifactView:
+		s = m.renderArtifactView(title)
+	case testReportView:
+		s = m.renderTestReportView(title)
+	case flakyView:
+		s = m.renderFlakyView(title)
+	case statsView:
+		s = m.renderStatsView(title)
 	default:
-		return m.renderPipelineView(title)
+		s = m.renderPipelineView(title)
+	}
+
+	return s + m.renderBottom()
+}
+
+// selectedPipeline returns the pipeline under the cursor of the pipeline
+// or dashboard view, with a service scoped to its project
+func (m model) selectedPipeline() (*core.Service, core.Pipeline, bool) {
+	switch m.currentView {
+	case pipelineView:
+		if m.pipelineCursor < len(m.pipelines) {
+			return m.service, m.pipelines[m.pipelineCursor], true
+		}
+	case dashboardView:
+		if row, ok := m.selectedDashboardRow(); ok {
+			return m.dashboardService(row), *row.Pipeline, true
+		}
+	case mrPipelineView:
+		if m.mrPipelineCursor < len(m.mrPipelines) {
+			pipeline := m.mrPipelines[m.mrPipelineCursor]
+			return m.mrPipelineService(pipeline), pipeline, true
+		}
+	}
+	return nil, core.Pipeline{}, false
+}
+
+// selectedJob returns the job under the cursor of the job or graph view
+func (m model) selectedJob() (core.Job, bool) {
+	switch m.currentView {
+	case jobView:
+		if m.jobCursor < len(m.jobs) {
+			return m.jobs[m.jobCursor], true
+		}
+	case graphView:
+		return m.selectedGraphJob()
 	}
+	return core.Job{}, false
+}
+
+// renderStatusBar shows refresh age and the API budget, as in docs/design.md
+func (m model) renderStatusBar() string {
+	var parts []string
+	if notice := m.renderNotice(); notice != "" {
+		parts = append(parts, notice)
+	}
+	if !m.lastRefresh.IsZero() {
+		refresh := fmt.Sprintf("Last refresh: %s (every %s)", refreshAge(m.lastRefresh), m.service.RefreshInterval())
+		if m.refreshing() {
+			refresh += " " + m.spinner()
+		}
+		parts = append(parts, refresh)
+	}
+	if limit, ok := m.service.RateLimit(); ok {
+		calls := "API calls: " + limit.String()
+		if limit.Remaining == 0 && !limit.Reset.IsZero() {
+			calls += fmt.Sprintf(" (resets in %s)", time.Until(limit.Reset).Round(time.Second))
+			parts = append(parts, failedStyle.Render(calls))
+		} else {
+			parts = append(parts, calls)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Faint(true).Render(strings.Join(parts, " | "))
 }
 
 func (m model) renderPipelineView(title string) string {
@@ -1030,34 +1068,23 @@ func (m model) renderPipelineView(title string) string {
 
 	statusLine := fmt.Sprintf("📊 %d total | 🔄 %d running | [r] Refresh | [Enter] View Jobs",
 		len(m.pipelines), runningCount)
-
-	// Implement simple scrolling - show max 10 pipelines at a time
-	maxVisible := 10
-	startIdx := 0
-	endIdx := len(m.pipelines)
-
-	// Simple scrolling: if more than maxVisible, show a window around cursor
-	if len(m.pipelines) > maxVisible {
-		startIdx = m.pipelineCursor - 5 // Show 5 before cursor
-		if startIdx < 0 {
-			startIdx = 0
-		}
-		endIdx = startIdx + maxVisible
-		if endIdx > len(m.pipelines) {
-			endIdx = len(m.pipelines)
-			startIdx = endIdx - maxVisible
-			if startIdx < 0 {
-				startIdx = 0
-			}
-		}
+	if n := len(m.pipelineSelected); n > 0 {
+		statusLine += fmt.Sprintf(" | ◆ %d selected", n)
 	}
 
+	footer := "\n" + m.renderHelp("Navigation: ↑/↓ or j/k | Ctrl+U/D: page up/down | g/G: first/last | Enter: view jobs | t: tree | m: merge requests | n: new pipeline | F: flaky | S: durations | Space/A: select | R: retry | C: cancel | D: delete | r: refresh | q: quit")
+
 	s := title + "\n"
 	s += header + "\n"
 	s += lipgloss.NewStyle().Faint(true).Render(statusLine) + "\n"
+	s += m.renderLoadStatus(pipelineView)
+
+	// When the pipelines don't all fit, show a window around the cursor
+	// below a line saying which ones
+	startIdx, endIdx := 0, len(m.pipelines)
+	if visible := m.listHeight(s+"\n", footer); len(m.pipelines) > visible {
+		startIdx, endIdx = m.scrollList(pipelineView, m.pipelineCursor, len(m.pipelines), max(visible-1, minListHeight))
 
-	// Show scroll indicator if needed
-	if len(m.pipelines) > maxVisible {
 		scrollInfo := fmt.Sprintf("Showing %d-%d of %d pipelines", startIdx+1, endIdx, len(m.pipelines))
 		s += lipgloss.NewStyle().Faint(true).Render(scrollInfo) + "\n"
 	}
@@ -1079,27 +1106,26 @@ func (m model) renderPipelineView(title string) string {
 		duration := ""
 		if pipeline.Status == "running" {
 			duration = "⏱️  running..."
-		} else {
+		} else if pipeline.Duration != "" {
 			duration = fmt.Sprintf("⏱️  %s", pipeline.Duration)
+		} else {
+			duration = fmt.Sprintf("⏱️  %s", core.FormatAge(pipeline.UpdatedAt))
 		}
 
 		// Simple line format - back to basics
-		line := fmt.Sprintf("%s%s #%-8d %-8s %-20s %s",
+		line := fmt.Sprintf("%s%s%s #%-8d %-8s %-20s %s",
 			cursor,
+			selectionMarker(m.pipelineSelected, pipeline.ID),
 			statusStyled,
 			pipeline.ID,
-			pipeline.ProjectName, // IID like (#6942)
+			pipeline.ProjectName,
 			truncateString(getBetterBranchName(pipeline.Ref), 20),
 			duration)
 
-		if m.pipelineCursor == i {
-			line = selectedStyle.Render(line)
-		}
-
-		s += line + "\n"
+		s += m.highlight(line, pipelineChangeKey(pipeline.ID), m.pipelineCursor == i) + "\n"
 	}
 
-	s += "\n" + lipgloss.NewStyle().Faint(true).Render("Navigation: ↑/↓ or j/k | Ctrl+U/D: page up/down | g/G: first/last | Enter: view jobs | r: refresh | q: quit")
+	s += footer
 	return s
 }
 
@@ -1124,6 +1150,7 @@ func (m model) renderWelcomeScreen(title string) string {
 	s += "  • Use ↑/↓ or j/k to navigate\n"
 	s += "  • Press Enter to drill down: Pipelines → Jobs → Logs\n"
 	s += "  • Press 'l' on a job to stream logs in real-time\n"
+	s += "  • Press 'n' to run a new pipeline\n"
 	s += "  • Press Esc to go back, 'q' to quit\n\n"
 
 	s += instructionStyle.Render("🔥 New Feature - Real-time Log Streaming:") + "\n"
@@ -1131,8 +1158,10 @@ func (m model) renderWelcomeScreen(title string) string {
 	s += "  • Or use CLI: glab-tui logs --follow <job-id>\n\n"
 
 	s += instructionStyle.Render("🎯 Current Status:") + "\n"
-	if len(m.pipelines) == 0 {
-		s += "  • No pipelines loaded yet\n"
+	if status := m.renderLoadStatus(pipelineView); status != "" {
+		s += "  " + status
+	} else if len(m.pipelines) == 0 {
+		s += "  • No pipelines found\n"
 		s += "  • Press 'r' to refresh and load from GitLab\n"
 	}
 
@@ -1183,13 +1212,21 @@ func (m model) renderJobView(title string) string {
 
 	statusLine := fmt.Sprintf("📊 %d total | ✅ %d success | 🔄 %d running | ❌ %d failed",
 		len(m.jobs), successJobs, runningJobs, failedJobs)
+	if n := len(m.jobSelected); n > 0 {
+		statusLine += fmt.Sprintf(" | ◆ %d selected", n)
+	}
 
 	s := title + "\n"
 	s += header + "\n"
-	s += lipgloss.NewStyle().Faint(true).Render(statusLine) + "\n\n"
+	s += lipgloss.NewStyle().Faint(true).Render(statusLine) + "\n"
+	s += m.renderLoadStatus(jobView) + "\n"
+
+	footer := "\n" + m.renderHelp("Navigation: ↑/↓ or j/k | Ctrl+U/D: page up/down | g/G: first/last | Enter: view logs | t: tree | v: graph | a: artifacts | T: tests | Space/A: select | R: retry | C: cancel | p: play | r: refresh | Esc: back | l: logs --follow")
+	start, end := m.scrollList(jobView, m.jobCursor, len(m.jobs), m.listHeight(s, footer))
 
 	// Simple job list - back to basics
-	for i, job := range m.jobs {
+	for i := start; i < end; i++ {
+		job := m.jobs[i]
 		cursor := "  "
 		if m.jobCursor == i {
 			cursor = "▶ "
@@ -1199,138 +1236,156 @@ func (m model) renderJobView(title string) string {
 		statusStyled := getStyledStatus(job.Status, status)
 
 		// Simple line format
-		line := fmt.Sprintf("%s%s %-25s %-12s %s",
+		line := fmt.Sprintf("%s%s%s %-25s %-12s %-12s %s",
 			cursor,
+			selectionMarker(m.jobSelected, job.ID),
 			statusStyled,
 			truncateString(job.Name, 25),
 			job.Status,
-			job.Stage)
-
-		if m.jobCursor == i {
-			line = selectedStyle.Render(line)
+			job.Stage,
+			job.Duration)
+		if job.QueuedSeconds >= 60 {
+			// Worth knowing when a job waited long for a runner
+			line += lipgloss.NewStyle().Faint(true).Render(" (queued " + core.FormatDuration(job.QueuedSeconds) + ")")
 		}
 
-		s += line + "\n"
+		s += m.highlight(line, jobChangeKey(job.ID), m.jobCursor == i) + "\n"
 	}
 
-	s += "\n" + lipgloss.NewStyle().Faint(true).Render("Navigation: ↑/↓ or j/k | Ctrl+U/D: page up/down | g/G: first/last | Enter: view logs/child pipeline | Esc: back to pipelines | l: logs --follow")
+	s += footer
 	return s
 }
 
 func (m model) renderLogView(title string) string {
 	header := headerStyle.Render(fmt.Sprintf("📋 Logs (Job #%d) - Real-time", m.selectedJobID))
+	if m.logSource != "" {
+		header = headerStyle.Render("📄 " + m.logSource)
+	}
 
 	s := title + "\n"
 	s += header + "\n"
-
-	// Show search info if searching
-	if m.searchMode {
-		searchHeader := fmt.Sprintf("🔍 Search: %s", m.searchQuery)
-		if m.searchQuery == "" {
-			searchHeader = "🔍 Search: (type to search)"
-		}
-		s += lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Render(searchHeader) + "\n"
+	s += m.renderLoadStatus(logView)
+	if m.errorMatcherErr != nil {
+		s += failedStyle.Render(fmt.Sprintf("⚠️  %v, detecting failures with the default patterns", m.errorMatcherErr)) + "\n"
 	}
+
+	s += m.renderSearchBar()
 	s += "\n"
 
-	// Parse log lines
-	logLines := strings.Split(m.logs, "\n")
+	// Only the matches when filtering, otherwise the lines outside
+	// collapsed sections with the matches highlighted
+	pattern := m.search.pattern
+	rows := m.logRows()
+	cursorRow := logRow(rows, m.logCursor)
+
+	// The help says which lines are shown, so size the window for the
+	// longest numbers it can show
+	total := len(rows)
+	height := m.listHeight(s, m.renderLogHelp(total, total, total))
+	startLine, endLine := logWindow(total, cursorRow, height, func(row int) int {
+		return m.logRowHeight(rows[row])
+	})
 
-	// Filter lines if searching
-	var displayLines []string
-	var lineNumbers []int
+	// Display logs with line numbers, cursor and section folds
+	used := 0
+	for i := startLine; i < endLine && used < height; i++ {
+		cursor := "  "
+		if i == cursorRow {
+			cursor = "▶ "
+		}
 
-	if m.searchMode && m.searchQuery != "" {
-		for i, line := range logLines {
-			if strings.Contains(strings.ToLower(line), strings.ToLower(m.searchQuery)) {
-				displayLines = append(displayLines, line)
-				lineNumbers = append(lineNumbers, i+1)
+		fold := ""
+		section := m.sectionHeader(rows[i])
+		if len(m.logSections) > 0 {
+			switch {
+			case section < 0:
+				fold = "  "
+			case m.sectionCollapsed(section):
+				fold = "▸ "
+			default:
+				fold = "▾ "
 			}
 		}
-	} else {
-		displayLines = logLines
-		for i := range logLines {
-			lineNumbers = append(lineNumbers, i+1)
-		}
-	}
-
-	// Calculate visible window
-	maxLines := 20
-	totalLines := len(displayLines)
 
-	// Ensure logCursor is within bounds
-	if m.logCursor >= totalLines {
-		m.logCursor = totalLines - 1
-	}
-	if m.logCursor < 0 {
-		m.logCursor = 0
-	}
-
-	startLine := 0
-	endLine := totalLines
-
-	if totalLines > maxLines {
-		// Center the cursor in the visible area
-		startLine = m.logCursor - maxLines/2
-		if startLine < 0 {
-			startLine = 0
-		}
-		endLine = startLine + maxLines
-		if endLine > totalLines {
-			endLine = totalLines
-			startLine = endLine - maxLines
-			if startLine < 0 {
-				startLine = 0
+		mark := ""
+		if len(m.logFailures) > 0 {
+			mark = "  "
+			if m.isLogFailure(rows[i]) {
+				mark = failedStyle.Render("✗") + " "
 			}
 		}
-	}
 
-	// Display logs with line numbers and cursor
-	for i := startLine; i < endLine && i < len(displayLines); i++ {
-		cursor := "  "
-		lineStyle := lipgloss.NewStyle()
-
-		if i == m.logCursor {
-			cursor = "▶ "
-			lineStyle = selectedStyle
+		line := m.logLines[rows[i]]
+		blank := line.Text == ""
+		if pattern != nil {
+			line = line.Highlight(pattern.FindAllStringIndex(line.Text, -1), searchHighlight)
 		}
 
-		lineNum := ""
-		if len(lineNumbers) > i {
-			lineNum = fmt.Sprintf("%4d: ", lineNumbers[i])
+		gutter := m.logGutterWidth(rows[i])
+		prefix := cursor + fmt.Sprintf("%4d: ", rows[i]+1) + fold
+		if i == cursorRow {
+			prefix = selectedStyle.Render(prefix)
 		}
+		prefix = mark + prefix
 
-		line := displayLines[i]
-		if line != "" {
-			// Highlight search terms
-			if m.searchMode && m.searchQuery != "" {
-				line = highlightSearchTerm(line, m.searchQuery)
+		// Wrapped lines continue under the text, after a blank gutter
+		parts := m.logLineParts(line, m.width-gutter)
+		for j, part := range parts {
+			if used == height {
+				break
 			}
-
-			fullLine := fmt.Sprintf("%s%s%s", cursor, lineNum, line)
-			if i == m.logCursor {
-				fullLine = lineStyle.Render(fullLine)
+			if j > 0 {
+				prefix = strings.Repeat(" ", gutter)
+			}
+			s += prefix + renderLogLine(part, i == cursorRow)
+			if section >= 0 && j == len(parts)-1 {
+				s += renderSectionTitle(m.logSections[section], blank)
 			}
-			s += fullLine + "\n"
+			s += "\n"
+			used++
 		}
 	}
 
-	// Status line
+	return s + m.renderLogHelp(startLine, endLine, total)
+}
+
+// renderLogHelp renders the keys of the log view below the log, with which
+// of its rows are shown
+func (m model) renderLogHelp(start, end, total int) string {
 	statusInfo := ""
-	if totalLines > maxLines {
-		statusInfo = fmt.Sprintf(" | Showing %d-%d of %d lines", startLine+1, endLine, totalLines)
+	if start > 0 || end < total {
+		statusInfo = fmt.Sprintf(" | Showing %d-%d of %d lines", start+1, end, total)
+	}
+	if m.logScroll > 0 {
+		statusInfo += fmt.Sprintf(" | Column %d", m.logScroll+1)
 	}
 
-	searchInfo := ""
-	if m.searchMode && m.searchQuery != "" {
-		searchInfo = fmt.Sprintf(" | Found %d matches", len(displayLines))
+	if m.search.editing {
+		return "\n" + m.renderHelp(
+			"Search: type a query | Enter: done | ↑/↓: history | Ctrl+R: regex | Ctrl+F: filter | Ctrl+U: clear | Esc: cancel"+statusInfo)
+	}
+	searchKeys := " | /: search"
+	if m.search.query != "" {
+		searchKeys = " | /: search | n/N: next/prev match | Ctrl+R: regex | Ctrl+F: filter/highlight | Esc: clear search"
 	}
 
-	s += "\n" + lipgloss.NewStyle().Faint(true).Render(
-		fmt.Sprintf("Navigation: ↑/↓: scroll | g/G: first/last | /: search | n: next match | Esc: back%s%s",
-			statusInfo, searchInfo))
+	sectionKeys := ""
+	if len(m.logSections) > 0 {
+		sectionKeys = " | Space: fold section | [/]: prev/next section | +/-: expand/collapse all"
+	}
+	if len(m.logFailures) > 0 {
+		sectionKeys += " | e/E: next/prev failure"
+		statusInfo += fmt.Sprintf(" | %d failure lines", len(m.logFailures))
+	}
 
-	return s
+	wrapKeys := " | w: wrap lines | ←/→ or h/l: scroll sideways | 0: line start"
+	if m.logWrap {
+		wrapKeys = " | w: scroll long lines sideways"
+	}
+
+	return "\n" + m.renderHelp(
+		fmt.Sprintf("Navigation: ↑/↓: scroll | Ctrl+U/D: page up/down | g/G: first/last%s%s%s | Esc: back%s",
+			searchKeys, sectionKeys, wrapKeys, statusInfo))
 }
 
 func getStatusIcon(status string) string {
@@ -1359,142 +1414,6 @@ func getStyledStatus(status, icon string) string {
 	}
 }
 
-// Helper functions (same as CLI)
-func getCurrentProjectPath() (string, error) {
-	cmd := exec.Command("git", "remote", "get-url", "origin")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("failed to get git remote: %w", err)
-	}
-
-	remoteURL := strings.TrimSpace(string(output))
-
-	if strings.Contains(remoteURL, "gitlab") {
-		if strings.HasPrefix(remoteURL, "git@") {
-			parts := strings.Split(remoteURL, ":")
-			if len(parts) >= 2 {
-				return strings.TrimSuffix(parts[1], ".git"), nil
-			}
-		} else if strings.HasPrefix(remoteURL, "https://") {
-			parts := strings.Split(remoteURL, "/")
-			if len(parts) >= 4 {
-				projectPath := strings.Join(parts[3:], "/")
-				return strings.TrimSuffix(projectPath, ".git"), nil
-			}
-		}
-	}
-
-	return "", fmt.Errorf("not a GitLab repository or unsupported URL format: %s", remoteURL)
-}
-
-func getProjectPipelinesViaGlab(projectPath string) ([]core.Pipeline, error) {
-	cmd := exec.Command("glab", "pipeline", "list")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("glab command failed: %w", err)
-	}
-
-	return parseGlabPipelineText(string(output))
-}
-
-func parseGlabPipelineText(output string) ([]core.Pipeline, error) {
-	lines := strings.Split(output, "\n")
-	var pipelines []core.Pipeline
-
-	// Extract project name from header line like "Showing 30 pipelines on group/project"
-	projectName := "current-project"
-	for _, line := range lines {
-		if strings.HasPrefix(line, "Showing") && strings.Contains(line, " on ") {
-			parts := strings.Split(line, " on ")
-			if len(parts) > 1 {
-				fullPath := strings.TrimSuffix(parts[1], ". (Page 1)")
-				pathParts := strings.Split(fullPath, "/")
-				if len(pathParts) > 0 {
-					projectName = pathParts[len(pathParts)-1]
-				}
-			}
-			break
-		}
-	}
-
-	// Skip header lines and parse pipeline data
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "Showing") || strings.HasPrefix(line, "State") {
-			continue
-		}
-
-		if strings.Contains(line, "#") {
-			pipeline := parsePipelineLine(line, projectName)
-			if pipeline.ID != 0 {
-				pipelines = append(pipelines, pipeline)
-			}
-		}
-	}
-
-	return pipelines, nil
-}
-
-func parsePipelineLine(line, projectName string) core.Pipeline {
-	// Extract status
-	var status string
-	if strings.Contains(line, "(running)") {
-		status = "running"
-	} else if strings.Contains(line, "(success)") {
-		status = "success"
-	} else if strings.Contains(line, "(failed)") {
-		status = "failed"
-	} else if strings.Contains(line, "(waiting_for_resource)") {
-		status = "waiting_for_resource"
-	} else {
-		status = "unknown"
-	}
-
-	// Extract pipeline ID
-	var pipelineID int
-	if idx := strings.Index(line, "#"); idx != -1 {
-		idStr := ""
-		for i := idx + 1; i < len(line) && (line[i] >= '0' && line[i] <= '9'); i++ {
-			idStr += string(line[i])
-		}
-		if id, err := strconv.Atoi(idStr); err == nil {
-			pipelineID = id
-		}
-	}
-
-	// Extract ref (simplified) - it's the 3rd tab-separated field
-	parts := strings.Split(line, "\t")
-	ref := "unknown"
-	if len(parts) >= 3 {
-		ref = strings.TrimSpace(parts[2])
-		// Clean up long merge request refs
-		if strings.HasPrefix(ref, "refs/merge-requests/") {
-			ref = "MR-" + strings.Split(ref, "/")[2]
-		}
-	}
-
-	// Determine job status based on pipeline status
-	jobs := "pending"
-	switch status {
-	case "running":
-		jobs = "in progress"
-	case "success":
-		jobs = "completed"
-	case "failed":
-		jobs = "failed"
-	case "waiting_for_resource":
-		jobs = "queued"
-	}
-
-	return core.Pipeline{
-		ID:          pipelineID,
-		Status:      status,
-		Ref:         ref,
-		ProjectName: projectName,
-		Jobs:        jobs,
-	}
-}
-
 // getBetterBranchName improves branch name display
 func getBetterBranchName(ref string) string {
 	// Handle merge request refs
@@ -0,0 +1,193 @@
+package analysis
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// DurationStats summarize how long something took across pipelines, in
+// seconds
+type DurationStats struct {
+	Runs  int
+	P50   float64
+	P90   float64
+	Max   float64
+	Trend []float64 // One duration per pipeline, oldest first
+}
+
+// JobDurations are the run and queue times of a job across pipelines
+type JobDurations struct {
+	Name  string
+	Stage string
+	DurationStats
+	Queued DurationStats
+}
+
+// StageDurations are the times of a stage across pipelines. Jobs of a stage
+// run side by side, so a stage takes as long as its slowest job.
+type StageDurations struct {
+	Name string
+	DurationStats
+}
+
+// DurationReport is the result of a duration analysis. Only successful
+// runs are timed, a failure stops early and would make things look fast.
+type DurationReport struct {
+	Ref       string
+	Pipelines int              // Finished pipelines analyzed
+	Pipeline  DurationStats    // Whole pipelines
+	Queued    DurationStats    // Time pipelines waited for runners
+	Stages    []StageDurations // Slowest first
+	Jobs      []JobDurations   // Slowest first
+}
+
+// AnalyzeDurations reads the last pipelines of a ref and summarizes how long
+// they, their stages and their jobs took
+func AnalyzeDurations(ctx context.Context, service *core.Service, opts Options) (*DurationReport, error) {
+	if opts.Last <= 0 {
+		opts.Last = DefaultLast
+	}
+	runs, err := service.PipelineHistory(ctx, core.HistoryOptions{Ref: opts.Ref, Last: opts.Last, Times: true})
+	if err != nil {
+		return nil, err
+	}
+
+	report := SummarizeDurations(runs)
+	report.Ref = opts.Ref
+	return &report, nil
+}
+
+// SummarizeDurations computes the duration percentiles of a pipeline
+// history, which is newest first
+func SummarizeDurations(runs []core.PipelineRun) DurationReport {
+	var pipelines, queued []float64
+	stages := make(map[string][]float64)
+	jobs := make(map[string][]float64)
+	jobQueued := make(map[string][]float64)
+	jobStages := make(map[string]string)
+
+	// Oldest first, so the samples are in trend order
+	for i := len(runs) - 1; i >= 0; i-- {
+		run := runs[i]
+		if run.Pipeline.Status == "success" && run.Pipeline.DurationSeconds > 0 {
+			pipelines = append(pipelines, run.Pipeline.DurationSeconds)
+			queued = append(queued, run.Pipeline.QueuedSeconds)
+		}
+
+		slowest := make(map[string]float64)
+		for name, job := range finalAttempts(run.Jobs) {
+			if job.Status != "success" || job.DurationSeconds <= 0 {
+				continue
+			}
+			jobs[name] = append(jobs[name], job.DurationSeconds)
+			jobQueued[name] = append(jobQueued[name], job.QueuedSeconds)
+			jobStages[name] = job.Stage
+			slowest[job.Stage] = max(slowest[job.Stage], job.DurationSeconds)
+		}
+		for stage, seconds := range slowest {
+			stages[stage] = append(stages[stage], seconds)
+		}
+	}
+
+	report := DurationReport{
+		Pipelines: len(runs),
+		Pipeline:  summarize(pipelines),
+		Queued:    summarize(queued),
+	}
+	for name, samples := range stages {
+		report.Stages = append(report.Stages, StageDurations{Name: name, DurationStats: summarize(samples)})
+	}
+	for name, samples := range jobs {
+		report.Jobs = append(report.Jobs, JobDurations{
+			Name:          name,
+			Stage:         jobStages[name],
+			DurationStats: summarize(samples),
+			Queued:        summarize(jobQueued[name]),
+		})
+	}
+
+	sort.Slice(report.Stages, func(i, j int) bool {
+		return slower(report.Stages[i].DurationStats, report.Stages[j].DurationStats, report.Stages[i].Name, report.Stages[j].Name)
+	})
+	sort.Slice(report.Jobs, func(i, j int) bool {
+		return slower(report.Jobs[i].DurationStats, report.Jobs[j].DurationStats, report.Jobs[i].Name, report.Jobs[j].Name)
+	})
+	return report
+}
+
+// slower orders by p90, then p50, then name
+func slower(a, b DurationStats, aName, bName string) bool {
+	if a.P90 != b.P90 {
+		return a.P90 > b.P90
+	}
+	if a.P50 != b.P50 {
+		return a.P50 > b.P50
+	}
+	return aName < bName
+}
+
+// finalAttempts picks the last attempt of each job of a pipeline
+func finalAttempts(jobs []core.Job) map[string]core.Job {
+	final := make(map[string]core.Job)
+	for _, job := range jobs {
+		if last, ok := final[job.Name]; !ok || job.ID > last.ID {
+			final[job.Name] = job
+		}
+	}
+	return final
+}
+
+// summarize computes the stats of samples given oldest first
+func summarize(samples []float64) DurationStats {
+	if len(samples) == 0 {
+		return DurationStats{}
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	return DurationStats{
+		Runs:  len(samples),
+		P50:   percentile(sorted, 0.5),
+		P90:   percentile(sorted, 0.9),
+		Max:   sorted[len(sorted)-1],
+		Trend: samples,
+	}
+}
+
+// percentile returns the nearest-rank percentile p, between 0 and 1, of
+// sorted samples
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[min(max(rank-1, 0), len(sorted)-1)]
+}
+
+// sparkBlocks are the bars of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a row of bars scaled between the smallest and
+// the largest value, keeping at most width of the last values
+func Sparkline(values []float64, width int) string {
+	if width > 0 && len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low, high = min(low, v), max(high, v)
+	}
+
+	top := len(sparkBlocks) - 1
+	bars := make([]rune, len(values))
+	for i, v := range values {
+		level := top / 2 // Flat when every value is the same
+		if high > low {
+			level = int(math.Round((v - low) / (high - low) * float64(top)))
+		}
+		bars[i] = sparkBlocks[level]
+	}
+	return string(bars)
+}
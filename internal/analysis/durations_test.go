@@ -0,0 +1,118 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// timedRun builds a pipeline that took seconds and waited queued seconds
+func timedRun(pipelineID int, status string, seconds, queued float64, jobs ...core.Job) core.PipelineRun {
+	return core.PipelineRun{
+		Pipeline: core.Pipeline{ID: pipelineID, Status: status, DurationSeconds: seconds, QueuedSeconds: queued},
+		Jobs:     jobs,
+	}
+}
+
+// timedJob builds a job that took seconds after a one second wait
+func timedJob(id int, name, stage, status string, seconds float64) core.Job {
+	return core.Job{ID: id, Name: name, Stage: stage, Status: status, DurationSeconds: seconds, QueuedSeconds: 1}
+}
+
+func TestSummarizeDurations(t *testing.T) {
+	// Newest first, like PipelineHistory
+	runs := []core.PipelineRun{
+		timedRun(3, "success", 300, 30,
+			timedJob(30, "build", "build", "success", 100),
+			timedJob(31, "test-a", "test", "success", 50),
+			timedJob(32, "test-b", "test", "success", 80),
+		),
+		// Failed pipelines aren't timed; of the jobs only the final attempt
+		// counts, and only if it passed
+		timedRun(2, "failed", 50, 5,
+			timedJob(20, "build", "build", "success", 200),
+			timedJob(21, "build", "build", "failed", 5),
+			timedJob(22, "test-a", "test", "failed", 3),
+			timedJob(23, "test-a", "test", "success", 70),
+			timedJob(24, "test-b", "test", "success", 0),
+		),
+		timedRun(1, "success", 100, 10,
+			timedJob(10, "build", "build", "success", 90),
+			timedJob(11, "test-a", "test", "success", 60),
+			timedJob(12, "test-b", "test", "success", 40),
+		),
+	}
+	queued := func(runs int) DurationStats {
+		trend := make([]float64, runs)
+		for i := range trend {
+			trend[i] = 1
+		}
+		return DurationStats{Runs: runs, P50: 1, P90: 1, Max: 1, Trend: trend}
+	}
+
+	want := DurationReport{
+		Pipelines: 3,
+		Pipeline:  DurationStats{Runs: 2, P50: 100, P90: 300, Max: 300, Trend: []float64{100, 300}},
+		Queued:    DurationStats{Runs: 2, P50: 10, P90: 30, Max: 30, Trend: []float64{10, 30}},
+		Stages: []StageDurations{
+			{Name: "build", DurationStats: DurationStats{Runs: 2, P50: 90, P90: 100, Max: 100, Trend: []float64{90, 100}}},
+			// A stage takes as long as its slowest job
+			{Name: "test", DurationStats: DurationStats{Runs: 3, P50: 70, P90: 80, Max: 80, Trend: []float64{60, 70, 80}}},
+		},
+		Jobs: []JobDurations{
+			{Name: "build", Stage: "build", DurationStats: DurationStats{Runs: 2, P50: 90, P90: 100, Max: 100, Trend: []float64{90, 100}}, Queued: queued(2)},
+			{Name: "test-b", Stage: "test", DurationStats: DurationStats{Runs: 2, P50: 40, P90: 80, Max: 80, Trend: []float64{40, 80}}, Queued: queued(2)},
+			{Name: "test-a", Stage: "test", DurationStats: DurationStats{Runs: 3, P50: 60, P90: 70, Max: 70, Trend: []float64{60, 70, 50}}, Queued: queued(3)},
+		},
+	}
+	if got := SummarizeDurations(runs); !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeDurations =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tenths := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		name   string
+		sorted []float64
+		p      float64
+		want   float64
+	}{
+		{"p50 of ten", tenths, 0.5, 5},
+		{"p90 of ten", tenths, 0.9, 9},
+		{"p50 of two", []float64{1, 2}, 0.5, 1},
+		{"p90 of two", []float64{1, 2}, 0.9, 2},
+		{"p90 of one", []float64{7}, 0.9, 7},
+		{"p0", tenths, 0, 1},
+		{"p100", tenths, 1, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.sorted, tt.p); got != tt.want {
+				t.Errorf("percentile(%v, %v) = %v, want %v", tt.sorted, tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{"empty", nil, 10, ""},
+		{"flat", []float64{3, 3, 3}, 0, "▄▄▄"},
+		{"lowest to highest", []float64{0, 7, 1, 6}, 0, "▁█▂▇"},
+		{"keeps the last values", []float64{9, 0, 6, 14}, 3, "▁▄█"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sparkline(tt.values, tt.width); got != tt.want {
+				t.Errorf("Sparkline(%v, %d) = %q, want %q", tt.values, tt.width, got, tt.want)
+			}
+		})
+	}
+}
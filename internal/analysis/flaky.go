@@ -0,0 +1,250 @@
+package analysis
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// DefaultLast is how many pipelines are analyzed when no count is given
+const DefaultLast = 50
+
+// Kind tells whether a flaky result is a job or a test case
+type Kind string
+
+const (
+	KindJob  Kind = "job"
+	KindTest Kind = "test"
+)
+
+// Flaky is a job or test case whose outcome changed without the code
+// changing
+type Flaky struct {
+	Kind        Kind
+	Name        string // Job name, or "suite › class › test" for a test case
+	Runs        int    // Passed or failed runs seen, retries included
+	Failures    int
+	RetryFlips  int   // Failed attempts that passed when retried
+	CommitFlips int   // Commits on which it both passed and failed
+	Pipelines   []int // Pipelines where it flipped, newest first
+}
+
+// Flips is how often the outcome changed on the same code
+func (f Flaky) Flips() int {
+	return f.RetryFlips + f.CommitFlips
+}
+
+// FailureRate is the share of runs that failed, between 0 and 1
+func (f Flaky) FailureRate() float64 {
+	if f.Runs == 0 {
+		return 0
+	}
+	return float64(f.Failures) / float64(f.Runs)
+}
+
+// Report is the result of a flakiness analysis
+type Report struct {
+	Ref         string
+	Pipelines   int // Finished pipelines analyzed
+	Commits     int // Distinct commits among them
+	TestReports int // Pipelines whose test report was compared
+	Flaky       []Flaky
+}
+
+// Options selects the pipeline history to analyze
+type Options struct {
+	Ref   string // Branch or tag, empty for all refs
+	Last  int    // How many of the newest pipelines, 0 uses DefaultLast
+	Tests bool   // Also compare JUnit test cases
+}
+
+// Analyze reads the last pipelines of a ref and reports the jobs, and with
+// opts.Tests the test cases, that both passed and failed on the same code
+func Analyze(ctx context.Context, service *core.Service, opts Options) (*Report, error) {
+	if opts.Last <= 0 {
+		opts.Last = DefaultLast
+	}
+	runs, err := service.PipelineHistory(ctx, core.HistoryOptions{Ref: opts.Ref, Last: opts.Last, Tests: opts.Tests})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Ref: opts.Ref, Pipelines: len(runs), Flaky: Detect(runs)}
+	commits := make(map[string]struct{})
+	for _, run := range runs {
+		commits[run.Pipeline.SHA] = struct{}{}
+		if run.Tests != nil {
+			report.TestReports++
+		}
+	}
+	report.Commits = len(commits)
+	return report, nil
+}
+
+// outcome is the pass or fail a pipeline ended with for a job or test case
+type outcome struct {
+	pipelineID int
+	sha        string
+	passed     bool
+}
+
+// tally collects the outcomes of one job or test case
+type tally struct {
+	flaky   Flaky
+	results []outcome
+	flipped map[int]struct{} // Pipelines where it flipped
+}
+
+// Detect finds the flaky jobs and test cases in a pipeline history, most
+// flips first. A job is flaky when a failed attempt passed on retry, or
+// when it passed and failed on the same commit; a test case when it passed
+// and failed on the same commit.
+func Detect(runs []core.PipelineRun) []Flaky {
+	tallies := make(map[string]*tally)
+	get := func(kind Kind, name string) *tally {
+		key := string(kind) + "\x00" + name
+		t, ok := tallies[key]
+		if !ok {
+			t = &tally{flaky: Flaky{Kind: kind, Name: name}, flipped: make(map[int]struct{})}
+			tallies[key] = t
+		}
+		return t
+	}
+	count := func(t *tally, passed bool) {
+		t.flaky.Runs++
+		if !passed {
+			t.flaky.Failures++
+		}
+	}
+	// Only the final attempt in a pipeline is compared across commits, a
+	// retry is already counted as a retry flip
+	record := func(t *tally, run core.PipelineRun, passed bool) {
+		t.results = append(t.results, outcome{pipelineID: run.Pipeline.ID, sha: run.Pipeline.SHA, passed: passed})
+	}
+
+	for _, run := range runs {
+		for name, attempts := range jobAttempts(run.Jobs) {
+			t := get(KindJob, name)
+			for i, job := range attempts {
+				passed := job.Status == "success"
+				count(t, passed)
+				if passed && i > 0 && attempts[i-1].Status == "failed" {
+					t.flaky.RetryFlips++
+					t.flipped[run.Pipeline.ID] = struct{}{}
+				}
+			}
+			record(t, run, attempts[len(attempts)-1].Status == "success")
+		}
+
+		if run.Tests == nil {
+			continue
+		}
+		for _, suite := range run.Tests.Suites {
+			for _, c := range suite.Cases {
+				if c.Status == "success" || c.Failed() {
+					t := get(KindTest, testName(suite, c))
+					count(t, !c.Failed())
+					record(t, run, !c.Failed())
+				}
+			}
+		}
+	}
+
+	var flaky []Flaky
+	for _, t := range tallies {
+		t.countCommitFlips()
+		if t.flaky.Flips() == 0 {
+			continue
+		}
+		t.flaky.Pipelines = pipelinesNewestFirst(runs, t.flipped)
+		flaky = append(flaky, t.flaky)
+	}
+
+	sort.Slice(flaky, func(i, j int) bool {
+		a, b := flaky[i], flaky[j]
+		if a.Flips() != b.Flips() {
+			return a.Flips() > b.Flips()
+		}
+		if a.FailureRate() != b.FailureRate() {
+			return a.FailureRate() > b.FailureRate()
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Kind < b.Kind
+	})
+	return flaky
+}
+
+// countCommitFlips counts the commits on which the results both passed and
+// failed, and marks the pipelines that ran them as flipped
+func (t *tally) countCommitFlips() {
+	type seen struct{ passed, failed bool }
+	commits := make(map[string]*seen)
+	for _, r := range t.results {
+		if r.sha == "" {
+			continue
+		}
+		s, ok := commits[r.sha]
+		if !ok {
+			s = &seen{}
+			commits[r.sha] = s
+		}
+		if r.passed {
+			s.passed = true
+		} else {
+			s.failed = true
+		}
+	}
+
+	for sha, s := range commits {
+		if !s.passed || !s.failed {
+			continue
+		}
+		t.flaky.CommitFlips++
+		for _, r := range t.results {
+			if r.sha == sha {
+				t.flipped[r.pipelineID] = struct{}{}
+			}
+		}
+	}
+}
+
+// jobAttempts groups the passed and failed attempts of a pipeline's jobs by
+// name, oldest attempt first. Canceled, skipped and manual jobs say nothing
+// about flakiness and are left out.
+func jobAttempts(jobs []core.Job) map[string][]core.Job {
+	attempts := make(map[string][]core.Job)
+	for _, job := range jobs {
+		if job.Status == "success" || job.Status == "failed" {
+			attempts[job.Name] = append(attempts[job.Name], job)
+		}
+	}
+	for _, list := range attempts {
+		sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	}
+	return attempts
+}
+
+// testName identifies a test case across pipelines
+func testName(suite core.TestSuite, c core.TestCase) string {
+	parts := []string{suite.Name}
+	if c.Classname != "" {
+		parts = append(parts, c.Classname)
+	}
+	return strings.Join(append(parts, c.Name), " › ")
+}
+
+// pipelinesNewestFirst lists the given pipeline IDs in the order of the
+// history, which is newest first
+func pipelinesNewestFirst(runs []core.PipelineRun, ids map[int]struct{}) []int {
+	var ordered []int
+	for _, run := range runs {
+		if _, ok := ids[run.Pipeline.ID]; ok {
+			ordered = append(ordered, run.Pipeline.ID)
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,116 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// run builds a pipeline of a commit with the given jobs
+func run(pipelineID int, sha string, jobs ...core.Job) core.PipelineRun {
+	return core.PipelineRun{Pipeline: core.Pipeline{ID: pipelineID, SHA: sha}, Jobs: jobs}
+}
+
+func job(id int, name, status string) core.Job {
+	return core.Job{ID: id, Name: name, Status: status}
+}
+
+// withTests adds a test report with one suite to a run
+func withTests(r core.PipelineRun, cases ...core.TestCase) core.PipelineRun {
+	r.Tests = &core.TestReport{Suites: []core.TestSuite{{Name: "unit", Cases: cases}}}
+	return r
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		runs []core.PipelineRun // Newest first, like PipelineHistory
+		want []Flaky
+	}{
+		{
+			name: "failed attempt passed on retry",
+			runs: []core.PipelineRun{run(1, "a", job(10, "test", "failed"), job(11, "test", "success"))},
+			want: []Flaky{{Kind: KindJob, Name: "test", Runs: 2, Failures: 1, RetryFlips: 1, Pipelines: []int{1}}},
+		},
+		{
+			name: "attempts ordered by job ID, not by listing",
+			runs: []core.PipelineRun{run(1, "a", job(11, "test", "success"), job(10, "test", "failed"))},
+			want: []Flaky{{Kind: KindJob, Name: "test", Runs: 2, Failures: 1, RetryFlips: 1, Pipelines: []int{1}}},
+		},
+		{
+			name: "passed attempt failed on retry",
+			runs: []core.PipelineRun{run(1, "a", job(10, "test", "success"), job(11, "test", "failed"))},
+			want: nil,
+		},
+		{
+			name: "passed and failed on the same commit",
+			runs: []core.PipelineRun{
+				run(3, "a", job(30, "test", "success")),
+				run(2, "b", job(20, "test", "success")),
+				run(1, "a", job(10, "test", "failed")),
+			},
+			want: []Flaky{{Kind: KindJob, Name: "test", Runs: 3, Failures: 1, CommitFlips: 1, Pipelines: []int{3, 1}}},
+		},
+		{
+			name: "passed and failed on different commits",
+			runs: []core.PipelineRun{
+				run(2, "b", job(20, "test", "success")),
+				run(1, "a", job(10, "test", "failed")),
+			},
+			want: nil,
+		},
+		{
+			name: "only the final attempt compared across commits",
+			runs: []core.PipelineRun{
+				run(2, "a", job(20, "test", "success")),
+				run(1, "a", job(10, "test", "failed"), job(11, "test", "success")),
+			},
+			want: []Flaky{{Kind: KindJob, Name: "test", Runs: 3, Failures: 1, RetryFlips: 1, Pipelines: []int{1}}},
+		},
+		{
+			name: "unknown commit not compared",
+			runs: []core.PipelineRun{
+				run(2, "", job(20, "test", "success")),
+				run(1, "", job(10, "test", "failed")),
+			},
+			want: nil,
+		},
+		{
+			name: "canceled, skipped and manual jobs ignored",
+			runs: []core.PipelineRun{
+				run(2, "a", job(20, "test", "canceled"), job(21, "deploy", "manual"), job(22, "lint", "skipped")),
+				run(1, "a", job(10, "test", "failed"), job(11, "deploy", "success"), job(12, "lint", "failed")),
+			},
+			want: nil,
+		},
+		{
+			name: "test case passed and failed on the same commit",
+			runs: []core.PipelineRun{
+				withTests(run(2, "a"), core.TestCase{Name: "loads", Classname: "Config", Status: "success"}, core.TestCase{Name: "saves", Status: "skipped"}),
+				withTests(run(1, "a"), core.TestCase{Name: "loads", Classname: "Config", Status: "error"}, core.TestCase{Name: "saves", Status: "failed"}),
+			},
+			want: []Flaky{{Kind: KindTest, Name: "unit › Config › loads", Runs: 2, Failures: 1, CommitFlips: 1, Pipelines: []int{2, 1}}},
+		},
+		{
+			name: "most flips first, then highest failure rate, then name",
+			runs: []core.PipelineRun{
+				run(3, "a", job(30, "b-build", "success"), job(31, "c-test", "success"), job(32, "a-lint", "success")),
+				run(2, "a", job(20, "b-build", "failed"), job(21, "c-test", "failed"), job(22, "a-lint", "failed"), job(23, "a-lint", "success"), job(24, "c-test", "failed")),
+				run(1, "b", job(10, "b-build", "failed"), job(11, "b-build", "success"), job(12, "c-test", "failed"), job(13, "c-test", "success")),
+			},
+			want: []Flaky{
+				{Kind: KindJob, Name: "c-test", Runs: 5, Failures: 3, RetryFlips: 1, CommitFlips: 1, Pipelines: []int{3, 2, 1}},
+				{Kind: KindJob, Name: "b-build", Runs: 4, Failures: 2, RetryFlips: 1, CommitFlips: 1, Pipelines: []int{3, 2, 1}},
+				{Kind: KindJob, Name: "a-lint", Runs: 3, Failures: 1, RetryFlips: 1, Pipelines: []int{2}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.runs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Detect =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// maxCacheEntries bounds how many URLs the response cache remembers
+const maxCacheEntries = 512
+
+// maxCachedBody keeps large responses out of the cache, whether or not
+// they announce their length
+const maxCachedBody = 1 << 20
+
+// responseCache remembers the validators (ETag, Last-Modified) and body of
+// GET responses per URL so polling can send conditional requests. A 304
+// Not Modified answer is then served from memory, which is cheap for
+// GitLab and keeps refreshes from downloading the same payload again.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	order   []string // Insertion order, oldest first, for eviction
+}
+
+type cacheEntry struct {
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]*cacheEntry)}
+}
+
+// prepare adds conditional headers to req when its URL has been seen
+// before, returning the cached entry to fall back on for a 304
+func (c *responseCache) prepare(req *http.Request) *cacheEntry {
+	if !cacheable(req) {
+		return nil
+	}
+
+	c.mu.Lock()
+	entry := c.entries[req.URL.String()]
+	c.mu.Unlock()
+
+	if entry == nil {
+		return nil
+	}
+	if entry.etag != "" {
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+	if entry.lastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.lastModified)
+	}
+	return entry
+}
+
+// cacheable reports whether the response to req may be cached. Job traces
+// and artifacts are downloads that are read once or followed with ranges,
+// so they never are.
+func cacheable(req *http.Request) bool {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return false
+	}
+	segments := strings.Split(req.URL.EscapedPath(), "/")
+	if segments[len(segments)-1] == "trace" {
+		return false
+	}
+	for _, segment := range segments {
+		if segment == "artifacts" {
+			return false
+		}
+	}
+	return true
+}
+
+// store remembers a successful GET response that carries validators. The
+// body is read into memory, up to maxCachedBody, so the returned response
+// must be used instead of resp.
+func (c *responseCache) store(req *http.Request, resp *http.Response) (*http.Response, error) {
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if !cacheable(req) || resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") ||
+		resp.ContentLength > maxCachedBody {
+		return resp, nil
+	}
+
+	// Chunked and compressed responses don't say how long they are, so
+	// read one byte past the limit to find out
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBody+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedBody {
+		resp.Body = readCloser{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	key := req.URL.String()
+	c.mu.Lock()
+	if _, ok := c.entries[key]; !ok {
+		c.order = append(c.order, key)
+		if len(c.order) > maxCacheEntries {
+			delete(c.entries, c.order[0])
+			c.order = c.order[1:]
+		}
+	}
+	c.entries[key] = &cacheEntry{
+		etag:         etag,
+		lastModified: lastModified,
+		header:       resp.Header.Clone(),
+		body:         body,
+	}
+	c.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// readCloser reads the rest of a body after the part already read from it
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// response rebuilds the cached 200 response for a request answered with 304
+func (e *cacheEntry) response(req *http.Request, notModified *http.Response) *http.Response {
+	header := e.header.Clone()
+	// Keep fresh values such as the rate-limit headers from the 304
+	for key, values := range notModified.Header {
+		header[key] = values
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
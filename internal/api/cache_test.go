@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// chunkedServer answers every GET with an ETag and a body of size bytes,
+// flushed in pieces so it is sent chunked, without a Content-Length
+func chunkedServer(t *testing.T, size int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		piece := bytes.Repeat([]byte("x"), 4096)
+		for left := size; left > 0; left -= len(piece) {
+			w.Write(piece[:min(left, len(piece))])
+			w.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestResponseCacheStore(t *testing.T) {
+	tests := []struct {
+		name       string
+		size       int
+		path       string
+		wantCached bool
+	}{
+		{"small chunked body", 1000, "/api/v4/projects/1/pipelines", true},
+		{"body at the limit", maxCachedBody, "/api/v4/projects/1/pipelines", true},
+		{"chunked body over the limit", maxCachedBody + 1, "/api/v4/projects/1/pipelines", false},
+		{"large chunked body", 3 * maxCachedBody, "/api/v4/projects/1/pipelines", false},
+		{"job trace", 1000, "/api/v4/projects/1/jobs/2/trace", false},
+		{"artifact archive", 1000, "/api/v4/projects/1/jobs/2/artifacts", false},
+		{"artifact file", 1000, "/api/v4/projects/1/jobs/2/artifacts/coverage/index.html", false},
+		{"project named artifacts", 1000, "/api/v4/projects/group%2Fartifacts/pipelines", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := chunkedServer(t, tt.size)
+			cache := newResponseCache()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := server.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.ContentLength != -1 {
+				t.Fatalf("ContentLength = %d, want a chunked response", resp.ContentLength)
+			}
+
+			resp, err = cache.store(req, resp)
+			if err != nil {
+				t.Fatalf("store() error = %v", err)
+			}
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				t.Fatalf("reading body: %v", err)
+			}
+			if len(body) != tt.size {
+				t.Errorf("body has %d bytes, want %d", len(body), tt.size)
+			}
+
+			if cached := cache.prepare(req.Clone(req.Context())) != nil; cached != tt.wantCached {
+				t.Errorf("cached = %v, want %v", cached, tt.wantCached)
+			}
+		})
+	}
+}
+
+func TestResponseCachePrepare(t *testing.T) {
+	cache := newResponseCache()
+	server := chunkedServer(t, 10)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v4/projects/1/pipelines", nil)
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, _ = cache.store(req, resp)
+	resp.Body.Close()
+
+	next, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v4/projects/1/pipelines", nil)
+	if cache.prepare(next) == nil {
+		t.Fatal("prepare() found no entry for a cached URL")
+	}
+	if got := next.Header.Get("If-None-Match"); got != `"v1"` {
+		t.Errorf("If-None-Match = %q, want %q", got, `"v1"`)
+	}
+
+	ranged, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v4/projects/1/pipelines", nil)
+	ranged.Header.Set("Range", "bytes=5-")
+	if cache.prepare(ranged) != nil || ranged.Header.Get("If-None-Match") != "" {
+		t.Error("prepare() made a range request conditional")
+	}
+}
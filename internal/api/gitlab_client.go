@@ -1,51 +1,35 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"time"
+	"strings"
 
 	"github.com/rkristelijn/glab-tui/internal/auth"
+	"github.com/rkristelijn/glab-tui/internal/core"
 )
 
 // GitLabClient handles GitLab API requests
 type GitLabClient struct {
 	auth       *auth.GitLabAuth
 	httpClient *http.Client
+	transport  *Transport
+	cache      *responseCache
 	baseURL    string
 }
 
-// Pipeline represents a GitLab pipeline
-type Pipeline struct {
-	ID        int       `json:"id"`
-	IID       int       `json:"iid"`
-	ProjectID int       `json:"project_id"`
-	Status    string    `json:"status"`
-	Ref       string    `json:"ref"`
-	SHA       string    `json:"sha"`
-	WebURL    string    `json:"web_url"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-}
-
-// Job represents a GitLab job
-type Job struct {
-	ID         int        `json:"id"`
-	Name       string     `json:"name"`
-	Status     string     `json:"status"`
-	Stage      string     `json:"stage"`
-	CreatedAt  time.Time  `json:"created_at"`
-	StartedAt  *time.Time `json:"started_at"`
-	FinishedAt *time.Time `json:"finished_at"`
-	Duration   *float64   `json:"duration"`
-	WebURL     string     `json:"web_url"`
-	Pipeline   struct {
-		ID int `json:"id"`
-	} `json:"pipeline"`
-}
+// Ensure GitLabClient satisfies the core backend interfaces
+var (
+	_ core.GitLabClient      = (*GitLabClient)(nil)
+	_ core.RateLimitReporter = (*GitLabClient)(nil)
+	_ core.TraceFollower     = (*GitLabClient)(nil)
+	_ core.JobNeedsReader    = (*GitLabClient)(nil)
+)
 
 // NewGitLabClient creates a new GitLab API client
 func NewGitLabClient() (*GitLabClient, error) {
@@ -54,172 +38,490 @@ func NewGitLabClient() (*GitLabClient, error) {
 		return nil, fmt.Errorf("failed to initialize auth: %w", err)
 	}
 
+	return newClient(auth), nil
+}
+
+// NewGitLabClientWithToken creates a GitLab API client for an explicit URL and token
+func NewGitLabClientWithToken(baseURL, token string) *GitLabClient {
+	return newClient(auth.NewTokenAuth(baseURL, token))
+}
+
+// newClient wires a client to its own rate-limit aware transport
+func newClient(auth *auth.GitLabAuth) *GitLabClient {
+	transport := NewTransport(nil)
 	return &GitLabClient{
-		auth: auth,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		baseURL: auth.GetBaseURL(),
-	}, nil
+		auth:       auth,
+		httpClient: &http.Client{Transport: transport},
+		transport:  transport,
+		cache:      newResponseCache(),
+		baseURL:    auth.GetBaseURL(),
+	}
+}
+
+// RateLimit returns the last observed API budget
+func (c *GitLabClient) RateLimit() (core.RateLimit, bool) {
+	return c.transport.RateLimit()
 }
 
-// GetPipelines gets pipelines for a project
-func (c *GitLabClient) GetPipelines(projectPath string, limit int) ([]Pipeline, error) {
-	// Convert project path to project ID or use path directly
-	projectID := url.PathEscape(projectPath)
+// GetProject gets a project by path or ID
+func (c *GitLabClient) GetProject(ctx context.Context, project string) (*core.Project, error) {
+	var p Project
+	if err := c.get(ctx, "get project "+project, projectPath(project), nil, &p); err != nil {
+		return nil, err
+	}
+	result := p.ToCore()
+	return &result, nil
+}
 
-	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines", c.baseURL, projectID)
-	if limit > 0 {
-		apiURL += fmt.Sprintf("?per_page=%d", limit)
+// ListGroupProjects gets the projects of a group, most recently active first
+func (c *GitLabClient) ListGroupProjects(ctx context.Context, group string, opts core.ProjectListOptions) ([]core.Project, error) {
+	query := url.Values{}
+	query.Set("order_by", "last_activity_at")
+	query.Set("sort", "desc")
+	if opts.IncludeSubgroups {
+		query.Set("include_subgroups", "true")
+	}
+	if !opts.Archived {
+		query.Set("archived", "false")
 	}
 
-	req, err := http.NewRequest("GET", apiURL, nil)
+	inactive := func(p Project) bool { return opts.Inactive(p.ToCore()) }
+	projects, err := getAllUntil(ctx, c, "list projects of group "+group, "/groups/"+url.PathEscape(group)+"/projects", query, opts.PerPage, opts.Limit, inactive)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", c.auth.GetAuthHeader())
-	req.Header.Set("Content-Type", "application/json")
+	result := make([]core.Project, 0, len(projects))
+	for _, p := range projects {
+		result = append(result, p.ToCore())
+	}
+	return result, nil
+}
 
-	resp, err := c.httpClient.Do(req)
+// ListPipelines gets pipelines for a project, most recently updated first
+func (c *GitLabClient) ListPipelines(ctx context.Context, project string, opts core.PipelineListOptions) ([]core.Pipeline, error) {
+	query := url.Values{}
+	query.Set("order_by", "updated_at")
+	query.Set("sort", "desc")
+	if opts.Ref != "" {
+		query.Set("ref", opts.Ref)
+	}
+	if opts.Status != "" {
+		query.Set("status", opts.Status)
+	}
+
+	pipelines, err := getAll[Pipeline](ctx, c, "list pipelines for "+project, projectPath(project)+"/pipelines", query, opts.PerPage, opts.Limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	result := make([]core.Pipeline, 0, len(pipelines))
+	for _, p := range pipelines {
+		result = append(result, p.ToCore())
+	}
+	return result, nil
+}
+
+// GetPipeline gets a single pipeline
+func (c *GitLabClient) GetPipeline(ctx context.Context, project string, pipelineID int) (*core.Pipeline, error) {
+	var p Pipeline
+	op := fmt.Sprintf("get pipeline %d", pipelineID)
+	if err := c.get(ctx, op, fmt.Sprintf("%s/pipelines/%d", projectPath(project), pipelineID), nil, &p); err != nil {
+		return nil, err
+	}
+	result := p.ToCore()
+	return &result, nil
+}
+
+// ListPipelineJobs gets jobs for a pipeline
+func (c *GitLabClient) ListPipelineJobs(ctx context.Context, project string, pipelineID int, opts core.JobListOptions) ([]core.Job, error) {
+	query := url.Values{}
+	if opts.IncludeRetried {
+		query.Set("include_retried", "true")
 	}
 
-	var pipelines []Pipeline
-	if err := json.NewDecoder(resp.Body).Decode(&pipelines); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	op := fmt.Sprintf("list jobs for pipeline %d", pipelineID)
+	jobs, err := getAll[Job](ctx, c, op, fmt.Sprintf("%s/pipelines/%d/jobs", projectPath(project), pipelineID), query, opts.PerPage, opts.Limit)
+	if err != nil {
+		return nil, err
 	}
 
-	return pipelines, nil
+	result := make([]core.Job, 0, len(jobs))
+	for _, j := range jobs {
+		result = append(result, j.ToCore())
+	}
+	return result, nil
 }
 
-// GetJobs gets jobs for a pipeline
-func (c *GitLabClient) GetJobs(projectPath string, pipelineID int) ([]Job, error) {
-	projectID := url.PathEscape(projectPath)
+// ListPipelineBridges gets the trigger jobs of a pipeline with their downstream pipelines
+func (c *GitLabClient) ListPipelineBridges(ctx context.Context, project string, pipelineID int, opts core.JobListOptions) ([]core.Bridge, error) {
+	op := fmt.Sprintf("list bridges for pipeline %d", pipelineID)
+	bridges, err := getAll[Bridge](ctx, c, op, fmt.Sprintf("%s/pipelines/%d/bridges", projectPath(project), pipelineID), nil, opts.PerPage, opts.Limit)
+	if err != nil {
+		return nil, err
+	}
 
-	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%d/jobs", c.baseURL, projectID, pipelineID)
+	result := make([]core.Bridge, 0, len(bridges))
+	for _, b := range bridges {
+		result = append(result, b.ToCore())
+	}
+	return result, nil
+}
+
+// ListBranches gets the branches of a project, optionally filtered by name
+func (c *GitLabClient) ListBranches(ctx context.Context, project string, opts core.BranchListOptions) ([]core.Branch, error) {
+	query := url.Values{}
+	if opts.Search != "" {
+		query.Set("search", opts.Search)
+	}
 
-	req, err := http.NewRequest("GET", apiURL, nil)
+	branches, err := getAll[Branch](ctx, c, "list branches for "+project, projectPath(project)+"/repository/branches", query, opts.PerPage, opts.Limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", c.auth.GetAuthHeader())
-	req.Header.Set("Content-Type", "application/json")
+	result := make([]core.Branch, 0, len(branches))
+	for _, b := range branches {
+		result = append(result, b.ToCore())
+	}
+	return result, nil
+}
 
-	resp, err := c.httpClient.Do(req)
+// ListMergeRequests gets the merge requests of a project, most recently
+// updated first
+func (c *GitLabClient) ListMergeRequests(ctx context.Context, project string, opts core.MergeRequestListOptions) ([]core.MergeRequest, error) {
+	query := url.Values{}
+	query.Set("order_by", "updated_at")
+	query.Set("sort", "desc")
+	if opts.State != "" {
+		query.Set("state", opts.State)
+	}
+
+	mrs, err := getAll[MergeRequest](ctx, c, "list merge requests for "+project, projectPath(project)+"/merge_requests", query, opts.PerPage, opts.Limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	result := make([]core.MergeRequest, 0, len(mrs))
+	for _, mr := range mrs {
+		result = append(result, mr.ToCore())
 	}
+	return result, nil
+}
 
-	var jobs []Job
-	if err := json.NewDecoder(resp.Body).Decode(&jobs); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+// ListMergeRequestPipelines gets the pipelines of a merge request, newest first
+func (c *GitLabClient) ListMergeRequestPipelines(ctx context.Context, project string, iid int, opts core.PipelineListOptions) ([]core.Pipeline, error) {
+	op := fmt.Sprintf("list pipelines of merge request !%d", iid)
+	pipelines, err := getAll[Pipeline](ctx, c, op, fmt.Sprintf("%s/merge_requests/%d/pipelines", projectPath(project), iid), nil, opts.PerPage, opts.Limit)
+	if err != nil {
+		return nil, err
 	}
 
-	return jobs, nil
+	result := make([]core.Pipeline, 0, len(pipelines))
+	for _, p := range pipelines {
+		result = append(result, p.ToCore())
+	}
+	return result, nil
+}
+
+// GetMergeRequestApprovals gets the approval state of a merge request
+func (c *GitLabClient) GetMergeRequestApprovals(ctx context.Context, project string, iid int) (*core.MergeRequestApproval, error) {
+	var a MergeRequestApprovals
+	if err := c.get(ctx, fmt.Sprintf("get approvals of merge request !%d", iid), fmt.Sprintf("%s/merge_requests/%d/approvals", projectPath(project), iid), nil, &a); err != nil {
+		return nil, err
+	}
+	result := a.ToCore()
+	return &result, nil
 }
 
 // GetJob gets a specific job
-func (c *GitLabClient) GetJob(projectPath string, jobID int) (*Job, error) {
-	projectID := url.PathEscape(projectPath)
+func (c *GitLabClient) GetJob(ctx context.Context, project string, jobID int) (*core.Job, error) {
+	var j Job
+	op := fmt.Sprintf("get job %d", jobID)
+	if err := c.get(ctx, op, fmt.Sprintf("%s/jobs/%d", projectPath(project), jobID), nil, &j); err != nil {
+		return nil, err
+	}
+	result := j.ToCore()
+	return &result, nil
+}
 
-	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/jobs/%d", c.baseURL, projectID, jobID)
+// GetJobTrace gets the log output of a specific job
+func (c *GitLabClient) GetJobTrace(ctx context.Context, project string, jobID int) (string, error) {
+	op := fmt.Sprintf("get trace of job %d", jobID)
+	resp, err := c.do(ctx, op, http.MethodGet, fmt.Sprintf("%s/jobs/%d/trace", projectPath(project), jobID), nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
 
-	req, err := http.NewRequest("GET", apiURL, nil)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return "", core.WrapError(op, fmt.Errorf("failed to read response: %w", err))
 	}
 
-	req.Header.Set("Authorization", c.auth.GetAuthHeader())
-	req.Header.Set("Content-Type", "application/json")
+	return string(body), nil
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+// GetJobArtifacts streams the artifacts archive of a job to w
+func (c *GitLabClient) GetJobArtifacts(ctx context.Context, project string, jobID int, w io.Writer) error {
+	return c.download(ctx, fmt.Sprintf("get artifacts of job %d", jobID), fmt.Sprintf("%s/jobs/%d/artifacts", projectPath(project), jobID), w)
+}
+
+// GetJobArtifactFile streams one file of the artifacts of a job to w
+func (c *GitLabClient) GetJobArtifactFile(ctx context.Context, project string, jobID int, path string, w io.Writer) error {
+	return c.download(ctx, fmt.Sprintf("get artifact %s of job %d", path, jobID), fmt.Sprintf("%s/jobs/%d/artifacts/%s", projectPath(project), jobID, ArtifactPath(path)), w)
+}
+
+// GetPipelineTestReport gets the JUnit test report of a pipeline
+func (c *GitLabClient) GetPipelineTestReport(ctx context.Context, project string, pipelineID int) (*core.TestReport, error) {
+	var r TestReport
+	if err := c.get(ctx, fmt.Sprintf("get test report of pipeline %d", pipelineID), fmt.Sprintf("%s/pipelines/%d/test_report", projectPath(project), pipelineID), nil, &r); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
+	result := r.ToCore()
+	return &result, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+// GetPipelineTestReportSummary gets the per-suite test counts of a pipeline
+func (c *GitLabClient) GetPipelineTestReportSummary(ctx context.Context, project string, pipelineID int) (*core.TestReport, error) {
+	var r TestReportSummary
+	if err := c.get(ctx, fmt.Sprintf("get test report summary of pipeline %d", pipelineID), fmt.Sprintf("%s/pipelines/%d/test_report_summary", projectPath(project), pipelineID), nil, &r); err != nil {
+		return nil, err
+	}
+	result := r.ToCore()
+	return &result, nil
+}
+
+// CreatePipeline runs a new pipeline for a branch or tag
+func (c *GitLabClient) CreatePipeline(ctx context.Context, project, ref string, variables []core.JobVariable) (*core.Pipeline, error) {
+	var p Pipeline
+	if err := c.post(ctx, "run pipeline for "+ref, projectPath(project)+"/pipeline", NewCreatePipelineRequest(ref, variables), &p); err != nil {
+		return nil, err
+	}
+	result := p.ToCore()
+	return &result, nil
+}
+
+// RetryPipeline reruns the failed and canceled jobs of a pipeline
+func (c *GitLabClient) RetryPipeline(ctx context.Context, project string, pipelineID int) (*core.Pipeline, error) {
+	return c.pipelineAction(ctx, fmt.Sprintf("retry pipeline %d", pipelineID), fmt.Sprintf("%s/pipelines/%d/retry", projectPath(project), pipelineID))
+}
+
+// CancelPipeline cancels the running and pending jobs of a pipeline
+func (c *GitLabClient) CancelPipeline(ctx context.Context, project string, pipelineID int) (*core.Pipeline, error) {
+	return c.pipelineAction(ctx, fmt.Sprintf("cancel pipeline %d", pipelineID), fmt.Sprintf("%s/pipelines/%d/cancel", projectPath(project), pipelineID))
+}
+
+// DeletePipeline deletes a pipeline with its jobs and logs
+func (c *GitLabClient) DeletePipeline(ctx context.Context, project string, pipelineID int) error {
+	op := fmt.Sprintf("delete pipeline %d", pipelineID)
+	resp, err := c.do(ctx, op, http.MethodDelete, fmt.Sprintf("%s/pipelines/%d", projectPath(project), pipelineID), nil)
+	if err != nil {
+		return err
 	}
+	resp.Body.Close()
+	return nil
+}
 
-	var job Job
-	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+// RetryJob reruns a job and returns the new job
+func (c *GitLabClient) RetryJob(ctx context.Context, project string, jobID int) (*core.Job, error) {
+	return c.jobAction(ctx, fmt.Sprintf("retry job %d", jobID), fmt.Sprintf("%s/jobs/%d/retry", projectPath(project), jobID), nil)
+}
+
+// CancelJob cancels a running or pending job
+func (c *GitLabClient) CancelJob(ctx context.Context, project string, jobID int) (*core.Job, error) {
+	return c.jobAction(ctx, fmt.Sprintf("cancel job %d", jobID), fmt.Sprintf("%s/jobs/%d/cancel", projectPath(project), jobID), nil)
+}
+
+// PlayJob starts a manual job with optional variables
+func (c *GitLabClient) PlayJob(ctx context.Context, project string, jobID int, variables []core.JobVariable) (*core.Job, error) {
+	var body interface{}
+	if len(variables) > 0 {
+		body = NewPlayJobRequest(variables)
 	}
+	return c.jobAction(ctx, fmt.Sprintf("play job %d", jobID), fmt.Sprintf("%s/jobs/%d/play", projectPath(project), jobID), body)
+}
 
-	return &job, nil
+func (c *GitLabClient) pipelineAction(ctx context.Context, op, path string) (*core.Pipeline, error) {
+	var p Pipeline
+	if err := c.post(ctx, op, path, nil, &p); err != nil {
+		return nil, err
+	}
+	result := p.ToCore()
+	return &result, nil
 }
 
-// GetJobLogs gets logs for a specific job
-func (c *GitLabClient) GetJobLogs(projectPath string, jobID int) (string, error) {
-	projectID := url.PathEscape(projectPath)
+func (c *GitLabClient) jobAction(ctx context.Context, op, path string, body interface{}) (*core.Job, error) {
+	var j Job
+	if err := c.post(ctx, op, path, body, &j); err != nil {
+		return nil, err
+	}
+	result := j.ToCore()
+	return &result, nil
+}
 
-	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/jobs/%d/trace", c.baseURL, projectID, jobID)
+// TestConnection tests the GitLab API connection
+func (c *GitLabClient) TestConnection(ctx context.Context) error {
+	resp, err := c.do(ctx, "authenticate", http.MethodGet, "/user", nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
 
-	req, err := http.NewRequest("GET", apiURL, nil)
+// get performs a GET request and decodes the JSON response into out
+func (c *GitLabClient) get(ctx context.Context, op, path string, query url.Values, out interface{}) error {
+	resp, err := c.do(ctx, op, http.MethodGet, path, query)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
+	defer resp.Body.Close()
 
-	req.Header.Set("Authorization", c.auth.GetAuthHeader())
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return core.WrapError(op, fmt.Errorf("failed to decode response: %w", err))
+	}
+	return nil
+}
+
+// post sends a JSON body (none if nil) to a v4 API endpoint and decodes the
+// JSON response into out
+func (c *GitLabClient) post(ctx context.Context, op, path string, body, out interface{}) error {
+	return c.postURL(ctx, op, c.baseURL+"/api/v4"+path, body, out)
+}
+
+// postURL sends a JSON body to an absolute API URL. POST requests bypass
+// the response cache and aren't retried by the transport.
+func (c *GitLabClient) postURL(ctx context.Context, op, apiURL string, body, out interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return core.WrapError(op, fmt.Errorf("failed to encode request: %w", err))
+		}
+		payload = bytes.NewReader(encoded)
+	}
+
+	req, err := c.newRequest(ctx, op, http.MethodPost, apiURL, payload)
+	if err != nil {
+		return err
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to make request: %w", err)
+		return core.WrapError(op, fmt.Errorf("failed to make request: %w", err))
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return core.NewAPIError(op, resp.StatusCode, errorMessage(body))
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return core.WrapError(op, fmt.Errorf("failed to decode response: %w", err))
 	}
+	return nil
+}
 
-	return string(body), nil
+// do sends an authenticated request to the v4 API. Non-2xx responses are
+// turned into *core.APIError values and their body is closed.
+func (c *GitLabClient) do(ctx context.Context, op, method, path string, query url.Values) (*http.Response, error) {
+	apiURL := c.baseURL + "/api/v4" + path
+	if len(query) > 0 {
+		apiURL += "?" + query.Encode()
+	}
+
+	return c.doURL(ctx, op, method, apiURL)
 }
 
-// TestConnection tests the GitLab API connection
-func (c *GitLabClient) TestConnection() error {
-	apiURL := fmt.Sprintf("%s/api/v4/user", c.baseURL)
+// doURL sends an authenticated request to an absolute API URL. GET
+// requests are made conditional on the last response for the same URL, and
+// a 304 Not Modified is answered from the cache.
+func (c *GitLabClient) doURL(ctx context.Context, op, method, apiURL string) (*http.Response, error) {
+	req, err := c.newRequest(ctx, op, method, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	cached := c.cache.prepare(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, core.WrapError(op, fmt.Errorf("failed to make request: %w", err))
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		return cached.response(req, resp), nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, core.NewAPIError(op, resp.StatusCode, errorMessage(body))
+	}
+
+	resp, err = c.cache.store(req, resp)
+	if err != nil {
+		return nil, core.WrapError(op, fmt.Errorf("failed to read response: %w", err))
+	}
+	return resp, nil
+}
 
-	req, err := http.NewRequest("GET", apiURL, nil)
+// newRequest creates an authenticated request for an absolute API URL
+func (c *GitLabClient) newRequest(ctx context.Context, op, method, apiURL string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, body)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, core.WrapError(op, fmt.Errorf("failed to create request: %w", err))
 	}
 
 	req.Header.Set("Authorization", c.auth.GetAuthHeader())
 	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
 
-	resp, err := c.httpClient.Do(req)
+// download streams the body of a GET request to w
+func (c *GitLabClient) download(ctx context.Context, op, path string, w io.Writer) error {
+	resp, err := c.do(ctx, op, http.MethodGet, path, nil)
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode, string(body))
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return core.WrapError(op, fmt.Errorf("failed to read response: %w", err))
 	}
-
 	return nil
 }
+
+// projectPath builds the /projects/:id prefix for a project path or ID
+func projectPath(project string) string {
+	return "/projects/" + url.PathEscape(project)
+}
+
+// ArtifactPath escapes the path of a file inside an artifacts archive for
+// the jobs/:id/artifacts/*path endpoint, keeping its slashes
+func ArtifactPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// errorMessage extracts the human readable part of a GitLab error body
+func errorMessage(body []byte) string {
+	var payload struct {
+		Message interface{} `json:"message"`
+		Error   string      `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if payload.Message != nil {
+			return fmt.Sprint(payload.Message)
+		}
+		if payload.Error != "" {
+			return payload.Error
+		}
+	}
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// PipelineNeedsQuery reads the needs: relations of a pipeline's jobs, one
+// page of jobs at a time. Variables: path, iid and the after cursor.
+const PipelineNeedsQuery = `query($path: ID!, $iid: ID!, $after: String) {
+  project(fullPath: $path) {
+    pipeline(iid: $iid) {
+      jobs(after: $after) {
+        pageInfo { hasNextPage endCursor }
+        nodes { name needs { nodes { name } } }
+      }
+    }
+  }
+}`
+
+// PipelineNeedsData is the data of a PipelineNeedsQuery response
+type PipelineNeedsData struct {
+	Project *struct {
+		Pipeline *struct {
+			Jobs struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []struct {
+					Name  string `json:"name"`
+					Needs struct {
+						Nodes []struct {
+							Name string `json:"name"`
+						} `json:"nodes"`
+					} `json:"needs"`
+				} `json:"nodes"`
+			} `json:"jobs"`
+		} `json:"pipeline"`
+	} `json:"project"`
+}
+
+// GraphQLResponse is the envelope of every GraphQL answer
+type GraphQLResponse[T any] struct {
+	Data   T `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Err returns the GraphQL errors of the response, if any
+func (r GraphQLResponse[T]) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		messages = append(messages, e.Message)
+	}
+	return fmt.Errorf("graphql: %s", strings.Join(messages, "; "))
+}
+
+// AddTo merges the needs of this page into needs and returns the cursor of
+// the next page, or "" on the last page
+func (d PipelineNeedsData) AddTo(op string, needs map[string][]string) (string, error) {
+	if d.Project == nil || d.Project.Pipeline == nil {
+		return "", core.NewAPIError(op, http.StatusNotFound, "pipeline not visible through GraphQL")
+	}
+
+	jobs := d.Project.Pipeline.Jobs
+	for _, job := range jobs.Nodes {
+		for _, need := range job.Needs.Nodes {
+			needs[job.Name] = append(needs[job.Name], need.Name)
+		}
+	}
+
+	if !jobs.PageInfo.HasNextPage {
+		return "", nil
+	}
+	return jobs.PageInfo.EndCursor, nil
+}
+
+// ListJobNeeds reads the needs: relations of a pipeline's jobs through
+// GraphQL. It implements core.JobNeedsReader.
+func (c *GitLabClient) ListJobNeeds(ctx context.Context, project string, pipelineID int) (map[string][]string, error) {
+	op := fmt.Sprintf("get job needs for pipeline %d", pipelineID)
+
+	// GraphQL addresses pipelines by project path and IID
+	p, err := c.GetProject(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := c.GetPipeline(ctx, project, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	needs := make(map[string][]string)
+	variables := map[string]interface{}{
+		"path": p.PathWithNamespace,
+		"iid":  strconv.Itoa(pipeline.IID),
+	}
+	for {
+		var resp GraphQLResponse[PipelineNeedsData]
+		if err := c.graphql(ctx, op, PipelineNeedsQuery, variables, &resp); err != nil {
+			return nil, err
+		}
+		if err := resp.Err(); err != nil {
+			return nil, core.WrapError(op, err)
+		}
+
+		after, err := resp.Data.AddTo(op, needs)
+		if err != nil {
+			return nil, err
+		}
+		if after == "" {
+			return needs, nil
+		}
+		variables["after"] = after
+	}
+}
+
+// graphql posts a query to the GraphQL endpoint and decodes the response
+func (c *GitLabClient) graphql(ctx context.Context, op, query string, variables map[string]interface{}, out interface{}) error {
+	body := map[string]interface{}{"query": query, "variables": variables}
+	return c.postURL(ctx, op, c.baseURL+"/api/graphql", body, out)
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// getAll fetches every page of a list endpoint, stopping once limit items
+// have been collected (limit <= 0 fetches everything).
+//
+// The Link header is followed when present, which covers both offset and
+// keyset pagination (pass pagination=keyset in query on endpoints that
+// support it). Otherwise the X-Next-Page header drives offset pagination.
+func getAll[T any](ctx context.Context, c *GitLabClient, op, path string, query url.Values, perPage, limit int) ([]T, error) {
+	return getAllUntil[T](ctx, c, op, path, query, perPage, limit, nil)
+}
+
+// getAllUntil is getAll that also stops at the first item for which stop
+// returns true, leaving it out. A nil stop never stops.
+func getAllUntil[T any](ctx context.Context, c *GitLabClient, op, path string, query url.Values, perPage, limit int, stop func(T) bool) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("per_page", strconv.Itoa(core.PageSize(perPage, limit)))
+
+	nextURL := c.baseURL + "/api/v4" + path + "?" + query.Encode()
+
+	var items []T
+	for nextURL != "" {
+		resp, err := c.doURL(ctx, op, http.MethodGet, nextURL)
+		if err != nil {
+			return nil, err
+		}
+
+		var page []T
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, core.WrapError(op, fmt.Errorf("failed to decode response: %w", err))
+		}
+
+		stopped := false
+		for i, item := range page {
+			if stop != nil && stop(item) {
+				page, stopped = page[:i], true
+				break
+			}
+		}
+
+		items = append(items, page...)
+		if limit > 0 && len(items) >= limit {
+			return items[:limit], nil
+		}
+		if stopped || len(page) == 0 {
+			break
+		}
+
+		nextURL = c.nextPageURL(resp, nextURL)
+	}
+
+	return items, nil
+}
+
+// nextPageURL works out the URL of the page after current, or "" on the last page
+func (c *GitLabClient) nextPageURL(resp *http.Response, current string) string {
+	if next := nextLink(resp.Header.Get("Link")); next != "" && strings.HasPrefix(next, c.baseURL+"/") {
+		return next
+	}
+
+	nextPage := resp.Header.Get("X-Next-Page")
+	if nextPage == "" {
+		return ""
+	}
+
+	u, err := url.Parse(current)
+	if err != nil {
+		return ""
+	}
+	q := u.Query()
+	q.Set("page", nextPage)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// nextLink extracts the rel="next" target from an RFC 5988 Link header
+func nextLink(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		segments := strings.Split(part, ";")
+		if len(segments) < 2 {
+			continue
+		}
+		for _, param := range segments[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(segments[0]), "<>")
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// projectPages serves 3 pages of 2 projects, most recently active first:
+// project i was last active i days before now
+func projectPages(t *testing.T, now time.Time) (*httptest.Server, *int) {
+	t.Helper()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page = max(page, 1)
+
+		var projects []map[string]interface{}
+		for i := (page - 1) * 2; i < page*2; i++ {
+			projects = append(projects, map[string]interface{}{
+				"id":               i,
+				"name":             fmt.Sprintf("p%d", i),
+				"last_activity_at": now.AddDate(0, 0, -i).Format(time.RFC3339),
+			})
+		}
+		if page < 3 {
+			w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+		}
+		json.NewEncoder(w).Encode(projects)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestListGroupProjects(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name         string
+		opts         core.ProjectListOptions
+		wantIDs      []int
+		wantRequests int
+	}{
+		{"every page", core.ProjectListOptions{}, []int{0, 1, 2, 3, 4, 5}, 3},
+		{"limit", core.ProjectListOptions{Limit: 3}, []int{0, 1, 2}, 2},
+		{"stops at the first inactive project", core.ProjectListOptions{ActiveSince: now.AddDate(0, 0, -2).Add(-time.Hour)}, []int{0, 1, 2}, 2},
+		{"stops at the start of a page", core.ProjectListOptions{ActiveSince: now.AddDate(0, 0, -1).Add(-time.Hour)}, []int{0, 1}, 2},
+		{"all active", core.ProjectListOptions{ActiveSince: now.AddDate(0, 0, -30)}, []int{0, 1, 2, 3, 4, 5}, 3},
+		{"limit before the cutoff", core.ProjectListOptions{Limit: 1, ActiveSince: now.AddDate(0, 0, -30)}, []int{0}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := projectPages(t, now)
+			client := NewGitLabClientWithToken(server.URL, "token")
+
+			tt.opts.PerPage = 2
+			projects, err := client.ListGroupProjects(context.Background(), "group", tt.opts)
+			if err != nil {
+				t.Fatalf("ListGroupProjects() error = %v", err)
+			}
+			var ids []int
+			for _, p := range projects {
+				ids = append(ids, p.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIDs) {
+				t.Errorf("projects = %v, want %v", ids, tt.wantIDs)
+			}
+			if *requests != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", *requests, tt.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// traceTailSize is how much of the already received trace is kept to check
+// that a response still continues where we left off. Ranges start this far
+// back, so a partial response repeats the tail too.
+const traceTailSize = 256
+
+// FollowJobTrace streams a job's log, fetching only the bytes appended since
+// the previous poll. It implements core.TraceFollower.
+func (c *GitLabClient) FollowJobTrace(ctx context.Context, project string, jobID int, interval time.Duration) <-chan core.TraceChunk {
+	follower := NewTraceFollower(c, project, jobID)
+	status := func(ctx context.Context) (string, error) {
+		job, err := c.GetJob(ctx, project, jobID)
+		if err != nil {
+			return "", err
+		}
+		return job.Status, nil
+	}
+
+	return core.FollowTrace(ctx, interval, follower.Next, status)
+}
+
+// TraceFollower reads a job trace incrementally. It asks for the bytes past
+// its offset with a Range header, along with the last few it already has to
+// see they haven't changed, and copes with servers that ignore the range and
+// with traces that were truncated or restarted.
+type TraceFollower struct {
+	client  *GitLabClient
+	project string
+	jobID   int
+
+	offset int64  // Bytes of the trace received so far
+	tail   []byte // Last bytes received, to detect a rewritten trace
+}
+
+// NewTraceFollower creates a follower that starts at the beginning of the trace
+func NewTraceFollower(client *GitLabClient, project string, jobID int) *TraceFollower {
+	return &TraceFollower{client: client, project: project, jobID: jobID}
+}
+
+// Offset returns the number of trace bytes received so far
+func (f *TraceFollower) Offset() int64 {
+	return f.offset
+}
+
+// Next fetches what was appended to the trace since the previous call
+func (f *TraceFollower) Next(ctx context.Context) (core.TraceChunk, error) {
+	return f.fetch(ctx, f.offset > 0)
+}
+
+// fetch requests the trace, only the bytes past the offset if ranged
+func (f *TraceFollower) fetch(ctx context.Context, ranged bool) (core.TraceChunk, error) {
+	op := fmt.Sprintf("follow trace of job %d", f.jobID)
+	apiURL := fmt.Sprintf("%s/api/v4%s/jobs/%d/trace", f.client.baseURL, projectPath(f.project), f.jobID)
+
+	req, err := f.client.newRequest(ctx, op, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return core.TraceChunk{}, err
+	}
+	from := f.offset - int64(len(f.tail))
+	if ranged {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", from))
+	}
+
+	resp, err := f.client.httpClient.Do(req)
+	if err != nil {
+		return core.TraceChunk{}, core.WrapError(op, fmt.Errorf("failed to make request: %w", err))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return core.TraceChunk{}, core.WrapError(op, fmt.Errorf("failed to read response: %w", err))
+	}
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		// A restarted job's trace can be longer than ours already, so check
+		// that it still has our tail where we left off
+		start, _, ok := contentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != from || !bytes.HasPrefix(body, f.tail) {
+			// Not the trace or range we asked for, compare against the full trace
+			return f.fetch(ctx, false)
+		}
+		return f.append(body[len(f.tail):]), nil
+
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Nothing past where we asked: either no new output or a shorter trace
+		_, total, ok := contentRange(resp.Header.Get("Content-Range"))
+		switch {
+		case !ok:
+			return f.fetch(ctx, false)
+		case total < f.offset:
+			return f.restart(ctx)
+		}
+		return core.TraceChunk{}, nil
+
+	case http.StatusOK:
+		// Range ignored, we got the whole trace
+		if int64(len(body)) >= f.offset && bytes.HasSuffix(body[:f.offset], f.tail) {
+			return f.append(body[f.offset:]), nil
+		}
+		f.reset()
+		chunk := f.append(body)
+		chunk.Reset = true
+		return chunk, nil
+
+	default:
+		return core.TraceChunk{}, core.NewAPIError(op, resp.StatusCode, errorMessage(body))
+	}
+}
+
+// restart drops the offset and fetches the trace from the start
+func (f *TraceFollower) restart(ctx context.Context) (core.TraceChunk, error) {
+	f.reset()
+
+	chunk, err := f.fetch(ctx, false)
+	chunk.Reset = err == nil
+	return chunk, err
+}
+
+func (f *TraceFollower) reset() {
+	f.offset = 0
+	f.tail = nil
+}
+
+// append records data as received and wraps it in a chunk
+func (f *TraceFollower) append(data []byte) core.TraceChunk {
+	f.offset += int64(len(data))
+
+	f.tail = append(f.tail, data...)
+	if len(f.tail) > traceTailSize {
+		f.tail = append([]byte(nil), f.tail[len(f.tail)-traceTailSize:]...)
+	}
+
+	return core.TraceChunk{Data: string(data)}
+}
+
+// contentRange parses "bytes 100-199/1000" or "bytes */1000" into the start
+// offset and total size
+func contentRange(header string) (start, total int64, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes ")
+	if !found {
+		return 0, 0, false
+	}
+	byteRange, size, found := strings.Cut(spec, "/")
+	if !found {
+		return 0, 0, false
+	}
+
+	total, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if byteRange == "*" {
+		return 0, total, true
+	}
+
+	first, _, _ := strings.Cut(byteRange, "-")
+	start, err = strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, total, true
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// traceServer serves a job trace that the test can change, answering Range
+// requests like GitLab unless ranges are ignored
+type traceServer struct {
+	mu           sync.Mutex
+	trace        string
+	ignoreRanges bool
+	ranges       []string // Range headers received
+}
+
+func (s *traceServer) set(trace string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trace = trace
+}
+
+func (s *traceServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	header := r.Header.Get("Range")
+	s.ranges = append(s.ranges, header)
+	if header == "" || s.ignoreRanges {
+		w.Write([]byte(s.trace))
+		return
+	}
+
+	start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(header, "bytes="), "-"))
+	if err != nil {
+		http.Error(w, "bad range", http.StatusBadRequest)
+		return
+	}
+	if start >= len(s.trace) {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", len(s.trace)))
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+	w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(s.trace)-1, len(s.trace)))
+	w.WriteHeader(http.StatusPartialContent)
+	w.Write([]byte(s.trace[start:]))
+}
+
+func newTestFollower(t *testing.T, server *traceServer) *TraceFollower {
+	t.Helper()
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	return NewTraceFollower(NewGitLabClientWithToken(ts.URL, "token"), "group/project", 1)
+}
+
+func TestTraceFollower(t *testing.T) {
+	tests := []struct {
+		name         string
+		ignoreRanges bool
+		first        string
+		second       string
+		want         core.TraceChunk
+	}{
+		{"206 appended output", false, "line 1\n", "line 1\nline 2\n", core.TraceChunk{Data: "line 2\n"}},
+		{"206 no new output", false, "line 1\n", "line 1\n", core.TraceChunk{}},
+		{"200 appended output", true, "line 1\n", "line 1\nline 2\n", core.TraceChunk{Data: "line 2\n"}},
+		{"200 no new output", true, "line 1\n", "line 1\n", core.TraceChunk{}},
+		{"416 truncated trace", false, "line 1\nline 2\n", "new\n", core.TraceChunk{Data: "new\n", Reset: true}},
+		{"200 truncated trace", true, "line 1\nline 2\n", "new\n", core.TraceChunk{Data: "new\n", Reset: true}},
+		{
+			"206 rewritten trace longer than ours",
+			false,
+			"attempt 1\n",
+			"attempt 2\nmore output\n",
+			core.TraceChunk{Data: "attempt 2\nmore output\n", Reset: true},
+		},
+		{
+			"200 rewritten trace longer than ours",
+			true,
+			"attempt 1\n",
+			"attempt 2\nmore output\n",
+			core.TraceChunk{Data: "attempt 2\nmore output\n", Reset: true},
+		},
+		{
+			"206 long trace rewritten before the tail",
+			false,
+			strings.Repeat("a", 1000) + "\n",
+			strings.Repeat("b", 500) + strings.Repeat("a", 500) + "\nmore\n",
+			core.TraceChunk{Data: "more\n"},
+		},
+		{
+			"206 long trace rewritten in the tail",
+			false,
+			strings.Repeat("a", 1000) + "\n",
+			strings.Repeat("a", 900) + strings.Repeat("b", 100) + "\nmore\n",
+			core.TraceChunk{Data: strings.Repeat("a", 900) + strings.Repeat("b", 100) + "\nmore\n", Reset: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &traceServer{trace: tt.first, ignoreRanges: tt.ignoreRanges}
+			follower := newTestFollower(t, server)
+
+			chunk, err := follower.Next(context.Background())
+			if err != nil {
+				t.Fatalf("first Next() error = %v", err)
+			}
+			if chunk != (core.TraceChunk{Data: tt.first}) {
+				t.Fatalf("first Next() = %+v, want the whole trace", chunk)
+			}
+
+			server.set(tt.second)
+			chunk, err = follower.Next(context.Background())
+			if err != nil {
+				t.Fatalf("Next() error = %v", err)
+			}
+			if chunk != tt.want {
+				t.Errorf("Next() = %+v, want %+v", chunk, tt.want)
+			}
+			if follower.Offset() != int64(len(tt.second)) {
+				t.Errorf("Offset() = %d, want %d", follower.Offset(), len(tt.second))
+			}
+		})
+	}
+}
+
+func TestTraceFollower_RangeStartsAtTail(t *testing.T) {
+	trace := strings.Repeat("x", 1000)
+	server := &traceServer{trace: trace}
+	follower := newTestFollower(t, server)
+
+	follower.Next(context.Background())
+	follower.Next(context.Background())
+
+	want := []string{"", fmt.Sprintf("bytes=%d-", len(trace)-traceTailSize)}
+	if fmt.Sprint(server.ranges) != fmt.Sprint(want) {
+		t.Errorf("Range headers = %q, want %q", server.ranges, want)
+	}
+}
+
+func TestContentRange(t *testing.T) {
+	tests := []struct {
+		header    string
+		wantStart int64
+		wantTotal int64
+		wantOK    bool
+	}{
+		{"bytes 100-199/1000", 100, 1000, true},
+		{"bytes */1000", 0, 1000, true},
+		{"bytes 100-199/*", 0, 0, false},
+		{"items 0-9/10", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		start, total, ok := contentRange(tt.header)
+		if start != tt.wantStart || total != tt.wantTotal || ok != tt.wantOK {
+			t.Errorf("contentRange(%q) = %d, %d, %v, want %d, %d, %v",
+				tt.header, start, total, ok, tt.wantStart, tt.wantTotal, tt.wantOK)
+		}
+	}
+}
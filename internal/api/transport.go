@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+const (
+	// defaultMaxRetries is how often an idempotent request is retried
+	defaultMaxRetries = 4
+
+	// minBackoff and maxBackoff bound the wait between retries
+	minBackoff = 500 * time.Millisecond
+	maxBackoff = 30 * time.Second
+
+	// requestTimeout bounds how long a single attempt waits for response headers
+	requestTimeout = 30 * time.Second
+)
+
+// Transport is a rate-limit aware http.RoundTripper shared by the API
+// backends. It records GitLab's RateLimit-* headers, holds requests back
+// while the budget is exhausted, and retries idempotent requests with
+// jittered exponential backoff on 429 and 5xx responses.
+type Transport struct {
+	// Base performs the actual requests, http.DefaultTransport if nil
+	Base http.RoundTripper
+
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+
+	mu    sync.Mutex
+	limit core.RateLimit
+}
+
+// NewTransport creates a Transport on top of base (nil uses a default
+// transport with a response header timeout)
+func NewTransport(base http.RoundTripper) *Transport {
+	if base == nil {
+		defaultTransport := http.DefaultTransport.(*http.Transport).Clone()
+		defaultTransport.ResponseHeaderTimeout = requestTimeout
+		base = defaultTransport
+	}
+	return &Transport{
+		Base:       base,
+		MaxRetries: defaultMaxRetries,
+	}
+}
+
+// NewHTTPClient creates an http.Client using a fresh Transport. The
+// overall timeout is left to the request context since retries may wait
+// for a rate limit window to reset.
+func NewHTTPClient() *http.Client {
+	return &http.Client{Transport: NewTransport(nil)}
+}
+
+// RateLimit returns the most recently observed rate-limit budget
+func (t *Transport) RateLimit() (core.RateLimit, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.limit, t.limit.Limit > 0
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := sleep(req.Context(), t.exhaustedFor()); err != nil {
+		return nil, err
+	}
+
+	retries := 0
+	if isIdempotent(req) {
+		retries = t.MaxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.Base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		t.record(resp.Header)
+
+		if attempt >= retries || !isRetryable(resp.StatusCode) {
+			return resp, nil
+		}
+
+		wait := retryDelay(resp, attempt)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// record updates the budget from GitLab's RateLimit-* response headers
+func (t *Transport) record(header http.Header) {
+	limit, err := strconv.Atoi(header.Get("RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(header.Get("RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	var reset time.Time
+	if epoch, err := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64); err == nil {
+		reset = time.Unix(epoch, 0)
+	}
+
+	t.mu.Lock()
+	t.limit = core.RateLimit{Limit: limit, Remaining: remaining, Reset: reset}
+	t.mu.Unlock()
+}
+
+// exhaustedFor returns how long to hold requests back because the budget
+// is used up, or 0 if requests may go out
+func (t *Transport) exhaustedFor() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.limit.Limit == 0 || t.limit.Remaining > 0 || t.limit.Reset.IsZero() {
+		return 0
+	}
+	wait := time.Until(t.limit.Reset)
+	if wait < 0 {
+		return 0
+	}
+	return min(wait, maxBackoff)
+}
+
+// retryDelay works out how long to wait before the next attempt, preferring
+// what the server asked for over the exponential backoff
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+		return min(wait, maxBackoff)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if epoch, err := strconv.ParseInt(resp.Header.Get("RateLimit-Reset"), 10, 64); err == nil {
+			if wait := time.Until(time.Unix(epoch, 0)); wait > 0 {
+				return min(wait, maxBackoff)
+			}
+		}
+	}
+
+	backoff := minBackoff << attempt
+	if backoff > maxBackoff || backoff <= 0 {
+		backoff = maxBackoff
+	}
+	// Full jitter keeps concurrent dashboard workers from retrying in lockstep
+	return time.Duration(rand.Int63n(int64(backoff))) + minBackoff/2
+}
+
+// retryAfter parses a Retry-After header in either seconds or HTTP date form
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// isIdempotent reports whether a request can safely be sent again
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return req.Body == nil || req.Body == http.NoBody
+	}
+	return false
+}
+
+// isRetryable reports whether a response status is worth another attempt
+func isRetryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,373 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// Project represents a GitLab project
+type Project struct {
+	ID                int       `json:"id"`
+	Name              string    `json:"name"`
+	NameWithNamespace string    `json:"name_with_namespace"`
+	PathWithNamespace string    `json:"path_with_namespace"`
+	DefaultBranch     string    `json:"default_branch"`
+	WebURL            string    `json:"web_url"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	Archived          bool      `json:"archived"`
+}
+
+// Pipeline represents a GitLab pipeline
+type Pipeline struct {
+	ID             int       `json:"id"`
+	IID            int       `json:"iid"`
+	ProjectID      int       `json:"project_id"`
+	Status         string    `json:"status"`
+	Source         string    `json:"source"`
+	Ref            string    `json:"ref"`
+	SHA            string    `json:"sha"`
+	WebURL         string    `json:"web_url"`
+	Duration       *float64  `json:"duration"`
+	QueuedDuration *float64  `json:"queued_duration"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Job represents a GitLab job
+type Job struct {
+	ID             int        `json:"id"`
+	Name           string     `json:"name"`
+	Status         string     `json:"status"`
+	Stage          string     `json:"stage"`
+	Ref            string     `json:"ref"`
+	CreatedAt      time.Time  `json:"created_at"`
+	StartedAt      *time.Time `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at"`
+	Duration       *float64   `json:"duration"`
+	QueuedDuration *float64   `json:"queued_duration"`
+	WebURL         string     `json:"web_url"`
+	Pipeline       struct {
+		ID        int `json:"id"`
+		ProjectID int `json:"project_id"`
+	} `json:"pipeline"`
+}
+
+// Branch represents a branch of a GitLab repository
+type Branch struct {
+	Name      string `json:"name"`
+	Default   bool   `json:"default"`
+	Protected bool   `json:"protected"`
+}
+
+// MergeRequest represents a GitLab merge request
+type MergeRequest struct {
+	ID           int       `json:"id"`
+	IID          int       `json:"iid"`
+	ProjectID    int       `json:"project_id"`
+	Title        string    `json:"title"`
+	SourceBranch string    `json:"source_branch"`
+	TargetBranch string    `json:"target_branch"`
+	State        string    `json:"state"`
+	Draft        bool      `json:"draft"`
+	WebURL       string    `json:"web_url"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Author       struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+// MergeRequestApprovals is the approval state of a merge request
+type MergeRequestApprovals struct {
+	ApprovalsRequired int `json:"approvals_required"`
+	ApprovalsLeft     int `json:"approvals_left"`
+	ApprovedBy        []struct {
+		User struct {
+			Username string `json:"username"`
+		} `json:"user"`
+	} `json:"approved_by"`
+}
+
+// TestReport is the JUnit test report of a pipeline
+type TestReport struct {
+	TotalTime    float64     `json:"total_time"`
+	TotalCount   int         `json:"total_count"`
+	SuccessCount int         `json:"success_count"`
+	FailedCount  int         `json:"failed_count"`
+	SkippedCount int         `json:"skipped_count"`
+	ErrorCount   int         `json:"error_count"`
+	TestSuites   []TestSuite `json:"test_suites"`
+}
+
+// TestSuite is a suite of a test report. The summary endpoint adds the
+// jobs that reported it and leaves out the test cases.
+type TestSuite struct {
+	Name         string     `json:"name"`
+	TotalTime    float64    `json:"total_time"`
+	TotalCount   int        `json:"total_count"`
+	SuccessCount int        `json:"success_count"`
+	FailedCount  int        `json:"failed_count"`
+	SkippedCount int        `json:"skipped_count"`
+	ErrorCount   int        `json:"error_count"`
+	SuiteError   string     `json:"suite_error"`
+	BuildIDs     []int      `json:"build_ids"`
+	TestCases    []TestCase `json:"test_cases"`
+}
+
+// TestCase is a test case of a suite
+type TestCase struct {
+	Status        string      `json:"status"`
+	Name          string      `json:"name"`
+	Classname     string      `json:"classname"`
+	File          string      `json:"file"`
+	ExecutionTime float64     `json:"execution_time"`
+	SystemOutput  interface{} `json:"system_output"` // A string, or a list of strings for some report formats
+	StackTrace    string      `json:"stack_trace"`
+}
+
+// TestReportSummary is the per-suite test report of a pipeline without
+// the test cases
+type TestReportSummary struct {
+	Total struct {
+		Time    float64 `json:"time"`
+		Count   int     `json:"count"`
+		Success int     `json:"success"`
+		Failed  int     `json:"failed"`
+		Skipped int     `json:"skipped"`
+		Error   int     `json:"error"`
+	} `json:"total"`
+	TestSuites []TestSuite `json:"test_suites"`
+}
+
+// CreatePipelineRequest is the body of a request running a new pipeline
+type CreatePipelineRequest struct {
+	Ref       string             `json:"ref"`
+	Variables []PipelineVariable `json:"variables,omitempty"`
+}
+
+// PipelineVariable is a variable passed to a new pipeline
+type PipelineVariable struct {
+	Key          string `json:"key"`
+	Value        string `json:"value"`
+	VariableType string `json:"variable_type"`
+}
+
+// NewCreatePipelineRequest builds the body for running a pipeline on a ref
+func NewCreatePipelineRequest(ref string, variables []core.JobVariable) CreatePipelineRequest {
+	req := CreatePipelineRequest{Ref: ref}
+	for _, v := range variables {
+		req.Variables = append(req.Variables, PipelineVariable{Key: v.Key, Value: v.Value, VariableType: "env_var"})
+	}
+	return req
+}
+
+// PlayJobRequest is the body of a request playing a manual job
+type PlayJobRequest struct {
+	JobVariablesAttributes []JobVariable `json:"job_variables_attributes"`
+}
+
+// JobVariable is a variable passed to a played job
+type JobVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// NewPlayJobRequest builds the body for playing a job with variables
+func NewPlayJobRequest(variables []core.JobVariable) PlayJobRequest {
+	req := PlayJobRequest{JobVariablesAttributes: make([]JobVariable, 0, len(variables))}
+	for _, v := range variables {
+		req.JobVariablesAttributes = append(req.JobVariablesAttributes, JobVariable{Key: v.Key, Value: v.Value})
+	}
+	return req
+}
+
+// Bridge represents a GitLab trigger job
+type Bridge struct {
+	Job
+	DownstreamPipeline *Pipeline `json:"downstream_pipeline"`
+}
+
+// ToCore converts the API representation into the domain model
+func (p Project) ToCore() core.Project {
+	lastActivity := ""
+	if !p.LastActivityAt.IsZero() {
+		lastActivity = p.LastActivityAt.Format(time.RFC3339)
+	}
+	return core.Project{
+		ID:                p.ID,
+		Name:              p.Name,
+		NameWithNamespace: p.NameWithNamespace,
+		PathWithNamespace: p.PathWithNamespace,
+		DefaultBranch:     p.DefaultBranch,
+		WebURL:            p.WebURL,
+		LastActivityAt:    lastActivity,
+		Archived:          p.Archived,
+	}
+}
+
+// ToCore converts the API representation into the domain model
+func (p Pipeline) ToCore() core.Pipeline {
+	pipeline := core.Pipeline{
+		ID:        p.ID,
+		IID:       p.IID,
+		Status:    p.Status,
+		Ref:       p.Ref,
+		SHA:       p.SHA,
+		Source:    p.Source,
+		WebURL:    p.WebURL,
+		ProjectID: p.ProjectID,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+	if p.Duration != nil {
+		pipeline.Duration = core.FormatDuration(*p.Duration)
+		pipeline.DurationSeconds = *p.Duration
+	}
+	if p.QueuedDuration != nil {
+		pipeline.QueuedSeconds = *p.QueuedDuration
+	}
+	return pipeline
+}
+
+// ToCore converts the API representation into the domain model
+func (j Job) ToCore() core.Job {
+	job := core.Job{
+		ID:         j.ID,
+		Name:       j.Name,
+		Status:     j.Status,
+		Stage:      j.Stage,
+		Ref:        j.Ref,
+		WebURL:     j.WebURL,
+		PipelineID: j.Pipeline.ID,
+		ProjectID:  j.Pipeline.ProjectID,
+	}
+	if j.Duration != nil {
+		job.Duration = core.FormatDuration(*j.Duration)
+		job.DurationSeconds = *j.Duration
+	}
+	if j.QueuedDuration != nil {
+		job.QueuedSeconds = *j.QueuedDuration
+	}
+	return job
+}
+
+// ToCore converts the API representation into the domain model
+func (mr MergeRequest) ToCore() core.MergeRequest {
+	return core.MergeRequest{
+		ID:           mr.ID,
+		IID:          mr.IID,
+		ProjectID:    mr.ProjectID,
+		Title:        mr.Title,
+		Author:       mr.Author.Username,
+		SourceBranch: mr.SourceBranch,
+		TargetBranch: mr.TargetBranch,
+		State:        mr.State,
+		Draft:        mr.Draft,
+		WebURL:       mr.WebURL,
+		UpdatedAt:    mr.UpdatedAt,
+	}
+}
+
+// ToCore converts the API representation into the domain model
+func (a MergeRequestApprovals) ToCore() core.MergeRequestApproval {
+	approval := core.MergeRequestApproval{Required: a.ApprovalsRequired, Left: a.ApprovalsLeft}
+	for _, by := range a.ApprovedBy {
+		approval.By = append(approval.By, by.User.Username)
+	}
+	return approval
+}
+
+// ToCore converts the API representation into the domain model
+func (b Branch) ToCore() core.Branch {
+	return core.Branch{Name: b.Name, Default: b.Default, Protected: b.Protected}
+}
+
+// ToCore converts the API representation into the domain model
+func (r TestReport) ToCore() core.TestReport {
+	report := core.TestReport{TestCounts: core.TestCounts{
+		Total:   r.TotalCount,
+		Success: r.SuccessCount,
+		Failed:  r.FailedCount,
+		Skipped: r.SkippedCount,
+		Error:   r.ErrorCount,
+		Time:    r.TotalTime,
+	}}
+	for _, suite := range r.TestSuites {
+		report.Suites = append(report.Suites, suite.ToCore())
+	}
+	return report
+}
+
+// ToCore converts the API representation into the domain model
+func (r TestReportSummary) ToCore() core.TestReport {
+	report := core.TestReport{TestCounts: core.TestCounts{
+		Total:   r.Total.Count,
+		Success: r.Total.Success,
+		Failed:  r.Total.Failed,
+		Skipped: r.Total.Skipped,
+		Error:   r.Total.Error,
+		Time:    r.Total.Time,
+	}}
+	for _, suite := range r.TestSuites {
+		report.Suites = append(report.Suites, suite.ToCore())
+	}
+	return report
+}
+
+// ToCore converts the API representation into the domain model
+func (s TestSuite) ToCore() core.TestSuite {
+	suite := core.TestSuite{
+		Name: s.Name,
+		TestCounts: core.TestCounts{
+			Total:   s.TotalCount,
+			Success: s.SuccessCount,
+			Failed:  s.FailedCount,
+			Skipped: s.SkippedCount,
+			Error:   s.ErrorCount,
+			Time:    s.TotalTime,
+		},
+		SuiteError: s.SuiteError,
+		JobIDs:     s.BuildIDs,
+	}
+	for _, c := range s.TestCases {
+		suite.Cases = append(suite.Cases, core.TestCase{
+			Status:       c.Status,
+			Name:         c.Name,
+			Classname:    c.Classname,
+			File:         c.File,
+			Time:         c.ExecutionTime,
+			SystemOutput: SystemOutput(c.SystemOutput),
+			StackTrace:   c.StackTrace,
+		})
+	}
+	return suite
+}
+
+// SystemOutput flattens the system output of a test case, which GitLab
+// reports as a string or as a list of strings
+func SystemOutput(v interface{}) string {
+	switch out := v.(type) {
+	case string:
+		return out
+	case []interface{}:
+		lines := make([]string, 0, len(out))
+		for _, line := range out {
+			if s, ok := line.(string); ok {
+				lines = append(lines, s)
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+	return ""
+}
+
+// ToCore converts the API representation into the domain model
+func (b Bridge) ToCore() core.Bridge {
+	bridge := core.Bridge{Job: b.Job.ToCore()}
+	if b.DownstreamPipeline != nil {
+		downstream := b.DownstreamPipeline.ToCore()
+		bridge.Downstream = &downstream
+	}
+	return bridge
+}
@@ -46,6 +46,14 @@ func NewGitLabAuth() (*GitLabAuth, error) {
 	return nil, fmt.Errorf("no GitLab token found - run 'glab auth login' first")
 }
 
+// NewTokenAuth creates an authentication handler for an explicit token
+func NewTokenAuth(baseURL, token string) *GitLabAuth {
+	return &GitLabAuth{
+		token:   token,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
 // getTokenFromGlabConfig reads the token from glab CLI config file
 func (g *GitLabAuth) getTokenFromGlabConfig() (string, error) {
 	homeDir, err := os.UserHomeDir()
@@ -10,12 +10,14 @@ import (
 type Config struct {
 	GitLab GitLabConfig
 	UI     UIConfig
+	Log    LogConfig
 }
 
 type GitLabConfig struct {
 	URL             string
 	Token           string
-	ProjectID       int // Single project ID for testing
+	Backend         string // auto, glab, api or go-gitlab
+	ProjectID       int    // Single project ID for testing
 	GroupID         int
 	GroupPath       string
 	ProjectIDs      []int
@@ -30,6 +32,11 @@ type UIConfig struct {
 	MaxPipelinesPerProject int
 }
 
+type LogConfig struct {
+	ErrorSets    []string // Failure pattern sets to detect, all if empty
+	ErrorPattern string   // Extra regular expression for failure lines
+}
+
 func Load() (*Config, error) {
 	// Load .env file if it exists
 	loadEnvFile()
@@ -52,6 +59,13 @@ func Load() (*Config, error) {
 		}
 	}
 
+	var errorSets []string
+	for _, set := range strings.Split(getEnv("LOG_ERROR_SETS", ""), ",") {
+		if set = strings.TrimSpace(set); set != "" {
+			errorSets = append(errorSets, set)
+		}
+	}
+
 	// Try to get token from .env first, then from glab config
 	token := getEnv("GITLAB_TOKEN", "")
 	if token == "" || token == "your-token-here" {
@@ -64,6 +78,7 @@ func Load() (*Config, error) {
 		GitLab: GitLabConfig{
 			URL:             getEnv("GITLAB_URL", "https://gitlab.com"),
 			Token:           token,
+			Backend:         getEnv("GITLAB_BACKEND", "auto"),
 			ProjectID:       projectID,
 			GroupID:         groupID,
 			GroupPath:       getEnv("GITLAB_GROUP_PATH", ""),
@@ -77,6 +92,10 @@ func Load() (*Config, error) {
 			RefreshInterval:        refreshInterval,
 			MaxPipelinesPerProject: maxPipelinesPerProject,
 		},
+		Log: LogConfig{
+			ErrorSets:    errorSets,
+			ErrorPattern: getEnv("LOG_ERROR_PATTERN", ""),
+		},
 	}, nil
 }
 
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// JobVariable is a CI/CD variable passed to a manual job when it is played
+type JobVariable struct {
+	Key   string
+	Value string
+}
+
+// ParseJobVariables parses KEY=value pairs separated by spaces or commas,
+// e.g. "DEPLOY_ENV=staging DRY_RUN=true". Values with spaces or commas are
+// quoted: MESSAGE="hello, world" or MESSAGE='hello, world'.
+func ParseJobVariables(s string) ([]JobVariable, error) {
+	fields, err := splitJobVariables(s)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := make([]JobVariable, 0, len(fields))
+	for _, field := range fields {
+		variable, err := ParseJobVariable(field)
+		if err != nil {
+			return nil, err
+		}
+		variables = append(variables, variable)
+	}
+	return variables, nil
+}
+
+// ParseJobVariable parses a single KEY=value pair, such as one command line
+// argument. The value is taken as is and may contain spaces, commas and
+// further '=' signs.
+func ParseJobVariable(s string) (JobVariable, error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || strings.TrimSpace(key) == "" {
+		return JobVariable{}, fmt.Errorf("invalid variable %q, expected KEY=value", s)
+	}
+	return JobVariable{Key: strings.TrimSpace(key), Value: value}, nil
+}
+
+// splitJobVariables splits s at spaces and commas outside quotes, dropping
+// the quotes
+func splitJobVariables(s string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	var quote rune
+	inField := false
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			field.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inField = r, true
+		case r == ' ' || r == ',':
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		default:
+			field.WriteRune(r)
+			inField = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in %q", quote, s)
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
+
+// IsActive reports whether a pipeline or job with this status can still be
+// canceled
+func IsActive(status string) bool {
+	switch status {
+	case "created", "waiting_for_resource", "preparing", "pending", "running", "scheduled":
+		return true
+	}
+	return false
+}
+
+// CanRetryPipeline reports whether retrying a pipeline with this status
+// would rerun anything: GitLab only retries failed and canceled jobs
+func CanRetryPipeline(status string) bool {
+	return status == "failed" || status == "canceled"
+}
+
+// CanRetryJob reports whether a job with this status can be retried
+func CanRetryJob(status string) bool {
+	return status == "failed" || status == "canceled" || status == "success"
+}
+
+// BulkResult is the outcome of a bulk action on one pipeline or job
+type BulkResult struct {
+	ID     int
+	NewID  int    // What replaces the item, e.g. the new job of a retry; 0 if nothing
+	Status string // Status after the action, "" if unknown
+	Err    error
+}
+
+// Bulk applies an action to every ID with bounded concurrency. The action
+// returns the status and new ID of the item, Bulk fills in ID and Err.
+// Results are in the order of ids; one failure doesn't stop the others.
+func Bulk(ctx context.Context, ids []int, fn func(ctx context.Context, id int) (BulkResult, error)) []BulkResult {
+	results := make([]BulkResult, len(ids))
+	for i, id := range ids {
+		// Items skipped because ctx was cancelled keep this error
+		results[i] = BulkResult{ID: id, Err: context.Canceled}
+	}
+	parallel(ctx, len(ids), DefaultWorkers, func(i int) {
+		result, err := fn(ctx, ids[i])
+		result.ID, result.Err = ids[i], err
+		results[i] = result
+	})
+	return results
+}
+
+// RetryPipeline reruns the failed and canceled jobs of a pipeline
+func (s *Service) RetryPipeline(ctx context.Context, pipelineID int) (*Pipeline, error) {
+	return s.gitlab.RetryPipeline(ctx, s.project, pipelineID)
+}
+
+// CancelPipeline cancels the running and pending jobs of a pipeline
+func (s *Service) CancelPipeline(ctx context.Context, pipelineID int) (*Pipeline, error) {
+	return s.gitlab.CancelPipeline(ctx, s.project, pipelineID)
+}
+
+// DeletePipeline deletes a pipeline with its jobs and logs
+func (s *Service) DeletePipeline(ctx context.Context, pipelineID int) error {
+	return s.gitlab.DeletePipeline(ctx, s.project, pipelineID)
+}
+
+// RetryJob reruns a job. GitLab creates a new job, which is returned.
+func (s *Service) RetryJob(ctx context.Context, jobID int) (*Job, error) {
+	return s.gitlab.RetryJob(ctx, s.project, jobID)
+}
+
+// CancelJob cancels a running or pending job
+func (s *Service) CancelJob(ctx context.Context, jobID int) (*Job, error) {
+	return s.gitlab.CancelJob(ctx, s.project, jobID)
+}
+
+// PlayJob starts a manual job with optional variables
+func (s *Service) PlayJob(ctx context.Context, jobID int, variables []JobVariable) (*Job, error) {
+	return s.gitlab.PlayJob(ctx, s.project, jobID, variables)
+}
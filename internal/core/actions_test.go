@@ -0,0 +1,36 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseJobVariables(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []JobVariable
+		wantErr bool
+	}{
+		{"empty", "", []JobVariable{}, false},
+		{"spaces and commas", "A=1, B=2 C=3", []JobVariable{{"A", "1"}, {"B", "2"}, {"C", "3"}}, false},
+		{"equals in value", "URL=a=b", []JobVariable{{"URL", "a=b"}}, false},
+		{"double quoted", `MSG="hello, world" A=1`, []JobVariable{{"MSG", "hello, world"}, {"A", "1"}}, false},
+		{"single quoted", `MSG='say "hi"'`, []JobVariable{{"MSG", `say "hi"`}}, false},
+		{"empty quoted value", `A="" B=2`, []JobVariable{{"A", ""}, {"B", "2"}}, false},
+		{"unquoted space in value", "MSG=hello world", nil, true},
+		{"unterminated quote", `MSG="hello`, nil, true},
+		{"missing key", "=1", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJobVariables(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseJobVariables(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package core
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxPreviewSize bounds how much of an artifact file is read for a preview
+const MaxPreviewSize = 1 << 20
+
+// MaxArtifactsSize bounds the archives JobArtifacts keeps in memory. Files
+// of bigger archives can still be downloaded one by one with
+// ExtractJobArtifactFile.
+const MaxArtifactsSize = 100 << 20
+
+// ErrArtifactsTooLarge is returned for archives over MaxArtifactsSize
+var ErrArtifactsTooLarge = errors.New("artifacts archive too large")
+
+// Artifacts is the downloaded artifacts archive of a job. The zip is kept in
+// memory and only the entries asked for are decompressed.
+type Artifacts struct {
+	JobID   int
+	archive []byte
+	reader  *zip.Reader
+}
+
+// ArtifactFile is a file inside an artifacts archive
+type ArtifactFile struct {
+	Path     string
+	Size     int64 // Uncompressed size
+	Modified time.Time
+}
+
+// JobArtifacts downloads the artifacts archive of a job, failing with
+// ErrArtifactsTooLarge for archives over MaxArtifactsSize
+func (s *Service) JobArtifacts(ctx context.Context, jobID int) (*Artifacts, error) {
+	archive := &cappedBuffer{max: MaxArtifactsSize}
+	err := s.gitlab.GetJobArtifacts(ctx, s.project, jobID, archive)
+	// Backends may report the cut-off download as a failure of their own
+	if archive.full {
+		return nil, fmt.Errorf("artifacts of job %d are over %s: %w", jobID, FormatSize(MaxArtifactsSize), ErrArtifactsTooLarge)
+	}
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("job %d has no artifacts: %w", jobID, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifacts of job %d: %w", jobID, err)
+	}
+	return NewArtifacts(jobID, archive.Bytes())
+}
+
+// ExtractJobArtifactFile downloads a single file of a job's artifacts below
+// dir, keeping its path inside the archive, without fetching the archive.
+// It returns where the file was written.
+func (s *Service) ExtractJobArtifactFile(ctx context.Context, jobID int, dir, name string) (string, error) {
+	name = strings.Trim(name, "/")
+	dest, err := artifactDest(dir, name)
+	if err != nil {
+		return "", err
+	}
+	var fetchErr error
+	err = writeArtifact(dest, name, func(w io.Writer) error {
+		fetchErr = s.gitlab.GetJobArtifactFile(ctx, s.project, jobID, name, w)
+		return fetchErr
+	})
+	if errors.Is(fetchErr, ErrNotFound) {
+		return "", fmt.Errorf("%s is not in the artifacts of job %d: %w", name, jobID, fetchErr)
+	}
+	if err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// cappedBuffer collects a download, refusing writes past max
+type cappedBuffer struct {
+	bytes.Buffer
+	max  int
+	full bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.max {
+		b.full = true
+		return 0, ErrArtifactsTooLarge
+	}
+	return b.Buffer.Write(p)
+}
+
+// NewArtifacts opens an artifacts archive
+func NewArtifacts(jobID int, archive []byte) (*Artifacts, error) {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("artifacts of job %d are not a zip archive: %w", jobID, err)
+	}
+	return &Artifacts{JobID: jobID, archive: archive, reader: reader}, nil
+}
+
+// Size returns the size of the compressed archive
+func (a *Artifacts) Size() int64 {
+	return int64(len(a.archive))
+}
+
+// Files lists the files of the archive sorted by path, without directories
+func (a *Artifacts) Files() []ArtifactFile {
+	var files []ArtifactFile
+	for _, f := range a.reader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		files = append(files, ArtifactFile{Path: f.Name, Size: int64(f.UncompressedSize64), Modified: f.Modified})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// Preview reads the start of a file, up to MaxPreviewSize. It reports
+// whether the file looks like text and whether it was cut off.
+func (a *Artifacts) Preview(name string) (content []byte, text, truncated bool, err error) {
+	f, err := a.open(name)
+	if err != nil {
+		return nil, false, false, err
+	}
+	defer f.Close()
+
+	content, err = io.ReadAll(io.LimitReader(f, MaxPreviewSize+1))
+	if err != nil {
+		return nil, false, false, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if len(content) > MaxPreviewSize {
+		content, truncated = content[:MaxPreviewSize], true
+	}
+	return content, isText(content, truncated), truncated, nil
+}
+
+// Extract writes the named files below dir, keeping their paths inside the
+// archive, and returns where they were written
+func (a *Artifacts) Extract(dir string, names ...string) ([]string, error) {
+	var written []string
+	for _, name := range names {
+		dest, err := a.extract(dir, name)
+		if err != nil {
+			return written, err
+		}
+		written = append(written, dest)
+	}
+	return written, nil
+}
+
+// ExtractPrefix writes the file named prefix, or every file below the
+// directory prefix, below dir. An empty prefix extracts everything.
+func (a *Artifacts) ExtractPrefix(dir, prefix string) ([]string, error) {
+	prefix = strings.Trim(prefix, "/")
+
+	var names []string
+	for _, f := range a.Files() {
+		if prefix == "" || f.Path == prefix || strings.HasPrefix(f.Path, prefix+"/") {
+			names = append(names, f.Path)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no artifact matches %q", prefix)
+	}
+	return a.Extract(dir, names...)
+}
+
+// Save writes the whole archive to a file
+func (a *Artifacts) Save(file string) error {
+	if err := os.WriteFile(file, a.archive, 0o644); err != nil {
+		return fmt.Errorf("failed to save artifacts of job %d: %w", a.JobID, err)
+	}
+	return nil
+}
+
+func (a *Artifacts) open(name string) (io.ReadCloser, error) {
+	for _, f := range a.reader.File {
+		if f.Name == name {
+			return f.Open()
+		}
+	}
+	return nil, fmt.Errorf("%s is not in the artifacts of job %d", name, a.JobID)
+}
+
+func (a *Artifacts) extract(dir, name string) (string, error) {
+	dest, err := artifactDest(dir, name)
+	if err != nil {
+		return "", err
+	}
+
+	src, err := a.open(name)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	err = writeArtifact(dest, name, func(w io.Writer) error {
+		_, err := io.Copy(w, src)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// artifactDest is where an artifact file goes below dir. Archive paths are
+// untrusted, so they are kept from escaping dir.
+func artifactDest(dir, name string) (string, error) {
+	clean := path.Clean("/" + name)[1:]
+	if clean == "" {
+		return "", fmt.Errorf("invalid artifact path %q", name)
+	}
+	return filepath.Join(dir, filepath.FromSlash(clean)), nil
+}
+
+// writeArtifact creates dest with what write writes to it, removing it again
+// if that fails
+func writeArtifact(dest, name string, write func(w io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("failed to extract %s: %w", name, err)
+	}
+	out, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", name, err)
+	}
+	err = write(out)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(dest)
+		return fmt.Errorf("failed to extract %s: %w", name, err)
+	}
+	return nil
+}
+
+// isText guesses whether content is text: valid UTF-8 without NUL bytes. A
+// cut-off preview may end inside a multi-byte rune, which is ignored.
+func isText(content []byte, truncated bool) bool {
+	if bytes.IndexByte(content, 0) >= 0 {
+		return false
+	}
+	if truncated {
+		for i := 0; i < utf8.UTFMax && len(content) > 0 && !utf8.Valid(content); i++ {
+			content = content[:len(content)-1]
+		}
+	}
+	return utf8.Valid(content)
+}
+
+// FormatSize renders a byte count for humans, e.g. "1.5 MB"
+func FormatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// zipArchive builds an artifacts archive from file names and contents
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(fw, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestArtifactsExtract_StaysInDir(t *testing.T) {
+	tests := []struct {
+		name string
+		want string // Relative to the extraction dir
+	}{
+		{"report.txt", "report.txt"},
+		{"coverage/index.html", "coverage/index.html"},
+		{"../escape.txt", "escape.txt"},
+		{"../../a/escape.txt", "a/escape.txt"},
+		{"/etc/passwd", "etc/passwd"},
+		{"a/../../b.txt", "b.txt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifacts, err := NewArtifacts(1, zipArchive(t, map[string]string{tt.name: "x"}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			dir := filepath.Join(t.TempDir(), "out")
+
+			written, err := artifacts.Extract(dir, tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := filepath.Join(dir, filepath.FromSlash(tt.want))
+			if !reflect.DeepEqual(written, []string{want}) {
+				t.Errorf("written = %v, want %v", written, []string{want})
+			}
+			if content, err := os.ReadFile(want); err != nil || string(content) != "x" {
+				t.Errorf("%s = %q, %v; want \"x\"", want, content, err)
+			}
+		})
+	}
+}
+
+func TestArtifactsPreview(t *testing.T) {
+	artifacts, err := NewArtifacts(1, zipArchive(t, map[string]string{
+		"log.txt":  "hello\n",
+		"logo.png": "\x89PNG\r\n\x1a\n\x00\x00",
+		"big.txt":  strings.Repeat("a", MaxPreviewSize) + "€",
+		"cut.txt":  strings.Repeat("a", MaxPreviewSize-1) + "€",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		wantLen       int
+		wantText      bool
+		wantTruncated bool
+		wantErr       bool
+	}{
+		{"log.txt", 6, true, false, false},
+		{"logo.png", 10, false, false, false},
+		{"big.txt", MaxPreviewSize, true, true, false},
+		// The cut falls inside the last rune, which doesn't make it binary
+		{"cut.txt", MaxPreviewSize, true, true, false},
+		{"missing.txt", 0, false, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, text, truncated, err := artifacts.Preview(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if len(content) != tt.wantLen || text != tt.wantText || truncated != tt.wantTruncated {
+				t.Errorf("Preview = %d bytes, text %v, truncated %v; want %d bytes, text %v, truncated %v",
+					len(content), text, truncated, tt.wantLen, tt.wantText, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+// artifactClient serves an artifacts archive of a given size, and single
+// files from a map
+type artifactClient struct {
+	MockClient
+	size  int
+	files map[string]string
+}
+
+func (c artifactClient) GetJobArtifacts(ctx context.Context, project string, jobID int, w io.Writer) error {
+	chunk := make([]byte, 64<<10)
+	for left := c.size; left > 0; left -= len(chunk) {
+		if _, err := w.Write(chunk[:min(len(chunk), left)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c artifactClient) GetJobArtifactFile(ctx context.Context, project string, jobID int, path string, w io.Writer) error {
+	content, ok := c.files[path]
+	if !ok {
+		return NewAPIError("get artifact", http.StatusNotFound, "404 Not Found")
+	}
+	_, err := io.WriteString(w, content)
+	return err
+}
+
+func TestJobArtifacts_TooLarge(t *testing.T) {
+	s := NewService(nil, artifactClient{size: MaxArtifactsSize + 1}, "group/app")
+
+	_, err := s.JobArtifacts(context.Background(), 1)
+	if !errors.Is(err, ErrArtifactsTooLarge) {
+		t.Errorf("err = %v, want %v", err, ErrArtifactsTooLarge)
+	}
+}
+
+func TestExtractJobArtifactFile(t *testing.T) {
+	s := NewService(nil, artifactClient{files: map[string]string{"coverage/summary.txt": "87%"}}, "group/app")
+	dir := t.TempDir()
+
+	file, err := s.ExtractJobArtifactFile(context.Background(), 1, dir, "/coverage/summary.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "coverage", "summary.txt"); file != want {
+		t.Errorf("file = %s, want %s", file, want)
+	}
+	if content, err := os.ReadFile(file); err != nil || string(content) != "87%" {
+		t.Errorf("content = %q, %v; want \"87%%\"", content, err)
+	}
+
+	_, err = s.ExtractJobArtifactFile(context.Background(), 1, dir, "coverage/missing.txt")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want %v", err, ErrNotFound)
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, "coverage", "missing.txt")); !os.IsNotExist(statErr) {
+		t.Error("missing file left an empty file behind")
+	}
+}
@@ -0,0 +1,138 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// GitLabClient is the transport-agnostic GitLab backend used by Service.
+// It is implemented by the native REST client (internal/api), the go-gitlab
+// client and the glab CLI wrapper (internal/gitlab).
+//
+// Projects are addressed by their full path ("group/sub/project") or by
+// their numeric ID in string form, see ProjectRef.
+//
+// Artifact downloads are streamed to w rather than held in memory; w may
+// have received part of the body when they fail.
+type GitLabClient interface {
+	GetProject(ctx context.Context, project string) (*Project, error)
+	ListGroupProjects(ctx context.Context, group string, opts ProjectListOptions) ([]Project, error)
+	ListPipelines(ctx context.Context, project string, opts PipelineListOptions) ([]Pipeline, error)
+	GetPipeline(ctx context.Context, project string, pipelineID int) (*Pipeline, error)
+	ListPipelineJobs(ctx context.Context, project string, pipelineID int, opts JobListOptions) ([]Job, error)
+	ListPipelineBridges(ctx context.Context, project string, pipelineID int, opts JobListOptions) ([]Bridge, error)
+	ListBranches(ctx context.Context, project string, opts BranchListOptions) ([]Branch, error)
+	ListMergeRequests(ctx context.Context, project string, opts MergeRequestListOptions) ([]MergeRequest, error)
+	ListMergeRequestPipelines(ctx context.Context, project string, iid int, opts PipelineListOptions) ([]Pipeline, error)
+	GetMergeRequestApprovals(ctx context.Context, project string, iid int) (*MergeRequestApproval, error)
+	GetJob(ctx context.Context, project string, jobID int) (*Job, error)
+	GetJobTrace(ctx context.Context, project string, jobID int) (string, error)
+	GetJobArtifacts(ctx context.Context, project string, jobID int, w io.Writer) error
+	GetJobArtifactFile(ctx context.Context, project string, jobID int, path string, w io.Writer) error
+	GetPipelineTestReport(ctx context.Context, project string, pipelineID int) (*TestReport, error)
+	GetPipelineTestReportSummary(ctx context.Context, project string, pipelineID int) (*TestReport, error)
+
+	CreatePipeline(ctx context.Context, project, ref string, variables []JobVariable) (*Pipeline, error)
+	RetryPipeline(ctx context.Context, project string, pipelineID int) (*Pipeline, error)
+	CancelPipeline(ctx context.Context, project string, pipelineID int) (*Pipeline, error)
+	DeletePipeline(ctx context.Context, project string, pipelineID int) error
+	RetryJob(ctx context.Context, project string, jobID int) (*Job, error)
+	CancelJob(ctx context.Context, project string, jobID int) (*Job, error)
+	PlayJob(ctx context.Context, project string, jobID int, variables []JobVariable) (*Job, error)
+}
+
+// RateLimitReporter is implemented by backends that can see GitLab's
+// rate-limit headers. It is optional; Service checks for it at runtime.
+type RateLimitReporter interface {
+	// RateLimit returns the last observed budget, false if none was seen yet
+	RateLimit() (RateLimit, bool)
+}
+
+// JobNeedsReader is implemented by backends that can read the needs: (DAG)
+// relations between the jobs of a pipeline, which the REST API doesn't
+// expose. It is optional; Service reports ErrUnsupported without it.
+type JobNeedsReader interface {
+	// ListJobNeeds maps each job name to the names of the jobs it needs
+	ListJobNeeds(ctx context.Context, project string, pipelineID int) (map[string][]string, error)
+}
+
+// RateLimit is the API request budget of the current rate-limit window
+type RateLimit struct {
+	Limit     int       // Requests allowed per window
+	Remaining int       // Requests left in the current window
+	Reset     time.Time // When the window resets, zero if unknown
+}
+
+// Used returns the number of requests made in the current window
+func (r RateLimit) Used() int {
+	return r.Limit - r.Remaining
+}
+
+// String formats the budget for a status bar, e.g. "15/1000"
+func (r RateLimit) String() string {
+	return fmt.Sprintf("%d/%d", r.Used(), r.Limit)
+}
+
+// ProjectListOptions filters a group's project listing
+type ProjectListOptions struct {
+	IncludeSubgroups bool // Also list projects of subgroups
+	Archived         bool // Include archived projects
+	PerPage          int  // Page size, 0 uses the backend default
+	Limit            int  // Stop after this many projects, 0 fetches every page
+
+	// Stop at the first project without activity since, zero lists them
+	// all. Projects are listed most recently active first, so the ones
+	// after it are older too.
+	ActiveSince time.Time
+}
+
+// Inactive reports whether a project is past the ActiveSince cutoff, where
+// listing can stop
+func (o ProjectListOptions) Inactive(p Project) bool {
+	return !activeSince(p, o.ActiveSince)
+}
+
+// PipelineListOptions filters a pipeline listing
+type PipelineListOptions struct {
+	Ref     string // Only pipelines for this branch or tag
+	Status  string // Only pipelines with this status
+	PerPage int    // Page size, 0 uses the backend default
+	Limit   int    // Stop after this many pipelines, 0 fetches every page
+}
+
+// JobListOptions controls a job listing
+type JobListOptions struct {
+	IncludeRetried bool // Also list the earlier attempts of retried jobs
+	PerPage        int  // Page size, 0 uses the backend default
+	Limit          int  // Stop after this many jobs, 0 fetches every page
+}
+
+// BranchListOptions filters a branch listing
+type BranchListOptions struct {
+	Search  string // Only branches whose name contains this
+	PerPage int    // Page size, 0 uses the backend default
+	Limit   int    // Stop after this many branches, 0 fetches every page
+}
+
+// DefaultPerPage is the page size used when a list call doesn't set one.
+// It is the maximum GitLab allows, to keep the number of requests down.
+const DefaultPerPage = 100
+
+// PageSize picks the page size for a bounded listing
+func PageSize(perPage, limit int) int {
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+	if limit > 0 && limit < perPage {
+		return limit
+	}
+	return perPage
+}
+
+// ProjectRef turns a numeric project ID into a project reference
+func ProjectRef(projectID int) string {
+	return strconv.Itoa(projectID)
+}
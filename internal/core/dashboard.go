@@ -0,0 +1,231 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rkristelijn/glab-tui/internal/config"
+)
+
+// ErrNoGroup is returned when the dashboard has no projects to look at
+var ErrNoGroup = errors.New("no group or projects configured, set GITLAB_GROUP_PATH, GITLAB_GROUP_ID or GITLAB_PROJECT_IDS")
+
+// DashboardRow is the latest pipeline of one project and ref
+type DashboardRow struct {
+	Project  Project
+	Ref      string
+	Pipeline *Pipeline // nil if the project has no pipelines
+	Err      error     // Set when the project's pipelines couldn't be loaded
+}
+
+// ProjectFilter selects the projects shown on the dashboard
+type ProjectFilter struct {
+	Patterns     []string  // Globs on the project path or name, one must match
+	ShowArchived bool      // Keep archived projects
+	ActiveSince  time.Time // Drop projects without activity since, zero keeps all
+	MaxProjects  int       // Keep at most this many projects, 0 keeps all
+}
+
+// NewProjectFilter builds the filter described by the GitLab configuration
+func NewProjectFilter(cfg config.GitLabConfig) ProjectFilter {
+	filter := ProjectFilter{
+		ShowArchived: cfg.ShowArchived,
+		MaxProjects:  cfg.MaxProjects,
+	}
+	for _, pattern := range strings.Split(cfg.ProjectPattern, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			filter.Patterns = append(filter.Patterns, pattern)
+		}
+	}
+	if cfg.MinActivityDays > 0 {
+		filter.ActiveSince = time.Now().AddDate(0, 0, -cfg.MinActivityDays)
+	}
+	return filter
+}
+
+// Match reports whether a project passes the filter
+func (f ProjectFilter) Match(p Project) bool {
+	if p.Archived && !f.ShowArchived {
+		return false
+	}
+
+	if !activeSince(p, f.ActiveSince) {
+		return false
+	}
+
+	if len(f.Patterns) == 0 {
+		return true
+	}
+	for _, pattern := range f.Patterns {
+		if matched, _ := path.Match(pattern, p.PathWithNamespace); matched {
+			return true
+		}
+		if matched, _ := path.Match(pattern, p.Name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// activeSince reports whether a project had activity since a time. Projects
+// without a known last activity count as active, as does any project when
+// since is zero.
+func activeSince(p Project, since time.Time) bool {
+	if since.IsZero() || p.LastActivityAt == "" {
+		return true
+	}
+	lastActivity, err := time.Parse(time.RFC3339, p.LastActivityAt)
+	return err != nil || !lastActivity.Before(since)
+}
+
+// DashboardProjects lists the projects to watch: GITLAB_PROJECT_IDS if set,
+// otherwise the configured group including its subgroups, filtered by
+// pattern, archive state and recent activity
+func (s *Service) DashboardProjects(ctx context.Context) ([]Project, error) {
+	if s.config == nil {
+		return nil, ErrNoGroup
+	}
+	cfg := s.config.GitLab
+	filter := NewProjectFilter(cfg)
+
+	var projects []Project
+	switch {
+	case len(cfg.ProjectIDs) > 0:
+		found := make([]*Project, len(cfg.ProjectIDs))
+		errs := make([]error, len(cfg.ProjectIDs))
+		for i := range errs {
+			// Projects skipped because ctx was cancelled keep this error
+			errs[i] = context.Canceled
+		}
+		parallel(ctx, len(cfg.ProjectIDs), DefaultWorkers, func(i int) {
+			found[i], errs[i] = s.gitlab.GetProject(ctx, ProjectRef(cfg.ProjectIDs[i]))
+		})
+		for i, p := range found {
+			if errs[i] != nil {
+				return nil, fmt.Errorf("failed to get project %d: %w", cfg.ProjectIDs[i], errs[i])
+			}
+			projects = append(projects, *p)
+		}
+
+	case cfg.GroupPath != "" || cfg.GroupID != 0:
+		group := cfg.GroupPath
+		if group == "" {
+			group = ProjectRef(cfg.GroupID)
+		}
+
+		// Projects come most recently active first, so paging stops at the
+		// first one that is too old
+		opts := ProjectListOptions{IncludeSubgroups: true, Archived: cfg.ShowArchived, ActiveSince: filter.ActiveSince}
+		if len(filter.Patterns) == 0 {
+			// Nothing else is filtered out locally, so paging can stop early
+			opts.Limit = filter.MaxProjects
+		}
+
+		var err error
+		projects, err = s.gitlab.ListGroupProjects(ctx, group, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects of group %s: %w", group, err)
+		}
+
+	default:
+		return nil, ErrNoGroup
+	}
+
+	var result []Project
+	for _, p := range projects {
+		if !filter.Match(p) {
+			continue
+		}
+		result = append(result, p)
+		if filter.MaxProjects > 0 && len(result) == filter.MaxProjects {
+			break
+		}
+	}
+	return result, nil
+}
+
+// Dashboard loads the latest pipeline of every ref with recent activity in
+// every dashboard project. Projects are fetched concurrently; a project
+// that fails gets a row with Err set instead of failing the dashboard.
+func (s *Service) Dashboard(ctx context.Context) ([]DashboardRow, error) {
+	projects, err := s.DashboardProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := 0
+	if s.config != nil {
+		limit = s.config.UI.MaxPipelinesPerProject
+	}
+
+	perProject := make([][]DashboardRow, len(projects))
+	parallel(ctx, len(projects), DefaultWorkers, func(i int) {
+		perProject[i] = s.projectRows(ctx, projects[i], limit)
+	})
+
+	var rows []DashboardRow
+	for _, projectRows := range perProject {
+		rows = append(rows, projectRows...)
+	}
+	SortDashboard(rows)
+	return rows, nil
+}
+
+// projectRows keeps the most recent pipeline per ref of a project
+func (s *Service) projectRows(ctx context.Context, project Project, limit int) []DashboardRow {
+	ref := ProjectRef(project.ID)
+	pipelines, err := s.gitlab.ListPipelines(ctx, ref, PipelineListOptions{Limit: limit})
+	if err != nil {
+		return []DashboardRow{{Project: project, Err: err}}
+	}
+	if len(pipelines) == 0 {
+		return []DashboardRow{{Project: project, Ref: project.DefaultBranch}}
+	}
+
+	var rows []DashboardRow
+	seen := make(map[string]bool)
+	for i := range pipelines {
+		pipeline := pipelines[i]
+		if seen[pipeline.Ref] {
+			continue
+		}
+		seen[pipeline.Ref] = true
+
+		pipeline.ProjectName = project.Name
+		rows = append(rows, DashboardRow{Project: project, Ref: pipeline.Ref, Pipeline: &pipeline})
+	}
+	return rows
+}
+
+// SortDashboard puts rows needing attention first (failed, then running),
+// most recently updated first within the same status
+func SortDashboard(rows []DashboardRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		ri, rj := rows[i].Status(), rows[j].Status()
+		if statusRank(ri) != statusRank(rj) {
+			return statusRank(ri) > statusRank(rj)
+		}
+		return rows[i].UpdatedAt().After(rows[j].UpdatedAt())
+	})
+}
+
+// Status returns the pipeline status of the row, "" if it has none
+func (r DashboardRow) Status() string {
+	if r.Pipeline == nil {
+		return ""
+	}
+	return r.Pipeline.Status
+}
+
+// UpdatedAt returns when the row's pipeline last changed
+func (r DashboardRow) UpdatedAt() time.Time {
+	if r.Pipeline == nil {
+		return time.Time{}
+	}
+	return r.Pipeline.UpdatedAt
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rkristelijn/glab-tui/internal/config"
+)
+
+// projectClient serves any project by ID, failing like a real backend once
+// the context is cancelled
+type projectClient struct {
+	MockClient
+}
+
+func (c projectClient) GetProject(ctx context.Context, project string) (*Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &Project{PathWithNamespace: "group/" + project}, nil
+}
+
+func TestDashboardProjects_ProjectIDs(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantPaths []string
+		wantErr   error
+	}{
+		{"all found in order", context.Background(), []string{"group/1", "group/2", "group/3"}, nil},
+		{"cancelled", cancelled, nil, context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{GitLab: config.GitLabConfig{ProjectIDs: []int{1, 2, 3}}}
+			s := NewService(cfg, projectClient{}, "")
+
+			projects, err := s.DashboardProjects(tt.ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			var paths []string
+			for _, p := range projects {
+				paths = append(paths, p.PathWithNamespace)
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("projects = %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+}
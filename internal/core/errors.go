@@ -0,0 +1,79 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors shared by every backend. Use errors.Is to check for them;
+// backends return *APIError values that match the sentinel for their status.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrUnavailable  = errors.New("service unavailable")
+	ErrUnsupported  = errors.New("not supported by this backend")
+)
+
+// APIError describes a failed GitLab request independent of the transport
+// (REST, go-gitlab or the glab CLI) that produced it
+type APIError struct {
+	Op         string // What we were doing, e.g. "get job 123"
+	StatusCode int    // HTTP status code, 0 if the request never completed
+	Message    string // Message returned by GitLab, if any
+	Err        error  // Underlying transport error, if any
+}
+
+// NewAPIError creates an error for a request that completed with a non-success status
+func NewAPIError(op string, statusCode int, message string) *APIError {
+	return &APIError{Op: op, StatusCode: statusCode, Message: message}
+}
+
+// WrapError wraps a transport error, keeping it unwrappable
+func WrapError(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return err
+	}
+	return &APIError{Op: op, Err: err}
+}
+
+func (e *APIError) Error() string {
+	msg := e.Op
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(": %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is maps the HTTP status code onto the sentinel errors above
+func (e *APIError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return target == ErrUnavailable
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"fmt"
+)
+
+// PipelineRun is a finished pipeline with every job attempt and, when read,
+// its test report
+type PipelineRun struct {
+	Pipeline Pipeline
+	Jobs     []Job       // Retried jobs appear once per attempt
+	Tests    *TestReport // nil if not read or the pipeline has none
+}
+
+// HistoryOptions selects the pipelines PipelineHistory reads
+type HistoryOptions struct {
+	Ref   string // Branch or tag, empty for all refs
+	Last  int    // How many of the newest pipelines to read
+	Tests bool   // Also read test reports, see PipelineHistory
+	Times bool   // Also read each pipeline for its duration and queued time
+}
+
+// PipelineHistory reads the last pipelines of a ref, newest first, with
+// every job attempt. Pipelines that are still active are left out, their
+// outcome isn't known yet. With opts.Tests the test reports are read for
+// pipelines that share their commit with another one, the only results
+// that can be compared.
+//
+// Pipeline listings have no durations, with opts.Times each pipeline is read
+// on its own to get them.
+//
+// A pipeline whose jobs can't be read is left out; it only fails if the
+// pipelines can't be listed or none of them could be read.
+func (s *Service) PipelineHistory(ctx context.Context, opts HistoryOptions) ([]PipelineRun, error) {
+	pipelines, err := s.ListRecentPipelines(ctx, PipelineListOptions{Ref: opts.Ref, Limit: opts.Last})
+	if err != nil {
+		return nil, err
+	}
+
+	var finished []Pipeline
+	for _, p := range pipelines {
+		if !IsActive(p.Status) {
+			finished = append(finished, p)
+		}
+	}
+
+	runs := make([]PipelineRun, len(finished))
+	errs := make([]error, len(finished))
+	parallel(ctx, len(finished), DefaultWorkers, func(i int) {
+		runs[i].Pipeline = finished[i]
+		runs[i].Jobs, errs[i] = s.gitlab.ListPipelineJobs(ctx, s.project, finished[i].ID, JobListOptions{IncludeRetried: true})
+		if opts.Times && errs[i] == nil {
+			if p, err := s.gitlab.GetPipeline(ctx, s.project, finished[i].ID); err == nil {
+				runs[i].Pipeline = *p
+			}
+		}
+	})
+
+	var read []PipelineRun
+	var firstErr error
+	for i, run := range runs {
+		switch {
+		case errs[i] != nil:
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to list jobs for pipeline %d: %w", finished[i].ID, errs[i])
+			}
+		case run.Pipeline.ID != 0: // Zero if ctx was cancelled before it was read
+			read = append(read, run)
+		}
+	}
+	if len(read) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+
+	if opts.Tests {
+		commits := make(map[string]int)
+		for _, run := range read {
+			commits[run.Pipeline.SHA]++
+		}
+		parallel(ctx, len(read), DefaultWorkers, func(i int) {
+			if sha := read[i].Pipeline.SHA; sha != "" && commits[sha] > 1 {
+				read[i].Tests, _ = s.gitlab.GetPipelineTestReport(ctx, s.project, read[i].Pipeline.ID)
+			}
+		})
+	}
+	return read, ctx.Err()
+}
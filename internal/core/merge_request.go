@@ -0,0 +1,116 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxMergeRequests bounds the merge requests listed per project
+const maxMergeRequests = 50
+
+// MergeRequest represents a GitLab merge request
+type MergeRequest struct {
+	ID           int       `json:"id"`
+	IID          int       `json:"iid"`
+	ProjectID    int       `json:"project_id"`
+	Title        string    `json:"title"`
+	Author       string    `json:"author"` // Username
+	SourceBranch string    `json:"source_branch"`
+	TargetBranch string    `json:"target_branch"`
+	State        string    `json:"state"`
+	Draft        bool      `json:"draft"`
+	WebURL       string    `json:"web_url"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	HeadPipeline *Pipeline             `json:"-"` // Latest pipeline, nil if none or not loaded
+	Approvals    *MergeRequestApproval `json:"-"` // nil if the approval state couldn't be read
+}
+
+// MergeRequestApproval is the approval state of a merge request
+type MergeRequestApproval struct {
+	Required int      // Approvals the rules require
+	Left     int      // Approvals still missing
+	By       []string // Usernames of the approvers so far
+}
+
+// Approved reports whether the approval rules are satisfied
+func (a MergeRequestApproval) Approved() bool {
+	return a.Left <= 0
+}
+
+// String formats the approvals for a list, e.g. "1/2"
+func (a MergeRequestApproval) String() string {
+	if a.Required == 0 {
+		return fmt.Sprintf("%d", len(a.By))
+	}
+	return fmt.Sprintf("%d/%d", a.Required-a.Left, a.Required)
+}
+
+// MergeRequestListOptions filters a merge request listing
+type MergeRequestListOptions struct {
+	State   string // opened, closed, merged or all; empty means opened
+	PerPage int    // Page size, 0 uses the backend default
+	Limit   int    // Stop after this many merge requests, 0 fetches every page
+}
+
+// MergeRequests returns the project's open merge requests, most recently
+// updated first, with their head pipeline and approvals. Those are fetched
+// concurrently per merge request; failures leave them unset instead of
+// failing the list.
+func (s *Service) MergeRequests(ctx context.Context) ([]MergeRequest, error) {
+	return s.RefreshMergeRequests(ctx, nil)
+}
+
+// RefreshMergeRequests is MergeRequests for polling: merge requests that
+// are in known, not updated since and without a running head pipeline keep
+// their head pipeline and approvals from there instead of fetching them
+// again, so a poll mostly costs one request for the list.
+func (s *Service) RefreshMergeRequests(ctx context.Context, known []MergeRequest) ([]MergeRequest, error) {
+	mrs, err := s.gitlab.ListMergeRequests(ctx, s.project, MergeRequestListOptions{State: "opened", Limit: maxMergeRequests})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge requests for %s: %w", s.project, err)
+	}
+
+	previous := make(map[int]MergeRequest, len(known))
+	for _, mr := range known {
+		previous[mr.IID] = mr
+	}
+	var stale []int
+	for i := range mrs {
+		mr := &mrs[i]
+		if old, ok := previous[mr.IID]; ok && old.UpdatedAt.Equal(mr.UpdatedAt) &&
+			(old.HeadPipeline == nil || !IsActive(old.HeadPipeline.Status)) {
+			mr.HeadPipeline, mr.Approvals = old.HeadPipeline, old.Approvals
+			continue
+		}
+		stale = append(stale, i)
+	}
+
+	parallel(ctx, len(stale), DefaultWorkers, func(i int) {
+		mr := &mrs[stale[i]]
+		if pipelines, err := s.gitlab.ListMergeRequestPipelines(ctx, s.project, mr.IID, PipelineListOptions{Limit: 1}); err == nil && len(pipelines) > 0 {
+			pipelines[0].ProjectName = ProjectName(s.project)
+			mr.HeadPipeline = &pipelines[0]
+		}
+		if approvals, err := s.gitlab.GetMergeRequestApprovals(ctx, s.project, mr.IID); err == nil {
+			mr.Approvals = approvals
+		}
+	})
+	return mrs, nil
+}
+
+// MergeRequestPipelines returns the pipelines of a merge request, newest
+// first
+func (s *Service) MergeRequestPipelines(ctx context.Context, iid int) ([]Pipeline, error) {
+	pipelines, err := s.gitlab.ListMergeRequestPipelines(ctx, s.project, iid, PipelineListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipelines of merge request !%d: %w", iid, err)
+	}
+	for i := range pipelines {
+		if pipelines[i].ProjectName == "" {
+			pipelines[i].ProjectName = ProjectName(s.project)
+		}
+	}
+	return pipelines, nil
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingMRClient serves fixed merge requests and counts the requests for
+// their pipelines and approvals
+type countingMRClient struct {
+	MockClient
+
+	mu        sync.Mutex
+	mrs       []MergeRequest
+	status    string      // Status of every head pipeline
+	pipelines map[int]int // Requests per IID
+	approvals map[int]int // Requests per IID
+}
+
+func (c *countingMRClient) ListMergeRequests(ctx context.Context, project string, opts MergeRequestListOptions) ([]MergeRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]MergeRequest(nil), c.mrs...), nil
+}
+
+func (c *countingMRClient) ListMergeRequestPipelines(ctx context.Context, project string, iid int, opts PipelineListOptions) ([]Pipeline, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pipelines[iid]++
+	return []Pipeline{{ID: iid * 10, Status: c.status}}, nil
+}
+
+func (c *countingMRClient) GetMergeRequestApprovals(ctx context.Context, project string, iid int) (*MergeRequestApproval, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.approvals[iid]++
+	return &MergeRequestApproval{Required: 1, Left: 1}, nil
+}
+
+func TestRefreshMergeRequests(t *testing.T) {
+	updated := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		status  string
+		change  func(mrs []MergeRequest) []MergeRequest
+		fetched map[int]int // Detail requests per IID on the second load
+	}{
+		{
+			"nothing changed",
+			"success",
+			func(mrs []MergeRequest) []MergeRequest { return mrs },
+			map[int]int{},
+		},
+		{
+			"one updated",
+			"success",
+			func(mrs []MergeRequest) []MergeRequest {
+				mrs[1].UpdatedAt = mrs[1].UpdatedAt.Add(time.Minute)
+				return mrs
+			},
+			map[int]int{2: 1},
+		},
+		{
+			"new merge request",
+			"failed",
+			func(mrs []MergeRequest) []MergeRequest {
+				return append(mrs, MergeRequest{IID: 3, UpdatedAt: updated})
+			},
+			map[int]int{3: 1},
+		},
+		{
+			"running head pipelines",
+			"running",
+			func(mrs []MergeRequest) []MergeRequest { return mrs },
+			map[int]int{1: 1, 2: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &countingMRClient{
+				mrs:       []MergeRequest{{IID: 1, UpdatedAt: updated}, {IID: 2, UpdatedAt: updated}},
+				status:    tt.status,
+				pipelines: map[int]int{},
+				approvals: map[int]int{},
+			}
+			service := NewService(nil, client, "group/project")
+
+			known, err := service.MergeRequests(context.Background())
+			if err != nil {
+				t.Fatalf("MergeRequests() error = %v", err)
+			}
+			if len(client.pipelines) != 2 || len(client.approvals) != 2 {
+				t.Fatalf("first load fetched pipelines %v and approvals %v, want both merge requests", client.pipelines, client.approvals)
+			}
+
+			client.mrs = tt.change(append([]MergeRequest(nil), client.mrs...))
+			client.pipelines, client.approvals = map[int]int{}, map[int]int{}
+			mrs, err := service.RefreshMergeRequests(context.Background(), known)
+			if err != nil {
+				t.Fatalf("RefreshMergeRequests() error = %v", err)
+			}
+
+			if !equalCounts(client.pipelines, tt.fetched) || !equalCounts(client.approvals, tt.fetched) {
+				t.Errorf("fetched pipelines %v and approvals %v, want %v", client.pipelines, client.approvals, tt.fetched)
+			}
+			for _, mr := range mrs {
+				if mr.HeadPipeline == nil || mr.Approvals == nil {
+					t.Errorf("!%d lost its pipeline or approvals: %+v", mr.IID, mr)
+				}
+			}
+		})
+	}
+}
+
+func equalCounts(a, b map[int]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
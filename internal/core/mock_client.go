@@ -0,0 +1,561 @@
+package core
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MockClient is a GitLabClient backed by the mock data in this package.
+// It powers demo mode so the UI can be explored without a GitLab project.
+type MockClient struct{}
+
+// Ensure MockClient satisfies the backend interfaces
+var (
+	_ GitLabClient   = MockClient{}
+	_ JobNeedsReader = MockClient{}
+)
+
+// GetProject returns the mock project matching the reference
+func (MockClient) GetProject(ctx context.Context, project string) (*Project, error) {
+	for _, p := range GetMockProjects() {
+		if strconv.Itoa(p.ID) == project || p.NameWithNamespace == project || p.Name == project {
+			return &p, nil
+		}
+	}
+	return &Project{Name: ProjectName(project), NameWithNamespace: project, PathWithNamespace: project}, nil
+}
+
+// ListGroupProjects returns the mock projects
+func (MockClient) ListGroupProjects(ctx context.Context, group string, opts ProjectListOptions) ([]Project, error) {
+	var projects []Project
+	for _, p := range GetMockProjects() {
+		if p.Archived && !opts.Archived {
+			continue
+		}
+		if opts.Inactive(p) {
+			break
+		}
+		projects = append(projects, p)
+		if opts.Limit > 0 && len(projects) == opts.Limit {
+			break
+		}
+	}
+	return projects, nil
+}
+
+// ListPipelines returns the mock pipelines, only those of the project when
+// it is one of the mock projects
+func (c MockClient) ListPipelines(ctx context.Context, project string, opts PipelineListOptions) ([]Pipeline, error) {
+	projectID := 0
+	if p, err := c.GetProject(ctx, project); err == nil {
+		projectID = p.ID
+	}
+
+	var pipelines []Pipeline
+	for _, p := range GetMockPipelines() {
+		if projectID != 0 && p.ProjectID != projectID {
+			continue
+		}
+		if opts.Ref != "" && p.Ref != opts.Ref {
+			continue
+		}
+		if opts.Status != "" && p.Status != opts.Status {
+			continue
+		}
+		pipelines = append(pipelines, p)
+		if opts.Limit > 0 && len(pipelines) == opts.Limit {
+			break
+		}
+	}
+	return pipelines, nil
+}
+
+// GetPipeline returns a mock pipeline by ID
+func (MockClient) GetPipeline(ctx context.Context, project string, pipelineID int) (*Pipeline, error) {
+	for _, p := range GetMockPipelines() {
+		if p.ID == pipelineID {
+			return &p, nil
+		}
+	}
+	return nil, NewAPIError(fmt.Sprintf("get pipeline %d", pipelineID), http.StatusNotFound, "")
+}
+
+// ListPipelineJobs returns the mock jobs for any pipeline. Jobs of finished
+// mock pipelines have finished too, with durations that vary per pipeline.
+// The integration tests follow the outcome of the pipeline, and in
+// successful ones they only passed on retry, so the pipeline history looks
+// flaky.
+func (MockClient) ListPipelineJobs(ctx context.Context, project string, pipelineID int, opts JobListOptions) ([]Job, error) {
+	status := mockPipelineStatus(pipelineID)
+
+	var jobs []Job
+	for _, job := range GetMockJobs() {
+		job.PipelineID = pipelineID
+		if status == "success" || status == "failed" {
+			job = mockFinishedJob(job, pipelineID, status)
+		}
+		if job.Name == "test-integration" && status == "success" && opts.IncludeRetried {
+			retried := job
+			retried.ID, retried.Status = job.ID-1000, "failed"
+			jobs = append(jobs, retried)
+		}
+		jobs = append(jobs, job)
+	}
+	if opts.Limit > 0 && len(jobs) > opts.Limit {
+		jobs = jobs[:opts.Limit]
+	}
+	return jobs, nil
+}
+
+// mockJobSeconds is about how long each mock job runs
+var mockJobSeconds = map[string]float64{
+	"build-frontend":    95,
+	"test-unit":         62,
+	"test-integration":  184,
+	"zap-security-scan": 240,
+	"build-docker":      131,
+	"deploy-staging":    48,
+	"cypress-e2e":       312,
+	"deploy-production": 55,
+}
+
+// mockFinishedJob finishes a job of a finished mock pipeline. In a failed
+// pipeline the integration tests fail and the later stages are skipped.
+func mockFinishedJob(job Job, pipelineID int, pipelineStatus string) Job {
+	switch {
+	case job.Status == "manual":
+		return job
+	case pipelineStatus == "failed" && job.Name == "test-integration":
+		job.Status = "failed"
+	case pipelineStatus == "failed" && (job.Stage == "security" || job.Stage == "deploy"):
+		job.Status = "skipped"
+		return job
+	default:
+		job.Status = "success"
+	}
+
+	// Up to 20% faster or slower from one pipeline to the next
+	jitter := float64((pipelineID%97)*(job.ID%89)%41-20) / 100
+	job.DurationSeconds = math.Round(mockJobSeconds[job.Name] * (1 + jitter))
+	job.QueuedSeconds = float64((pipelineID+job.ID)%17 + 1)
+	job.Duration = FormatDuration(job.DurationSeconds)
+	return job
+}
+
+// ListPipelineBridges gives each top-level mock pipeline a child pipeline
+// and a multi-project downstream pipeline
+func (MockClient) ListPipelineBridges(ctx context.Context, project string, pipelineID int, opts JobListOptions) ([]Bridge, error) {
+	var parent *Pipeline
+	for _, p := range GetMockPipelines() {
+		if p.ID == pipelineID {
+			parent = &p
+			break
+		}
+	}
+	if parent == nil {
+		return nil, nil
+	}
+
+	bridges := []Bridge{
+		{
+			Job: Job{ID: pipelineID + 1, Name: "trigger:apps", Status: parent.Status, Stage: "trigger", PipelineID: pipelineID, ProjectID: parent.ProjectID},
+			Downstream: &Pipeline{
+				ID: pipelineID + 100, Status: parent.Status, Ref: parent.Ref, Source: "parent_pipeline", ProjectID: parent.ProjectID,
+				WebURL: fmt.Sprintf("https://gitlab.example.com/company/%s/-/pipelines/%d", parent.ProjectName, pipelineID+100),
+			},
+		},
+		{
+			Job: Job{ID: pipelineID + 2, Name: "trigger:deploy", Status: "success", Stage: "deploy", PipelineID: pipelineID, ProjectID: parent.ProjectID},
+			Downstream: &Pipeline{
+				ID: pipelineID + 200, Status: "success", Ref: "main", Source: "pipeline", ProjectID: 999,
+				WebURL: fmt.Sprintf("https://gitlab.example.com/company/infrastructure/-/pipelines/%d", pipelineID+200),
+			},
+		},
+	}
+	if opts.Limit > 0 && len(bridges) > opts.Limit {
+		bridges = bridges[:opts.Limit]
+	}
+	return bridges, nil
+}
+
+// ListBranches returns main plus the branches of the mock pipelines
+func (MockClient) ListBranches(ctx context.Context, project string, opts BranchListOptions) ([]Branch, error) {
+	branches := []Branch{{Name: "main", Default: true, Protected: true}}
+	seen := map[string]bool{"main": true}
+	for _, p := range GetMockPipelines() {
+		if seen[p.Ref] || strings.HasPrefix(p.Ref, "refs/") {
+			continue
+		}
+		seen[p.Ref] = true
+		branches = append(branches, Branch{Name: p.Ref})
+	}
+
+	var result []Branch
+	for _, b := range branches {
+		if !strings.Contains(b.Name, opts.Search) {
+			continue
+		}
+		result = append(result, b)
+		if opts.Limit > 0 && len(result) == opts.Limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// ListMergeRequests returns the mock merge requests
+func (MockClient) ListMergeRequests(ctx context.Context, project string, opts MergeRequestListOptions) ([]MergeRequest, error) {
+	var mrs []MergeRequest
+	for _, mr := range GetMockMergeRequests() {
+		if opts.State != "" && opts.State != "all" && mr.State != opts.State {
+			continue
+		}
+		mrs = append(mrs, mr)
+		if opts.Limit > 0 && len(mrs) == opts.Limit {
+			break
+		}
+	}
+	return mrs, nil
+}
+
+// ListMergeRequestPipelines returns the mock pipelines of the merge
+// request's source branch or merge request ref
+func (MockClient) ListMergeRequestPipelines(ctx context.Context, project string, iid int, opts PipelineListOptions) ([]Pipeline, error) {
+	var mr *MergeRequest
+	for _, m := range GetMockMergeRequests() {
+		if m.IID == iid {
+			mr = &m
+			break
+		}
+	}
+	if mr == nil {
+		return nil, NewAPIError(fmt.Sprintf("list pipelines of merge request !%d", iid), http.StatusNotFound, "")
+	}
+
+	var pipelines []Pipeline
+	for _, p := range GetMockPipelines() {
+		if p.Ref != mr.SourceBranch && !strings.HasSuffix(p.Ref, "/"+strconv.Itoa(iid)) {
+			continue
+		}
+		pipelines = append(pipelines, p)
+		if opts.Limit > 0 && len(pipelines) == opts.Limit {
+			break
+		}
+	}
+	return pipelines, nil
+}
+
+// GetMergeRequestApprovals returns a mix of approved and pending mock
+// approval states
+func (MockClient) GetMergeRequestApprovals(ctx context.Context, project string, iid int) (*MergeRequestApproval, error) {
+	switch iid {
+	case 406:
+		return &MergeRequestApproval{Required: 2, Left: 1, By: []string{"mlee"}}, nil
+	case 398:
+		return &MergeRequestApproval{Required: 1, Left: 0, By: []string{"asmith"}}, nil
+	}
+	return &MergeRequestApproval{Required: 1, Left: 1}, nil
+}
+
+// ListJobNeeds returns a small DAG over the mock jobs
+func (MockClient) ListJobNeeds(ctx context.Context, project string, pipelineID int) (map[string][]string, error) {
+	return map[string][]string{
+		"test-unit":         {"build-frontend"},
+		"test-integration":  {"build-frontend", "build-docker"},
+		"cypress-e2e":       {"build-docker"},
+		"zap-security-scan": {"build-docker"},
+		"deploy-staging":    {"test-integration", "cypress-e2e"},
+		"deploy-production": {"deploy-staging", "zap-security-scan"},
+	}, nil
+}
+
+// GetJob returns a mock job by ID
+func (MockClient) GetJob(ctx context.Context, project string, jobID int) (*Job, error) {
+	for _, j := range GetMockJobs() {
+		if j.ID == jobID {
+			return &j, nil
+		}
+	}
+	return nil, NewAPIError(fmt.Sprintf("get job %d", jobID), http.StatusNotFound, "")
+}
+
+// GetJobTrace returns a canned log for a mock job. The integration tests
+// fail the way they do in the failed mock pipelines.
+func (c MockClient) GetJobTrace(ctx context.Context, project string, jobID int) (string, error) {
+	job, err := c.GetJob(ctx, project, jobID)
+	if err != nil {
+		return "", err
+	}
+
+	start := int64(1700000000 + job.ID)
+	section := func(at int64, edge, name string) string {
+		return fmt.Sprintf("\x1b[0Ksection_%s:%d:%s\r\x1b[0K", edge, start+at, name)
+	}
+	title := func(text string) string {
+		return "\x1b[0K\x1b[36;1m" + text + "\x1b[0;m\n"
+	}
+	script := "[INFO] Running tests...\n" +
+		"[SUCCESS] All tests passed!\n" +
+		"[INFO] Job completed successfully\n\n"
+	outcome := "\x1b[32;1mJob succeeded\x1b[0;m\n"
+	if job.Name == "test-integration" {
+		script = "$ go test ./integration/...\n" +
+			"=== RUN   TestSupplierSync\n" +
+			"    supplier_test.go:42: sync timed out after 30s\n" +
+			"--- FAIL: TestSupplierSync (30.02s)\n" +
+			"=== RUN   TestOrderExport\n" +
+			"--- PASS: TestOrderExport (1.20s)\n" +
+			"FAIL\n" +
+			"FAIL\tgithub.com/demo/app/integration\t31.284s\n\n"
+		outcome = "\x1b[31;1mERROR: Job failed: exit code 1\x1b[0;m\n"
+	}
+
+	return "\x1b[0KRunning with gitlab-runner 16.6.0 (demo)\x1b[0;m\n" +
+		section(0, "start", "prepare_executor") + title("Preparing the \"docker\" executor") +
+		"\x1b[0KUsing Docker executor with image golang:1.21 ...\x1b[0;m\n" +
+		"\x1b[0KPulling docker image golang:1.21 ...\x1b[0;m\n" +
+		section(9, "end", "prepare_executor") +
+		section(9, "start", "prepare_script") + title("Preparing environment") +
+		"Running on runner-demo via demo-host...\n" +
+		section(11, "end", "prepare_script") +
+		section(11, "start", "get_sources") + title("Getting source from Git repository") +
+		"\x1b[32;1mFetching changes with git depth set to 20...\x1b[0;m\n" +
+		"\x1b[32;1mChecking out 1a2b3c4d as detached HEAD...\x1b[0;m\n" +
+		section(14, "end", "get_sources") +
+		section(14, "start", "step_script") + title("Executing \"step_script\" stage of the job script") +
+		"🎯 Demo Mode - Mock Job Logs\n\n" +
+		"📋 Job: " + job.Name + "\n" +
+		"📊 Status: " + job.Status + "\n" +
+		"🏗️  Stage: " + job.Stage + "\n\n" +
+		"Sample log output:\n" +
+		"[INFO] Starting job execution...\n" +
+		"[INFO] Installing dependencies...\n" +
+		script +
+		"💡 In real GitLab projects, you'd see actual job logs here.\n" +
+		"🔥 Use 'l' key for real-time streaming in live projects!\n" +
+		section(83, "end", "step_script") +
+		section(83, "start", "cleanup_file_variables") + title("Cleaning up project directory and file based variables") +
+		section(84, "end", "cleanup_file_variables") +
+		outcome, nil
+}
+
+// CreatePipeline pretends to start a pipeline; the mock jobs answer for
+// any pipeline ID, so the new pipeline can be opened
+func (MockClient) CreatePipeline(ctx context.Context, project, ref string, variables []JobVariable) (*Pipeline, error) {
+	id := 0
+	for _, p := range GetMockPipelines() {
+		id = max(id, p.ID)
+	}
+	now := time.Now()
+	return &Pipeline{ID: id + 1, Status: "created", Ref: ref, Source: "api", CreatedAt: now, UpdatedAt: now}, nil
+}
+
+// GetJobArtifacts builds a small artifacts archive for build and test jobs
+func (c MockClient) GetJobArtifacts(ctx context.Context, project string, jobID int, w io.Writer) error {
+	op := fmt.Sprintf("get artifacts of job %d", jobID)
+	files, err := c.mockArtifacts(ctx, op, project, jobID)
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return WrapError(op, err)
+		}
+		if _, err := fw.Write([]byte(f.content)); err != nil {
+			return WrapError(op, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return WrapError(op, err)
+	}
+	return nil
+}
+
+// GetJobArtifactFile serves one file of the mock artifacts archive
+func (c MockClient) GetJobArtifactFile(ctx context.Context, project string, jobID int, path string, w io.Writer) error {
+	op := fmt.Sprintf("get artifact %s of job %d", path, jobID)
+	files, err := c.mockArtifacts(ctx, op, project, jobID)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.name == path {
+			if _, err := io.WriteString(w, f.content); err != nil {
+				return WrapError(op, err)
+			}
+			return nil
+		}
+	}
+	return NewAPIError(op, http.StatusNotFound, "404 Not Found")
+}
+
+type mockArtifact struct{ name, content string }
+
+// mockArtifacts lists the files in the artifacts of build and test jobs
+func (c MockClient) mockArtifacts(ctx context.Context, op, project string, jobID int) ([]mockArtifact, error) {
+	job, err := c.GetJob(ctx, project, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Stage != "build" && job.Stage != "test" {
+		return nil, NewAPIError(op, http.StatusNotFound, "404 Not Found")
+	}
+
+	return []mockArtifact{
+		{"coverage/summary.txt", "Statements   : 87.4% ( 1748/2000 )\nBranches     : 79.1% ( 412/521 )\nFunctions    : 90.2% ( 331/367 )\nLines        : 88.0% ( 1702/1934 )\n"},
+		{"reports/junit.xml", `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<testsuites tests="3" failures="0"><testsuite name="` + job.Name + `" tests="3"><testcase name="loads config"/><testcase name="lists pipelines"/><testcase name="streams logs"/></testsuite></testsuites>` + "\n"},
+		{"dist/app.js", "console.log(\"" + job.Name + "\");\n"},
+		{"dist/logo.png", "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"},
+	}, nil
+}
+
+// GetPipelineTestReport reports the mock test suites for any pipeline
+func (MockClient) GetPipelineTestReport(ctx context.Context, project string, pipelineID int) (*TestReport, error) {
+	report := mockTestReport(mockPipelineStatus(pipelineID) == "success")
+	return &report, nil
+}
+
+// GetPipelineTestReportSummary reports the counts of the mock test suites
+func (MockClient) GetPipelineTestReportSummary(ctx context.Context, project string, pipelineID int) (*TestReport, error) {
+	summary := mockTestReport(mockPipelineStatus(pipelineID) == "success")
+	for i := range summary.Suites {
+		summary.Suites[i].Cases = nil
+	}
+	return &summary, nil
+}
+
+// mockTestReport builds the suites of the mock test jobs: unit tests pass,
+// integration tests have failures unless the pipeline passed
+func mockTestReport(passed bool) TestReport {
+	suites := []TestSuite{
+		{Name: "test-unit", JobIDs: []int{11100002}, Cases: []TestCase{
+			{Status: "success", Name: "loads config", Classname: "config.LoaderTest", File: "internal/config/config_test.go", Time: 0.012},
+			{Status: "success", Name: "parses remote URL", Classname: "cli.ParseTest", File: "cmd/cli/cli_test.go", Time: 0.004},
+			{Status: "success", Name: "formats durations", Classname: "core.FormatTest", File: "internal/core/pipeline_test.go", Time: 0.001},
+			{Status: "skipped", Name: "reads glab config", Classname: "config.LoaderTest", File: "internal/config/config_test.go"},
+		}},
+		{Name: "test-integration", JobIDs: []int{11100003}, Cases: []TestCase{
+			{Status: "success", Name: "lists pipelines", Classname: "api.PipelinesTest", File: "internal/api/pipelines_test.go", Time: 1.31},
+			{
+				Status: "failed", Name: "retries on 429", Classname: "api.TransportTest", File: "internal/api/transport_test.go", Time: 5.02,
+				SystemOutput: "GET /api/v4/projects/123/pipelines -> 429 Too Many Requests\nretrying in 1s\nGET /api/v4/projects/123/pipelines -> 429 Too Many Requests\n",
+				StackTrace:   "transport_test.go:88: expected 2 attempts, got 3\n\tat api.TestTransportRetries (internal/api/transport_test.go:88)",
+			},
+			{
+				Status: "error", Name: "follows job trace", Classname: "api.TraceTest", File: "internal/api/trace_test.go", Time: 30,
+				SystemOutput: "connecting to http://gitlab:8080\n",
+				StackTrace:   "panic: test timed out after 30s\n\ngoroutine 7 [running]:\napi.TestFollowJobTrace(0xc000102340)\n\tinternal/api/trace_test.go:42 +0x1f5",
+			},
+		}},
+	}
+
+	var report TestReport
+	for i := range suites {
+		suite := &suites[i]
+		for j := range suite.Cases {
+			c := &suite.Cases[j]
+			if passed && c.Failed() {
+				c.Status, c.SystemOutput, c.StackTrace = "success", "", ""
+			}
+			suite.Total++
+			suite.Time += c.Time
+			switch c.Status {
+			case "success":
+				suite.Success++
+			case "failed":
+				suite.Failed++
+			case "skipped":
+				suite.Skipped++
+			case "error":
+				suite.Error++
+			}
+		}
+		report.Total += suite.Total
+		report.Success += suite.Success
+		report.Failed += suite.Failed
+		report.Skipped += suite.Skipped
+		report.Error += suite.Error
+		report.Time += suite.Time
+	}
+	report.Suites = suites
+	return report
+}
+
+// mockPipelineStatus returns the status of a mock pipeline, "" for
+// pipelines that aren't mocked
+func mockPipelineStatus(pipelineID int) string {
+	for _, p := range GetMockPipelines() {
+		if p.ID == pipelineID {
+			return p.Status
+		}
+	}
+	return ""
+}
+
+// RetryPipeline pretends to restart a mock pipeline
+func (c MockClient) RetryPipeline(ctx context.Context, project string, pipelineID int) (*Pipeline, error) {
+	return c.pipelineWithStatus(ctx, project, pipelineID, "running")
+}
+
+// CancelPipeline pretends to cancel a mock pipeline
+func (c MockClient) CancelPipeline(ctx context.Context, project string, pipelineID int) (*Pipeline, error) {
+	return c.pipelineWithStatus(ctx, project, pipelineID, "canceled")
+}
+
+// DeletePipeline pretends to delete a mock pipeline
+func (c MockClient) DeletePipeline(ctx context.Context, project string, pipelineID int) error {
+	_, err := c.GetPipeline(ctx, project, pipelineID)
+	return err
+}
+
+// RetryJob pretends to retry a mock job. GitLab would create a new job;
+// the mock keeps the ID so its log stays available.
+func (c MockClient) RetryJob(ctx context.Context, project string, jobID int) (*Job, error) {
+	return c.jobWithStatus(ctx, project, jobID, "pending")
+}
+
+// CancelJob pretends to cancel a mock job
+func (c MockClient) CancelJob(ctx context.Context, project string, jobID int) (*Job, error) {
+	return c.jobWithStatus(ctx, project, jobID, "canceled")
+}
+
+// PlayJob pretends to start a manual mock job
+func (c MockClient) PlayJob(ctx context.Context, project string, jobID int, variables []JobVariable) (*Job, error) {
+	job, err := c.GetJob(ctx, project, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != "manual" {
+		return nil, NewAPIError(fmt.Sprintf("play job %d", jobID), http.StatusBadRequest, "Unplayable Job")
+	}
+	job.Status = "pending"
+	return job, nil
+}
+
+func (c MockClient) pipelineWithStatus(ctx context.Context, project string, pipelineID int, status string) (*Pipeline, error) {
+	pipeline, err := c.GetPipeline(ctx, project, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+	pipeline.Status = status
+	return pipeline, nil
+}
+
+func (c MockClient) jobWithStatus(ctx context.Context, project string, jobID int, status string) (*Job, error) {
+	job, err := c.GetJob(ctx, project, jobID)
+	if err != nil {
+		return nil, err
+	}
+	job.Status = status
+	return job, nil
+}
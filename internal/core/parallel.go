@@ -0,0 +1,42 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultWorkers bounds how many requests fan-out helpers run at once, to
+// stay friendly with GitLab's rate limits
+const DefaultWorkers = 8
+
+// parallel calls fn for 0..n-1 on at most workers goroutines and waits for
+// all of them. Indexes not yet started when ctx is cancelled are skipped.
+func parallel(ctx context.Context, n, workers int, fn func(i int)) {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			close(indexes)
+			wg.Wait()
+			return
+		}
+	}
+	close(indexes)
+	wg.Wait()
+}
@@ -1,24 +1,53 @@
 package core
 
+import (
+	"fmt"
+	"time"
+)
+
 // Job represents a GitLab CI/CD job
 type Job struct {
-	ID       int    `json:"id"`
-	Name     string `json:"name"`
-	Status   string `json:"status"`
-	Stage    string `json:"stage"`
-	Duration string `json:"duration,omitempty"`
+	ID              int     `json:"id"`
+	Name            string  `json:"name"`
+	Status          string  `json:"status"`
+	Stage           string  `json:"stage"`
+	Duration        string  `json:"duration,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds,omitempty"` // Time running, 0 if it never started
+	QueuedSeconds   float64 `json:"queued_seconds,omitempty"`   // Time waiting for a runner
+	Ref             string  `json:"ref,omitempty"`
+	WebURL          string  `json:"web_url,omitempty"`
+	PipelineID      int     `json:"pipeline_id,omitempty"`
+	ProjectID       int     `json:"project_id,omitempty"`
+}
+
+// Bridge is a trigger job that starts a downstream pipeline, either a child
+// pipeline in the same project or a multi-project pipeline
+type Bridge struct {
+	Job
+	Downstream *Pipeline `json:"downstream_pipeline,omitempty"` // nil until the downstream pipeline exists
 }
 
 // Pipeline represents a GitLab CI/CD pipeline
 type Pipeline struct {
-	ID          int    `json:"id"`
-	Status      string `json:"status"`
-	Ref         string `json:"ref"`
-	WebURL      string `json:"web_url"`
-	ProjectID   int    `json:"project_id"`
-	ProjectName string `json:"-"` // Computed field
-	Jobs        string `json:"-"` // Computed field
-	Duration    string `json:"-"` // Computed field for display
+	ID          int       `json:"id"`
+	IID         int       `json:"iid"`
+	Status      string    `json:"status"`
+	Ref         string    `json:"ref"`
+	SHA         string    `json:"sha"`
+	Source      string    `json:"source"`
+	WebURL      string    `json:"web_url"`
+	ProjectID   int       `json:"project_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	ProjectName string    `json:"-"` // Computed field
+	Jobs        string    `json:"-"` // Computed field
+	Duration    string    `json:"-"` // Computed field for display
+
+	// Seconds from the first job starting to the last one finishing, and
+	// spent waiting for runners. Pipeline listings leave them out, only
+	// a single pipeline read has them.
+	DurationSeconds float64 `json:"duration_seconds"`
+	QueuedSeconds   float64 `json:"queued_seconds"`
 }
 
 // Project represents a GitLab project
@@ -26,11 +55,64 @@ type Project struct {
 	ID                int    `json:"id"`
 	Name              string `json:"name"`
 	NameWithNamespace string `json:"name_with_namespace"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	DefaultBranch     string `json:"default_branch"`
 	WebURL            string `json:"web_url"`
 	LastActivityAt    string `json:"last_activity_at"`
 	Archived          bool   `json:"archived"`
 }
 
+// Branch represents a branch of a project's repository
+type Branch struct {
+	Name      string `json:"name"`
+	Default   bool   `json:"default"`
+	Protected bool   `json:"protected"`
+}
+
+// FormatDuration renders a duration in seconds the way the GitLab UI does, e.g. "4m 32s"
+func FormatDuration(seconds float64) string {
+	if seconds <= 0 {
+		return ""
+	}
+	d := time.Duration(seconds * float64(time.Second)).Round(time.Second)
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+	switch {
+	case h > 0:
+		return fmt.Sprintf("%dh %dm %ds", h, m, s)
+	case m > 0:
+		return fmt.Sprintf("%dm %ds", m, s)
+	default:
+		return fmt.Sprintf("%ds", s)
+	}
+}
+
+// FormatAge renders how long ago a timestamp was, e.g. "5 minutes ago"
+func FormatAge(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "less than a minute ago"
+	case d < time.Hour:
+		return plural(int(d.Minutes()), "minute") + " ago"
+	case d < 24*time.Hour:
+		return plural(int(d.Hours()), "hour") + " ago"
+	default:
+		return plural(int(d.Hours()/24), "day") + " ago"
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
 // PipelineService handles pipeline operations
 type PipelineService struct {
 	// Will contain GitLab client, config, etc.
@@ -39,23 +121,23 @@ type PipelineService struct {
 // GetMockPipelines returns mock data for development (multi-project)
 func GetMockPipelines() []Pipeline {
 	return []Pipeline{
-		{ID: 1996879423, Status: "running", Ref: "feat/zap-c3", ProjectID: 123, ProjectName: "frontend-app", Jobs: "3/8 jobs", Duration: "4m 32s"},
-		{ID: 1996867272, Status: "running", Ref: "refs/merge-req/406", ProjectID: 456, ProjectName: "backend-api", Jobs: "5/8 jobs", Duration: "2m 15s"},
-		{ID: 1996733511, Status: "success", Ref: "fix/supplier-bug", ProjectID: 123, ProjectName: "frontend-app", Jobs: "8/8 jobs", Duration: "6m 45s"},
-		{ID: 1996723026, Status: "failed", Ref: "fix/supplier-bug", ProjectID: 789, ProjectName: "data-pipeline", Jobs: "failed", Duration: "3m 12s"},
-		{ID: 1996719037, Status: "success", Ref: "main", ProjectID: 456, ProjectName: "backend-api", Jobs: "8/8 jobs", Duration: "5m 23s"},
-		{ID: 1996719038, Status: "running", Ref: "feature/auth", ProjectID: 101, ProjectName: "auth-service", Jobs: "2/5 jobs", Duration: "1m 45s"},
-		{ID: 1996719039, Status: "success", Ref: "main", ProjectID: 789, ProjectName: "data-pipeline", Jobs: "12/12 jobs", Duration: "8m 15s"},
+		{ID: 1996879423, Status: "running", Ref: "feat/zap-c3", SHA: "9f2c4e1a7b3d5e6f8091a2b3c4d5e6f708192a3b", ProjectID: 123, ProjectName: "frontend-app", Jobs: "3/8 jobs", Duration: "4m 32s", DurationSeconds: 272, QueuedSeconds: 12},
+		{ID: 1996867272, Status: "running", Ref: "refs/merge-req/406", SHA: "2b7e1d9c04f3a5b6c7d8e9f0a1b2c3d4e5f60718", ProjectID: 456, ProjectName: "backend-api", Jobs: "5/8 jobs", Duration: "2m 15s", DurationSeconds: 135, QueuedSeconds: 48},
+		{ID: 1996733511, Status: "success", Ref: "fix/supplier-bug", SHA: "c41d9e07b2a8f3e6d5c4b3a2918070f6e5d4c3b2", ProjectID: 123, ProjectName: "frontend-app", Jobs: "8/8 jobs", Duration: "6m 45s", DurationSeconds: 405, QueuedSeconds: 9},
+		{ID: 1996723026, Status: "failed", Ref: "fix/supplier-bug", SHA: "c41d9e07b2a8f3e6d5c4b3a2918070f6e5d4c3b2", ProjectID: 789, ProjectName: "data-pipeline", Jobs: "failed", Duration: "3m 12s", DurationSeconds: 192, QueuedSeconds: 31},
+		{ID: 1996719037, Status: "success", Ref: "main", SHA: "e5a3b7f9016c2d4e8f0a1b3c5d7e9f1a2b4c6d8e", ProjectID: 456, ProjectName: "backend-api", Jobs: "8/8 jobs", Duration: "5m 23s", DurationSeconds: 323, QueuedSeconds: 7},
+		{ID: 1996719038, Status: "running", Ref: "feature/auth", SHA: "7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b", ProjectID: 101, ProjectName: "auth-service", Jobs: "2/5 jobs", Duration: "1m 45s", DurationSeconds: 105, QueuedSeconds: 64},
+		{ID: 1996719039, Status: "success", Ref: "main", SHA: "e5a3b7f9016c2d4e8f0a1b3c5d7e9f1a2b4c6d8e", ProjectID: 789, ProjectName: "data-pipeline", Jobs: "12/12 jobs", Duration: "8m 15s", DurationSeconds: 495, QueuedSeconds: 22},
 	}
 }
 
 // GetMockProjects returns mock project data
 func GetMockProjects() []Project {
 	return []Project{
-		{ID: 123, Name: "frontend-app", NameWithNamespace: "company/frontend-app", Archived: false},
-		{ID: 456, Name: "backend-api", NameWithNamespace: "company/backend-api", Archived: false},
-		{ID: 789, Name: "data-pipeline", NameWithNamespace: "company/data-pipeline", Archived: false},
-		{ID: 101, Name: "auth-service", NameWithNamespace: "company/auth-service", Archived: false},
+		{ID: 123, Name: "frontend-app", NameWithNamespace: "company/frontend-app", PathWithNamespace: "company/frontend-app", DefaultBranch: "main", Archived: false},
+		{ID: 456, Name: "backend-api", NameWithNamespace: "company/backend-api", PathWithNamespace: "company/backend-api", DefaultBranch: "main", Archived: false},
+		{ID: 789, Name: "data-pipeline", NameWithNamespace: "company/data-pipeline", PathWithNamespace: "company/data-pipeline", DefaultBranch: "main", Archived: false},
+		{ID: 101, Name: "auth-service", NameWithNamespace: "company/auth-service", PathWithNamespace: "company/auth-service", DefaultBranch: "main", Archived: false},
 	}
 }
 
@@ -69,7 +151,17 @@ func GetMockJobs() []Job {
 		{ID: 11100005, Name: "build-docker", Status: "pending", Stage: "build"},
 		{ID: 11100006, Name: "deploy-staging", Status: "pending", Stage: "deploy"},
 		{ID: 11100007, Name: "cypress-e2e", Status: "pending", Stage: "test"},
-		{ID: 11100008, Name: "deploy-production", Status: "pending", Stage: "deploy"},
+		{ID: 11100008, Name: "deploy-production", Status: "manual", Stage: "deploy"},
+	}
+}
+
+// GetMockMergeRequests returns mock merge request data for demo purposes
+func GetMockMergeRequests() []MergeRequest {
+	now := time.Now()
+	return []MergeRequest{
+		{ID: 90406, IID: 406, ProjectID: 456, Title: "Add supplier search endpoint", Author: "jdoe", SourceBranch: "feat/supplier-search", TargetBranch: "main", State: "opened", UpdatedAt: now.Add(-12 * time.Minute)},
+		{ID: 90411, IID: 411, ProjectID: 123, Title: "Draft: ZAP scan in CI", Author: "asmith", SourceBranch: "feat/zap-c3", TargetBranch: "main", State: "opened", Draft: true, UpdatedAt: now.Add(-time.Hour)},
+		{ID: 90398, IID: 398, ProjectID: 789, Title: "Fix supplier import bug", Author: "mlee", SourceBranch: "fix/supplier-bug", TargetBranch: "release/2.4", State: "opened", UpdatedAt: now.Add(-26 * time.Hour)},
 	}
 }
 
@@ -77,5 +169,3 @@ func GetMockJobs() []Job {
 // func (ps *PipelineService) GetGroupProjects(groupID int) ([]Project, error)
 // func (ps *PipelineService) GetProjectPipelines(projectID int) ([]Pipeline, error)
 // func (ps *PipelineService) GetGroupPipelines(groupID int) ([]Pipeline, error)
-// func (ps *PipelineService) RetryPipeline(pipelineID int) error
-// func (ps *PipelineService) CancelPipeline(pipelineID int) error
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+
+	"github.com/rkristelijn/glab-tui/internal/config"
+)
+
+// ResolveProject determines which project to work on. An explicit project
+// wins, then GITLAB_PROJECT_ID, then the origin remote of the current git
+// repository.
+func ResolveProject(cfg *config.Config, project string) (string, error) {
+	if project != "" {
+		return project, nil
+	}
+
+	if cfg != nil && cfg.GitLab.ProjectID != 0 {
+		return ProjectRef(cfg.GitLab.ProjectID), nil
+	}
+
+	return DetectProjectPath()
+}
+
+// DetectProjectPath extracts the GitLab project path from the origin remote
+func DetectProjectPath() (string, error) {
+	// Get the remote URL
+	cmd := exec.Command("git", "remote", "get-url", "origin")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get git remote: %w", err)
+	}
+
+	remoteURL := strings.TrimSpace(string(output))
+
+	// Parse GitLab project path from URL
+	if strings.Contains(remoteURL, "gitlab") {
+		if strings.HasPrefix(remoteURL, "git@") {
+			// SSH format: git@gitlab.com:group/project.git
+			parts := strings.Split(remoteURL, ":")
+			if len(parts) >= 2 {
+				return strings.TrimSuffix(parts[1], ".git"), nil
+			}
+		} else if strings.HasPrefix(remoteURL, "https://") {
+			// HTTPS format: https://gitlab.com/group/project.git
+			parts := strings.Split(remoteURL, "/")
+			if len(parts) >= 4 {
+				projectPath := strings.Join(parts[3:], "/")
+				return strings.TrimSuffix(projectPath, ".git"), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("not a GitLab repository or unsupported URL format: %s", remoteURL)
+}
+
+// DetectCurrentBranch returns the branch checked out in the current git
+// repository
+func DetectCurrentBranch() (string, error) {
+	output, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current git branch: %w", err)
+	}
+
+	branch := strings.TrimSpace(string(output))
+	if branch == "HEAD" {
+		return "", fmt.Errorf("not on a branch (detached HEAD)")
+	}
+	return branch, nil
+}
+
+// ProjectName returns the last segment of a project path ("group/app" -> "app")
+func ProjectName(project string) string {
+	parts := strings.Split(strings.Trim(project, "/"), "/")
+	return parts[len(parts)-1]
+}
+
+// ProjectPathFromWebURL extracts the project path from a GitLab web URL,
+// e.g. "https://gitlab.com/group/app/-/pipelines/1" -> "group/app"
+func ProjectPathFromWebURL(webURL string) string {
+	u, err := url.Parse(webURL)
+	if err != nil {
+		return ""
+	}
+	path, _, _ := strings.Cut(strings.Trim(u.Path, "/"), "/-/")
+	return path
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"context"
+	"fmt"
+)
+
+// maxBranches bounds the branches offered when picking a ref to run
+const maxBranches = 100
+
+// CreatePipeline runs a new pipeline for a branch or tag, with optional
+// variables
+func (s *Service) CreatePipeline(ctx context.Context, ref string, variables []JobVariable) (*Pipeline, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("a branch or tag is required to run a pipeline")
+	}
+
+	pipeline, err := s.gitlab.CreatePipeline(ctx, s.project, ref, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run pipeline for %s: %w", ref, err)
+	}
+	if pipeline.ProjectName == "" {
+		pipeline.ProjectName = ProjectName(s.project)
+	}
+	return pipeline, nil
+}
+
+// ListBranches returns the project's branches whose name contains search,
+// the default branch first
+func (s *Service) ListBranches(ctx context.Context, search string) ([]Branch, error) {
+	branches, err := s.gitlab.ListBranches(ctx, s.project, BranchListOptions{Search: search, Limit: maxBranches})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches for %s: %w", s.project, err)
+	}
+
+	for i, b := range branches {
+		if b.Default && i > 0 {
+			copy(branches[1:i+1], branches[:i])
+			branches[0] = b
+			break
+		}
+	}
+	return branches, nil
+}
+
+// DefaultRef picks the ref to offer when running a pipeline: the branch
+// checked out locally when the service is scoped to the current repository,
+// otherwise the project's default branch
+func (s *Service) DefaultRef(ctx context.Context) string {
+	if project, err := DetectProjectPath(); err == nil && project == s.project {
+		if branch, err := DetectCurrentBranch(); err == nil {
+			return branch
+		}
+	}
+
+	if project, err := s.gitlab.GetProject(ctx, s.project); err == nil && project.DefaultBranch != "" {
+		return project.DefaultBranch
+	}
+	return "main"
+}
@@ -1,62 +1,186 @@
 package core
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/rkristelijn/glab-tui/internal/config"
-	"github.com/xanzy/go-gitlab"
+	"github.com/rkristelijn/glab-tui/internal/joblog"
 )
 
+// Service is the single data path for the CLI and the TUI. It owns project
+// resolution and all fetching, whatever GitLabClient backend is underneath.
 type Service struct {
-	config *config.Config
-	gitlab GitLabClient
+	config  *config.Config
+	gitlab  GitLabClient
+	project string
 }
 
-type GitLabClient interface {
-	GetJob(projectID, jobID int) (interface{}, error)
-	GetProjectPipelines(projectID int) ([]Pipeline, error)
-	GetProject(projectID int) (interface{}, error)
-	GetProjectByPath(path string) (interface{}, error)
+// NewService creates a service scoped to a project (path or numeric ID)
+func NewService(cfg *config.Config, gitlabClient GitLabClient, project string) *Service {
+	return &Service{
+		config:  cfg,
+		gitlab:  gitlabClient,
+		project: project,
+	}
 }
 
-func NewService(cfg *config.Config, gitlabClient GitLabClient) *Service {
-	return &Service{
-		config: cfg,
-		gitlab: gitlabClient,
+// NewMockService creates a service over the mock backend for demo mode
+func NewMockService(cfg *config.Config) *Service {
+	return NewService(cfg, MockClient{}, "demo-project")
+}
+
+// Project returns the project reference the service is scoped to
+func (s *Service) Project() string {
+	return s.project
+}
+
+// ForProject returns a service on the same backend scoped to another
+// project, e.g. the target of a multi-project trigger
+func (s *Service) ForProject(project string) *Service {
+	if project == "" || project == s.project {
+		return s
 	}
+	return NewService(s.config, s.gitlab, project)
 }
 
-// GetPipelines returns pipelines based on configuration
-func (s *Service) GetPipelines() ([]Pipeline, error) {
-	// For now, just use mock data - we'll improve this integration later
-	return GetMockPipelines(), nil
+// RateLimit returns the backend's current API budget, false if the backend
+// doesn't report one or hasn't made a request yet
+func (s *Service) RateLimit() (RateLimit, bool) {
+	if reporter, ok := s.gitlab.(RateLimitReporter); ok {
+		return reporter.RateLimit()
+	}
+	return RateLimit{}, false
 }
 
-// GetJobStatus gets the status of a specific job
-func (s *Service) GetJobStatus(jobID int) (string, error) {
-	if s.config.GitLab.ProjectID == 0 {
-		return "", fmt.Errorf("no project ID configured")
+// DefaultRefreshInterval is used when REFRESH_INTERVAL is unset or invalid
+const DefaultRefreshInterval = 5 * time.Second
+
+// minRefreshInterval keeps a wall monitor from eating the API budget
+const minRefreshInterval = time.Second
+
+// RefreshInterval returns how often live views poll for new data
+func (s *Service) RefreshInterval() time.Duration {
+	if s.config == nil || s.config.UI.RefreshInterval <= 0 {
+		return DefaultRefreshInterval
 	}
+	return max(s.config.UI.RefreshInterval, minRefreshInterval)
+}
 
-	job, err := s.gitlab.GetJob(s.config.GitLab.ProjectID, jobID)
+// ErrorMatcher returns the failure detection of job logs configured by
+// LOG_ERROR_SETS and LOG_ERROR_PATTERN
+func (s *Service) ErrorMatcher() (*joblog.Matcher, error) {
+	if s.config == nil {
+		return joblog.DefaultMatcher(), nil
+	}
+	return joblog.NewMatcher(s.config.Log.ErrorSets, s.config.Log.ErrorPattern)
+}
+
+// ListPipelines returns the most recently updated pipelines of the project,
+// up to MAX_PIPELINES_PER_PROJECT
+func (s *Service) ListPipelines(ctx context.Context) ([]Pipeline, error) {
+	opts := PipelineListOptions{}
+	if s.config != nil {
+		opts.Limit = s.config.UI.MaxPipelinesPerProject
+	}
+
+	return s.ListRecentPipelines(ctx, opts)
+}
+
+// ListRecentPipelines returns the most recently updated pipelines matching
+// opts, following pagination up to opts.Limit (0 fetches the full history)
+func (s *Service) ListRecentPipelines(ctx context.Context, opts PipelineListOptions) ([]Pipeline, error) {
+	pipelines, err := s.gitlab.ListPipelines(ctx, s.project, opts)
 	if err != nil {
-		return "", fmt.Errorf("failed to get job %d: %w", jobID, err)
+		return nil, fmt.Errorf("failed to list pipelines for %s: %w", s.project, err)
+	}
+
+	for i := range pipelines {
+		if pipelines[i].ProjectName == "" {
+			pipelines[i].ProjectName = ProjectName(s.project)
+		}
+		if pipelines[i].Jobs == "" || pipelines[i].Jobs == "loading..." {
+			pipelines[i].Jobs = jobsSummary(pipelines[i].Status)
+		}
 	}
 
-	return extractJobStatus(job), nil
+	return pipelines, nil
+}
+
+// ListJobs returns every job of a pipeline, across all pages
+func (s *Service) ListJobs(ctx context.Context, pipelineID int) ([]Job, error) {
+	jobs, err := s.gitlab.ListPipelineJobs(ctx, s.project, pipelineID, JobListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs for pipeline %d: %w", pipelineID, err)
+	}
+	return jobs, nil
+}
+
+// JobNeeds maps the job names of a pipeline to the jobs they need. It
+// returns ErrUnsupported when the backend can't read needs.
+func (s *Service) JobNeeds(ctx context.Context, pipelineID int) (map[string][]string, error) {
+	reader, ok := s.gitlab.(JobNeedsReader)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+
+	needs, err := reader.ListJobNeeds(ctx, s.project, pipelineID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job needs for pipeline %d: %w", pipelineID, err)
+	}
+	return needs, nil
+}
+
+// GetJob returns a single job
+func (s *Service) GetJob(ctx context.Context, jobID int) (*Job, error) {
+	job, err := s.gitlab.GetJob(ctx, s.project, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %d: %w", jobID, err)
+	}
+	return job, nil
+}
+
+// GetJobStatus gets the status of a specific job
+func (s *Service) GetJobStatus(ctx context.Context, jobID int) (string, error) {
+	job, err := s.GetJob(ctx, jobID)
+	if err != nil {
+		return "", err
+	}
+
+	return job.Status, nil
+}
+
+// GetJobLogs returns the full trace of a job
+func (s *Service) GetJobLogs(ctx context.Context, jobID int) (string, error) {
+	logs, err := s.gitlab.GetJobTrace(ctx, s.project, jobID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs for job %d: %w", jobID, err)
+	}
+	return logs, nil
 }
 
-// Helper functions to extract data from interface{}
-func extractProjectID(project interface{}) int {
-	if p, ok := project.(*gitlab.Project); ok {
-		return p.ID
+// jobsSummary describes job progress based on the pipeline status
+func jobsSummary(status string) string {
+	switch status {
+	case "running":
+		return "in progress"
+	case "success":
+		return "completed"
+	case "failed":
+		return "failed"
+	case "waiting_for_resource":
+		return "queued"
+	default:
+		return "pending"
 	}
-	return 0
 }
 
-func extractJobStatus(job interface{}) string {
-	if j, ok := job.(*gitlab.Job); ok {
-		return j.Status
+// IsFinished reports whether a job or pipeline status is final
+func IsFinished(status string) bool {
+	switch status {
+	case "success", "failed", "canceled", "skipped":
+		return true
 	}
-	return "unknown"
+	return false
 }
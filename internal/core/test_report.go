@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TestCounts are the outcomes of a set of test cases
+type TestCounts struct {
+	Total   int
+	Success int
+	Failed  int
+	Skipped int
+	Error   int
+	Time    float64 // Seconds
+}
+
+// String summarizes the counts, e.g. "120 tests, 3 failed, 2 skipped"
+func (c TestCounts) String() string {
+	plural := func(n int, noun string) string {
+		if n == 1 {
+			return "1 " + noun
+		}
+		return fmt.Sprintf("%d %ss", n, noun)
+	}
+
+	parts := []string{plural(c.Total, "test")}
+	if c.Failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", c.Failed))
+	}
+	if c.Error > 0 {
+		parts = append(parts, plural(c.Error, "error"))
+	}
+	if c.Skipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", c.Skipped))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Passed reports whether no test failed or errored
+func (c TestCounts) Passed() bool {
+	return c.Failed == 0 && c.Error == 0
+}
+
+// TestReport is the JUnit test report of a pipeline, merged from the
+// reports its jobs uploaded
+type TestReport struct {
+	TestCounts
+	Suites []TestSuite
+}
+
+// TestSuite groups the test cases reported under one name, usually the
+// name of the job that ran them
+type TestSuite struct {
+	Name string
+	TestCounts
+	SuiteError string     // Why GitLab couldn't parse the suite's report
+	JobIDs     []int      // Jobs that reported the suite, from the summary
+	Cases      []TestCase // Empty in a summary
+}
+
+// TestCase is a single test of a suite
+type TestCase struct {
+	Status       string // success, failed, skipped or error
+	Name         string
+	Classname    string
+	File         string
+	Time         float64 // Seconds
+	SystemOutput string
+	StackTrace   string
+}
+
+// Failed reports whether the test case failed or errored
+func (c TestCase) Failed() bool {
+	return c.Status == "failed" || c.Status == "error"
+}
+
+// FailedCases returns the failed and errored cases of the suite
+func (s TestSuite) FailedCases() []TestCase {
+	var failed []TestCase
+	for _, c := range s.Cases {
+		if c.Failed() {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// TestReport returns the test report of a pipeline with every test case.
+// The summary is read alongside it to know which jobs reported each suite;
+// if that fails the suites just lack their job IDs.
+func (s *Service) TestReport(ctx context.Context, pipelineID int) (*TestReport, error) {
+	var report, summary *TestReport
+	var err error
+	parallel(ctx, 2, 2, func(i int) {
+		if i == 0 {
+			report, err = s.gitlab.GetPipelineTestReport(ctx, s.project, pipelineID)
+		} else {
+			summary, _ = s.gitlab.GetPipelineTestReportSummary(ctx, s.project, pipelineID)
+		}
+	})
+	if err == nil && report == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test report of pipeline %d: %w", pipelineID, err)
+	}
+
+	if summary != nil {
+		jobs := make(map[string][]int, len(summary.Suites))
+		for _, suite := range summary.Suites {
+			jobs[suite.Name] = suite.JobIDs
+		}
+		for i := range report.Suites {
+			report.Suites[i].JobIDs = jobs[report.Suites[i].Name]
+		}
+	}
+	return report, nil
+}
+
+// TestReportSummary returns the test counts of a pipeline per suite,
+// without the test cases
+func (s *Service) TestReportSummary(ctx context.Context, pipelineID int) (*TestReport, error) {
+	summary, err := s.gitlab.GetPipelineTestReportSummary(ctx, s.project, pipelineID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test report summary of pipeline %d: %w", pipelineID, err)
+	}
+	return summary, nil
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultTraceInterval is how often a followed trace is polled
+const DefaultTraceInterval = 2 * time.Second
+
+// TraceChunk is a piece of job log delivered while following a trace
+type TraceChunk struct {
+	Data   string // Log output appended since the previous chunk
+	Reset  bool   // The trace was truncated or restarted; Data replaces everything so far
+	Done   bool   // The job finished; no more chunks follow
+	Status string // Final job status, set when Done
+	Err    error  // A poll failed; following goes on unless the channel closes
+}
+
+// TraceFollower is implemented by backends that can fetch only the new part
+// of a trace. It is optional; Service falls back to polling the full trace.
+type TraceFollower interface {
+	FollowJobTrace(ctx context.Context, project string, jobID int, interval time.Duration) <-chan TraceChunk
+}
+
+// TraceFetcher returns what was appended to a trace since its last call
+type TraceFetcher func(ctx context.Context) (TraceChunk, error)
+
+// FollowJobLogs streams a job's log until the job finishes or ctx is
+// cancelled. The channel is closed after the final Done chunk.
+func (s *Service) FollowJobLogs(ctx context.Context, jobID int, interval time.Duration) <-chan TraceChunk {
+	if follower, ok := s.gitlab.(TraceFollower); ok {
+		return follower.FollowJobTrace(ctx, s.project, jobID, interval)
+	}
+
+	var previous string
+	fetch := func(ctx context.Context) (TraceChunk, error) {
+		trace, err := s.gitlab.GetJobTrace(ctx, s.project, jobID)
+		if err != nil {
+			return TraceChunk{}, err
+		}
+		chunk := DiffTrace(previous, trace)
+		previous = trace
+		return chunk, nil
+	}
+	status := func(ctx context.Context) (string, error) {
+		return s.GetJobStatus(ctx, jobID)
+	}
+
+	return FollowTrace(ctx, interval, fetch, status)
+}
+
+// FollowTrace runs the polling loop shared by all backends: each round it
+// checks the job status, fetches new output and sends it, and stops after a
+// round that started with the job already finished, so no output is lost.
+// Errors are sent as chunks; not found and permission errors end following.
+func FollowTrace(ctx context.Context, interval time.Duration, fetch TraceFetcher, status func(ctx context.Context) (string, error)) <-chan TraceChunk {
+	if interval <= 0 {
+		interval = DefaultTraceInterval
+	}
+
+	ch := make(chan TraceChunk)
+	go func() {
+		defer close(ch)
+
+		send := func(chunk TraceChunk) bool {
+			select {
+			case ch <- chunk:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			jobStatus, statusErr := status(ctx)
+
+			chunk, err := fetch(ctx)
+			if err != nil {
+				if !send(TraceChunk{Err: err}) || isFatalTraceError(err) {
+					return
+				}
+			} else if chunk.Data != "" || chunk.Reset {
+				if !send(chunk) {
+					return
+				}
+			}
+
+			if statusErr != nil {
+				if !send(TraceChunk{Err: statusErr}) || isFatalTraceError(statusErr) {
+					return
+				}
+			} else if IsFinished(jobStatus) {
+				send(TraceChunk{Done: true, Status: jobStatus})
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+
+	return ch
+}
+
+// DiffTrace works out the chunk that turns previous into current
+func DiffTrace(previous, current string) TraceChunk {
+	if strings.HasPrefix(current, previous) {
+		return TraceChunk{Data: current[len(previous):]}
+	}
+	return TraceChunk{Data: current, Reset: true}
+}
+
+// isFatalTraceError reports whether polling a trace again is pointless
+func isFatalTraceError(err error) bool {
+	return errors.Is(err, ErrNotFound) ||
+		errors.Is(err, ErrUnauthorized) ||
+		errors.Is(err, ErrForbidden) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
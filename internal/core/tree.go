@@ -0,0 +1,157 @@
+package core
+
+import (
+	"context"
+	"fmt"
+)
+
+// maxTreeDepth bounds how deep downstream pipelines are followed. GitLab
+// allows two levels of child pipelines, but multi-project triggers can chain.
+const maxTreeDepth = 5
+
+// PipelineNode is a pipeline together with the downstream pipelines its
+// bridge jobs triggered
+type PipelineNode struct {
+	Pipeline     Pipeline
+	Project      string          // Project reference the pipeline belongs to
+	Trigger      *Bridge         // Bridge job in the parent that started it, nil for the root
+	MultiProject bool            // Triggered in a different project than its parent
+	Children     []*PipelineNode // Downstream pipelines, in bridge order
+	Err          error           // Set when the downstream pipelines couldn't be listed
+}
+
+// PipelineTree returns a pipeline with all its child and multi-project
+// downstream pipelines, discovered recursively through the bridges API
+func (s *Service) PipelineTree(ctx context.Context, pipelineID int) (*PipelineNode, error) {
+	pipeline, err := s.gitlab.GetPipeline(ctx, s.project, pipelineID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline %d: %w", pipelineID, err)
+	}
+	if pipeline.ProjectName == "" {
+		pipeline.ProjectName = ProjectName(s.project)
+	}
+	if pipeline.ProjectID == 0 {
+		// Some backends leave it out, but it tells multi-project triggers
+		// from child pipelines
+		if project, err := s.gitlab.GetProject(ctx, s.project); err == nil {
+			pipeline.ProjectID = project.ID
+		}
+	}
+
+	root := &PipelineNode{Pipeline: *pipeline, Project: s.project}
+	if err := s.addDownstream(ctx, root, 0, map[int]bool{pipeline.ID: true}); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// addDownstream fills in the children of node. Only a failure on the root
+// is returned; deeper failures are recorded on the node so one inaccessible
+// downstream project doesn't hide the rest of the tree.
+func (s *Service) addDownstream(ctx context.Context, node *PipelineNode, depth int, seen map[int]bool) error {
+	if depth >= maxTreeDepth {
+		return nil
+	}
+
+	bridges, err := s.gitlab.ListPipelineBridges(ctx, node.Project, node.Pipeline.ID, JobListOptions{})
+	if err != nil {
+		err = fmt.Errorf("failed to list bridges of pipeline %d: %w", node.Pipeline.ID, err)
+		if depth == 0 {
+			return err
+		}
+		node.Err = err
+		return nil
+	}
+
+	for i := range bridges {
+		bridge := bridges[i]
+		downstream := bridge.Downstream
+		if downstream == nil || seen[downstream.ID] {
+			continue
+		}
+		seen[downstream.ID] = true
+
+		child := &PipelineNode{
+			Pipeline: *downstream,
+			Project:  node.Project,
+			Trigger:  &bridge,
+		}
+		if differentProjects(node.Pipeline, *downstream) {
+			child.Project = ProjectPathFromWebURL(downstream.WebURL)
+			if downstream.ProjectID != 0 {
+				child.Project = ProjectRef(downstream.ProjectID)
+			}
+			child.MultiProject = true
+		}
+		if child.Pipeline.ProjectName == "" {
+			if path := ProjectPathFromWebURL(downstream.WebURL); path != "" {
+				child.Pipeline.ProjectName = ProjectName(path)
+			} else {
+				child.Pipeline.ProjectName = ProjectName(child.Project)
+			}
+		}
+
+		_ = s.addDownstream(ctx, child, depth+1, seen)
+		node.Children = append(node.Children, child)
+	}
+	return nil
+}
+
+// differentProjects reports whether two pipelines are known to belong to
+// different projects, by project ID or else by the project path of their web
+// URL. Pipelines with unknown projects count as the same.
+func differentProjects(a, b Pipeline) bool {
+	if a.ProjectID != 0 && b.ProjectID != 0 {
+		return a.ProjectID != b.ProjectID
+	}
+	pathA, pathB := ProjectPathFromWebURL(a.WebURL), ProjectPathFromWebURL(b.WebURL)
+	return pathA != "" && pathB != "" && pathA != pathB
+}
+
+// Walk visits the node and all its descendants depth-first
+func (n *PipelineNode) Walk(fn func(node *PipelineNode, depth int)) {
+	n.walk(fn, 0)
+}
+
+func (n *PipelineNode) walk(fn func(node *PipelineNode, depth int), depth int) {
+	fn(n, depth)
+	for _, child := range n.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// AggregateStatus combines the status of the pipeline and everything
+// downstream of it: a failure anywhere wins, then activity, then success
+func (n *PipelineNode) AggregateStatus() string {
+	status := ""
+	n.Walk(func(node *PipelineNode, _ int) {
+		if statusRank(node.Pipeline.Status) > statusRank(status) {
+			status = node.Pipeline.Status
+		}
+	})
+	return status
+}
+
+// statusRank orders statuses by how much attention they need
+func statusRank(status string) int {
+	switch status {
+	case "failed":
+		return 7
+	case "running":
+		return 6
+	case "pending", "preparing", "waiting_for_resource":
+		return 5
+	case "created", "scheduled":
+		return 4
+	case "manual":
+		return 3
+	case "canceled":
+		return 2
+	case "success":
+		return 1
+	case "":
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// treeClient serves a root pipeline whose backend leaves out the project ID,
+// with a child pipeline and a multi-project downstream pipeline
+type treeClient struct {
+	MockClient
+	projectErr error            // GetProject fails with it if set
+	downstream []Pipeline       // Downstream pipelines of the root
+	bridges    map[int][]string // Projects bridges were listed in, by pipeline
+}
+
+func (c *treeClient) GetProject(ctx context.Context, project string) (*Project, error) {
+	if c.projectErr != nil {
+		return nil, c.projectErr
+	}
+	return &Project{ID: 10, PathWithNamespace: "group/app"}, nil
+}
+
+func (c *treeClient) GetPipeline(ctx context.Context, project string, pipelineID int) (*Pipeline, error) {
+	return &Pipeline{ID: pipelineID, WebURL: "https://gitlab.example.com/group/app/-/pipelines/1"}, nil
+}
+
+func (c *treeClient) ListPipelineBridges(ctx context.Context, project string, pipelineID int, opts JobListOptions) ([]Bridge, error) {
+	c.bridges[pipelineID] = append(c.bridges[pipelineID], project)
+	if pipelineID != 1 {
+		return nil, nil
+	}
+	var bridges []Bridge
+	for i := range c.downstream {
+		bridges = append(bridges, Bridge{Job: Job{ID: 100 + i}, Downstream: &c.downstream[i]})
+	}
+	return bridges, nil
+}
+
+func TestPipelineTree_MultiProject(t *testing.T) {
+	const appURL, libURL = "https://gitlab.example.com/group/app", "https://gitlab.example.com/group/lib"
+
+	tests := []struct {
+		name        string
+		projectErr  error
+		downstream  Pipeline
+		wantMulti   bool
+		wantProject string
+	}{
+		{"child with project ID", nil, Pipeline{ID: 2, ProjectID: 10}, false, "group/app"},
+		{"other project by ID", nil, Pipeline{ID: 2, ProjectID: 20}, true, "20"},
+		{"root project unknown, same web URL", errors.New("forbidden"), Pipeline{ID: 2, ProjectID: 10, WebURL: appURL + "/-/pipelines/2"}, false, "group/app"},
+		{"root project unknown, other web URL", errors.New("forbidden"), Pipeline{ID: 2, ProjectID: 20, WebURL: libURL + "/-/pipelines/2"}, true, "20"},
+		{"root project unknown, no web URL", errors.New("forbidden"), Pipeline{ID: 2, ProjectID: 20}, false, "group/app"},
+		{"downstream ID unknown, other web URL", nil, Pipeline{ID: 2, WebURL: libURL + "/-/pipelines/2"}, true, "group/lib"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &treeClient{projectErr: tt.projectErr, downstream: []Pipeline{tt.downstream}, bridges: map[int][]string{}}
+			service := NewService(nil, client, "group/app")
+
+			tree, err := service.PipelineTree(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("PipelineTree() error = %v", err)
+			}
+			if len(tree.Children) != 1 {
+				t.Fatalf("tree has %d children, want 1", len(tree.Children))
+			}
+			child := tree.Children[0]
+			if child.MultiProject != tt.wantMulti || child.Project != tt.wantProject {
+				t.Errorf("child MultiProject = %v in %q, want %v in %q", child.MultiProject, child.Project, tt.wantMulti, tt.wantProject)
+			}
+			if got := client.bridges[2]; len(got) != 1 || got[0] != tt.wantProject {
+				t.Errorf("bridges of the child listed in %q, want %q", got, tt.wantProject)
+			}
+		})
+	}
+}
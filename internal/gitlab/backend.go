@@ -0,0 +1,70 @@
+package gitlab
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/rkristelijn/glab-tui/internal/api"
+	"github.com/rkristelijn/glab-tui/internal/config"
+	"github.com/rkristelijn/glab-tui/internal/core"
+)
+
+// NewBackend picks the GitLab transport configured by GITLAB_BACKEND.
+// In auto mode the glab CLI is preferred because it handles authentication
+// itself; without it we fall back to the REST API and the configured token.
+func NewBackend(cfg *config.Config, projectPath string) (core.GitLabClient, error) {
+	switch cfg.GitLab.Backend {
+	case "glab":
+		return NewGlabWrapper(projectPath), nil
+	case "api":
+		if !hasToken(cfg) {
+			return nil, fmt.Errorf("the api backend needs GITLAB_TOKEN")
+		}
+		return api.NewGitLabClientWithToken(cfg.GitLab.URL, cfg.GitLab.Token), nil
+	case "go-gitlab":
+		if !hasToken(cfg) {
+			return nil, fmt.Errorf("the go-gitlab backend needs GITLAB_TOKEN")
+		}
+		return NewClient(cfg)
+	case "", "auto":
+		if _, err := exec.LookPath("glab"); err == nil {
+			return NewGlabWrapper(projectPath), nil
+		}
+		if hasToken(cfg) {
+			return api.NewGitLabClientWithToken(cfg.GitLab.URL, cfg.GitLab.Token), nil
+		}
+		return nil, fmt.Errorf("no GitLab access: install glab and run 'glab auth login', or set GITLAB_TOKEN")
+	default:
+		return nil, fmt.Errorf("unknown GITLAB_BACKEND %q (want auto, glab, api or go-gitlab)", cfg.GitLab.Backend)
+	}
+}
+
+// NewService loads the configured backend and scopes a service to the project
+func NewService(cfg *config.Config, project string) (*core.Service, error) {
+	project, err := core.ResolveProject(cfg, project)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := NewBackend(cfg, project)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.NewService(cfg, backend, project), nil
+}
+
+func hasToken(cfg *config.Config) bool {
+	return cfg.GitLab.Token != "" && cfg.GitLab.Token != "your-token-here"
+}
+
+// NewGroupService loads the configured backend for views spanning many
+// projects, like the dashboard. The service isn't scoped to a project.
+func NewGroupService(cfg *config.Config) (*core.Service, error) {
+	backend, err := NewBackend(cfg, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return core.NewService(cfg, backend, ""), nil
+}
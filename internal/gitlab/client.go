@@ -1,84 +1,676 @@
 package gitlab
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"time"
 
+	"github.com/rkristelijn/glab-tui/internal/api"
 	"github.com/rkristelijn/glab-tui/internal/config"
 	"github.com/rkristelijn/glab-tui/internal/core"
 	"github.com/xanzy/go-gitlab"
 )
 
 type Client struct {
-	client *gitlab.Client
-	config *config.Config
+	client    *gitlab.Client
+	config    *config.Config
+	transport *api.Transport
 }
 
+// Ensure Client satisfies the core backend interface
+var _ core.GitLabClient = (*Client)(nil)
+
 func NewClient(cfg *config.Config) (*Client, error) {
-	client, err := gitlab.NewClient(cfg.GitLab.Token, gitlab.WithBaseURL(cfg.GitLab.URL))
+	// Share the REST client's transport so retries, backoff and the
+	// rate-limit budget behave the same on both backends
+	transport := api.NewTransport(nil)
+	client, err := gitlab.NewClient(cfg.GitLab.Token,
+		gitlab.WithBaseURL(cfg.GitLab.URL),
+		gitlab.WithHTTPClient(&http.Client{Transport: transport}),
+		gitlab.WithoutRetries(),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GitLab client: %w", err)
 	}
 
 	return &Client{
-		client: client,
-		config: cfg,
+		client:    client,
+		config:    cfg,
+		transport: transport,
 	}, nil
 }
 
-// GetJob fetches a specific job by ID
-func (c *Client) GetJob(projectID, jobID int) (interface{}, error) {
-	job, _, err := c.client.Jobs.GetJob(projectID, jobID)
+// RateLimit returns the last observed API budget
+func (c *Client) RateLimit() (core.RateLimit, bool) {
+	return c.transport.RateLimit()
+}
+
+// GetProject fetches project details by path (e.g. "group/project/frontend-apps") or ID
+func (c *Client) GetProject(ctx context.Context, project string) (*core.Project, error) {
+	p, resp, err := c.client.Projects.GetProject(project, nil, gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get job %d from project %d: %w", jobID, projectID, err)
+		return nil, wrapError("get project "+project, resp, err)
 	}
-	return job, nil
+
+	result := convertProject(p)
+	return &result, nil
 }
 
-// GetProjectPipelines fetches recent pipelines for a project
-func (c *Client) GetProjectPipelines(projectID int) ([]core.Pipeline, error) {
-	opts := &gitlab.ListProjectPipelinesOptions{
+// ListGroupProjects fetches the projects of a group, most recently active
+// first, following pagination up to opts.Limit or the first inactive project
+func (c *Client) ListGroupProjects(ctx context.Context, group string, opts core.ProjectListOptions) ([]core.Project, error) {
+	listOpts := &gitlab.ListGroupProjectsOptions{
 		ListOptions: gitlab.ListOptions{
-			PerPage: c.config.UI.MaxPipelinesPerProject,
+			PerPage: core.PageSize(opts.PerPage, opts.Limit),
+			Page:    1,
+		},
+		OrderBy: gitlab.String("last_activity_at"),
+		Sort:    gitlab.String("desc"),
+	}
+	if opts.IncludeSubgroups {
+		listOpts.IncludeSubGroups = gitlab.Bool(true)
+	}
+	if !opts.Archived {
+		listOpts.Archived = gitlab.Bool(false)
+	}
+
+	var result []core.Project
+	for {
+		projects, resp, err := c.client.Groups.ListGroupProjects(group, listOpts, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, wrapError("list projects of group "+group, resp, err)
+		}
+
+		for _, p := range projects {
+			project := convertProject(p)
+			if opts.Inactive(project) {
+				return result, nil
+			}
+			result = append(result, project)
+			if opts.Limit > 0 && len(result) == opts.Limit {
+				return result, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return result, nil
+		}
+		listOpts.Page = resp.NextPage
+	}
+}
+
+// ListPipelines fetches recent pipelines for a project, following pagination
+// up to opts.Limit
+func (c *Client) ListPipelines(ctx context.Context, project string, opts core.PipelineListOptions) ([]core.Pipeline, error) {
+	listOpts := &gitlab.ListProjectPipelinesOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: core.PageSize(opts.PerPage, opts.Limit),
 			Page:    1,
 		},
 		OrderBy: gitlab.String("updated_at"),
 		Sort:    gitlab.String("desc"),
 	}
+	if opts.Ref != "" {
+		listOpts.Ref = gitlab.String(opts.Ref)
+	}
+	if opts.Status != "" {
+		listOpts.Status = gitlab.BuildState(gitlab.BuildStateValue(opts.Status))
+	}
+
+	var result []core.Pipeline
+	for {
+		pipelines, resp, err := c.client.Pipelines.ListProjectPipelines(project, listOpts, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, wrapError("list pipelines for "+project, resp, err)
+		}
+
+		for _, p := range pipelines {
+			pipeline := convertPipelineInfo(p)
+			pipeline.Jobs = "loading..." // We'd need another API call to get job count
+			result = append(result, pipeline)
+			if opts.Limit > 0 && len(result) == opts.Limit {
+				return result, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return result, nil
+		}
+		listOpts.Page = resp.NextPage
+	}
+}
 
-	pipelines, _, err := c.client.Pipelines.ListProjectPipelines(projectID, opts)
+// GetPipeline fetches a single pipeline
+func (c *Client) GetPipeline(ctx context.Context, project string, pipelineID int) (*core.Pipeline, error) {
+	p, resp, err := c.client.Pipelines.GetPipeline(project, pipelineID, gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pipelines for project %d: %w", projectID, err)
+		return nil, wrapError(fmt.Sprintf("get pipeline %d", pipelineID), resp, err)
 	}
 
-	var result []core.Pipeline
-	for _, p := range pipelines {
-		result = append(result, core.Pipeline{
-			ID:        p.ID,
-			Status:    p.Status,
-			Ref:       p.Ref,
-			WebURL:    p.WebURL,
-			ProjectID: projectID,
-			Jobs:      "loading...", // We'd need another API call to get job count
-		})
+	result := convertPipeline(p)
+	return &result, nil
+}
+
+// ListPipelineJobs fetches the jobs of a pipeline, following pagination up
+// to opts.Limit
+func (c *Client) ListPipelineJobs(ctx context.Context, project string, pipelineID int, opts core.JobListOptions) ([]core.Job, error) {
+	listOpts := &gitlab.ListJobsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: core.PageSize(opts.PerPage, opts.Limit),
+			Page:    1,
+		},
+	}
+	if opts.IncludeRetried {
+		listOpts.IncludeRetried = gitlab.Ptr(true)
+	}
+
+	var result []core.Job
+	for {
+		jobs, resp, err := c.client.Jobs.ListPipelineJobs(project, pipelineID, listOpts, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, wrapError(fmt.Sprintf("list jobs for pipeline %d", pipelineID), resp, err)
+		}
+
+		for _, j := range jobs {
+			result = append(result, convertJob(j))
+			if opts.Limit > 0 && len(result) == opts.Limit {
+				return result, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return result, nil
+		}
+		listOpts.Page = resp.NextPage
+	}
+}
+
+// ListPipelineBridges fetches the trigger jobs of a pipeline, following
+// pagination up to opts.Limit
+func (c *Client) ListPipelineBridges(ctx context.Context, project string, pipelineID int, opts core.JobListOptions) ([]core.Bridge, error) {
+	listOpts := &gitlab.ListJobsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: core.PageSize(opts.PerPage, opts.Limit),
+			Page:    1,
+		},
+	}
+
+	var result []core.Bridge
+	for {
+		bridges, resp, err := c.client.Jobs.ListPipelineBridges(project, pipelineID, listOpts, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, wrapError(fmt.Sprintf("list bridges for pipeline %d", pipelineID), resp, err)
+		}
+
+		for _, b := range bridges {
+			result = append(result, convertBridge(b))
+			if opts.Limit > 0 && len(result) == opts.Limit {
+				return result, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return result, nil
+		}
+		listOpts.Page = resp.NextPage
+	}
+}
+
+// ListBranches fetches the branches of a project, following pagination up
+// to opts.Limit
+func (c *Client) ListBranches(ctx context.Context, project string, opts core.BranchListOptions) ([]core.Branch, error) {
+	listOpts := &gitlab.ListBranchesOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: core.PageSize(opts.PerPage, opts.Limit),
+			Page:    1,
+		},
+	}
+	if opts.Search != "" {
+		listOpts.Search = gitlab.Ptr(opts.Search)
+	}
+
+	var result []core.Branch
+	for {
+		branches, resp, err := c.client.Branches.ListBranches(project, listOpts, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, wrapError("list branches for "+project, resp, err)
+		}
+
+		for _, b := range branches {
+			result = append(result, core.Branch{Name: b.Name, Default: b.Default, Protected: b.Protected})
+			if opts.Limit > 0 && len(result) == opts.Limit {
+				return result, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return result, nil
+		}
+		listOpts.Page = resp.NextPage
+	}
+}
+
+// ListMergeRequests fetches the merge requests of a project, most recently
+// updated first, following pagination up to opts.Limit
+func (c *Client) ListMergeRequests(ctx context.Context, project string, opts core.MergeRequestListOptions) ([]core.MergeRequest, error) {
+	listOpts := &gitlab.ListProjectMergeRequestsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: core.PageSize(opts.PerPage, opts.Limit),
+			Page:    1,
+		},
+		OrderBy: gitlab.Ptr("updated_at"),
+		Sort:    gitlab.Ptr("desc"),
+	}
+	if opts.State != "" {
+		listOpts.State = gitlab.Ptr(opts.State)
+	}
+
+	var result []core.MergeRequest
+	for {
+		mrs, resp, err := c.client.MergeRequests.ListProjectMergeRequests(project, listOpts, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, wrapError("list merge requests for "+project, resp, err)
+		}
+
+		for _, mr := range mrs {
+			result = append(result, convertMergeRequest(mr))
+			if opts.Limit > 0 && len(result) == opts.Limit {
+				return result, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return result, nil
+		}
+		listOpts.Page = resp.NextPage
+	}
+}
+
+// ListMergeRequestPipelines fetches the pipelines of a merge request. go-gitlab
+// doesn't page this endpoint, so only GitLab's first page is returned.
+func (c *Client) ListMergeRequestPipelines(ctx context.Context, project string, iid int, opts core.PipelineListOptions) ([]core.Pipeline, error) {
+	pipelines, resp, err := c.client.MergeRequests.ListMergeRequestPipelines(project, iid, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, wrapError(fmt.Sprintf("list pipelines of merge request !%d", iid), resp, err)
 	}
 
+	result := make([]core.Pipeline, 0, len(pipelines))
+	for _, p := range pipelines {
+		result = append(result, convertPipelineInfo(p))
+		if opts.Limit > 0 && len(result) == opts.Limit {
+			break
+		}
+	}
 	return result, nil
 }
 
-// GetProject fetches project details
-func (c *Client) GetProject(projectID int) (interface{}, error) {
-	project, _, err := c.client.Projects.GetProject(projectID, nil)
+// GetMergeRequestApprovals fetches the approval state of a merge request
+func (c *Client) GetMergeRequestApprovals(ctx context.Context, project string, iid int) (*core.MergeRequestApproval, error) {
+	a, resp, err := c.client.MergeRequestApprovals.GetConfiguration(project, iid, gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project %d: %w", projectID, err)
+		return nil, wrapError(fmt.Sprintf("get approvals of merge request !%d", iid), resp, err)
+	}
+
+	result := core.MergeRequestApproval{Required: a.ApprovalsRequired, Left: a.ApprovalsLeft}
+	for _, by := range a.ApprovedBy {
+		if by.User != nil {
+			result.By = append(result.By, by.User.Username)
+		}
 	}
-	return project, nil
+	return &result, nil
 }
 
-// GetProjectByPath fetches project by path (e.g., "group/project/frontend-apps")
-func (c *Client) GetProjectByPath(path string) (interface{}, error) {
-	project, _, err := c.client.Projects.GetProject(path, nil)
+// GetJob fetches a specific job by ID
+func (c *Client) GetJob(ctx context.Context, project string, jobID int) (*core.Job, error) {
+	job, resp, err := c.client.Jobs.GetJob(project, jobID, gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project %s: %w", path, err)
+		return nil, wrapError(fmt.Sprintf("get job %d", jobID), resp, err)
+	}
+
+	result := convertJob(job)
+	return &result, nil
+}
+
+// GetJobTrace fetches the log output of a job
+func (c *Client) GetJobTrace(ctx context.Context, project string, jobID int) (string, error) {
+	trace, resp, err := c.client.Jobs.GetTraceFile(project, jobID, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", wrapError(fmt.Sprintf("get trace of job %d", jobID), resp, err)
+	}
+
+	body, err := io.ReadAll(trace)
+	if err != nil {
+		return "", core.WrapError(fmt.Sprintf("get trace of job %d", jobID), err)
+	}
+	return string(body), nil
+}
+
+// GetJobArtifacts streams the artifacts archive of a job to w. go-gitlab
+// buffers downloads in memory, so the request is built by hand.
+func (c *Client) GetJobArtifacts(ctx context.Context, project string, jobID int, w io.Writer) error {
+	path := fmt.Sprintf("projects/%s/jobs/%d/artifacts", gitlab.PathEscape(project), jobID)
+	return c.download(ctx, fmt.Sprintf("get artifacts of job %d", jobID), path, w)
+}
+
+// GetJobArtifactFile streams one file of the artifacts of a job to w
+func (c *Client) GetJobArtifactFile(ctx context.Context, project string, jobID int, path string, w io.Writer) error {
+	apiPath := fmt.Sprintf("projects/%s/jobs/%d/artifacts/%s", gitlab.PathEscape(project), jobID, api.ArtifactPath(path))
+	return c.download(ctx, fmt.Sprintf("get artifact %s of job %d", path, jobID), apiPath, w)
+}
+
+// download streams the body of a GET request to w
+func (c *Client) download(ctx context.Context, op, path string, w io.Writer) error {
+	req, err := c.client.NewRequest(http.MethodGet, path, nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return core.WrapError(op, err)
+	}
+	resp, err := c.client.Do(req, w)
+	if err != nil {
+		return wrapError(op, resp, err)
+	}
+	return nil
+}
+
+// GetPipelineTestReport fetches the JUnit test report of a pipeline
+func (c *Client) GetPipelineTestReport(ctx context.Context, project string, pipelineID int) (*core.TestReport, error) {
+	r, resp, err := c.client.Pipelines.GetPipelineTestReport(project, pipelineID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, wrapError(fmt.Sprintf("get test report of pipeline %d", pipelineID), resp, err)
+	}
+
+	result := convertTestReport(r)
+	return &result, nil
+}
+
+// GetPipelineTestReportSummary fetches the per-suite test counts of a
+// pipeline. go-gitlab has no method for this endpoint, so the request is
+// built by hand.
+func (c *Client) GetPipelineTestReportSummary(ctx context.Context, project string, pipelineID int) (*core.TestReport, error) {
+	op := fmt.Sprintf("get test report summary of pipeline %d", pipelineID)
+	path := fmt.Sprintf("projects/%s/pipelines/%d/test_report_summary", gitlab.PathEscape(project), pipelineID)
+	req, err := c.client.NewRequest(http.MethodGet, path, nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, core.WrapError(op, err)
+	}
+
+	var r api.TestReportSummary
+	resp, err := c.client.Do(req, &r)
+	if err != nil {
+		return nil, wrapError(op, resp, err)
+	}
+
+	result := r.ToCore()
+	return &result, nil
+}
+
+// CreatePipeline runs a new pipeline for a branch or tag
+func (c *Client) CreatePipeline(ctx context.Context, project, ref string, variables []core.JobVariable) (*core.Pipeline, error) {
+	opts := &gitlab.CreatePipelineOptions{Ref: gitlab.Ptr(ref)}
+	if len(variables) > 0 {
+		vars := make([]*gitlab.PipelineVariableOptions, 0, len(variables))
+		for _, v := range variables {
+			vars = append(vars, &gitlab.PipelineVariableOptions{
+				Key:          gitlab.Ptr(v.Key),
+				Value:        gitlab.Ptr(v.Value),
+				VariableType: gitlab.Ptr(gitlab.EnvVariableType),
+			})
+		}
+		opts.Variables = &vars
+	}
+
+	p, resp, err := c.client.Pipelines.CreatePipeline(project, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, wrapError("run pipeline for "+ref, resp, err)
+	}
+
+	result := convertPipeline(p)
+	return &result, nil
+}
+
+// RetryPipeline reruns the failed and canceled jobs of a pipeline
+func (c *Client) RetryPipeline(ctx context.Context, project string, pipelineID int) (*core.Pipeline, error) {
+	p, resp, err := c.client.Pipelines.RetryPipelineBuild(project, pipelineID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, wrapError(fmt.Sprintf("retry pipeline %d", pipelineID), resp, err)
+	}
+
+	result := convertPipeline(p)
+	return &result, nil
+}
+
+// CancelPipeline cancels the running and pending jobs of a pipeline
+func (c *Client) CancelPipeline(ctx context.Context, project string, pipelineID int) (*core.Pipeline, error) {
+	p, resp, err := c.client.Pipelines.CancelPipelineBuild(project, pipelineID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, wrapError(fmt.Sprintf("cancel pipeline %d", pipelineID), resp, err)
+	}
+
+	result := convertPipeline(p)
+	return &result, nil
+}
+
+// DeletePipeline deletes a pipeline with its jobs and logs
+func (c *Client) DeletePipeline(ctx context.Context, project string, pipelineID int) error {
+	resp, err := c.client.Pipelines.DeletePipeline(project, pipelineID, gitlab.WithContext(ctx))
+	if err != nil {
+		return wrapError(fmt.Sprintf("delete pipeline %d", pipelineID), resp, err)
+	}
+	return nil
+}
+
+// RetryJob reruns a job and returns the new job
+func (c *Client) RetryJob(ctx context.Context, project string, jobID int) (*core.Job, error) {
+	job, resp, err := c.client.Jobs.RetryJob(project, jobID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, wrapError(fmt.Sprintf("retry job %d", jobID), resp, err)
+	}
+
+	result := convertJob(job)
+	return &result, nil
+}
+
+// CancelJob cancels a running or pending job
+func (c *Client) CancelJob(ctx context.Context, project string, jobID int) (*core.Job, error) {
+	job, resp, err := c.client.Jobs.CancelJob(project, jobID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, wrapError(fmt.Sprintf("cancel job %d", jobID), resp, err)
+	}
+
+	result := convertJob(job)
+	return &result, nil
+}
+
+// PlayJob starts a manual job with optional variables
+func (c *Client) PlayJob(ctx context.Context, project string, jobID int, variables []core.JobVariable) (*core.Job, error) {
+	opts := &gitlab.PlayJobOptions{}
+	if len(variables) > 0 {
+		attributes := make([]*gitlab.JobVariableOptions, 0, len(variables))
+		for _, v := range variables {
+			attributes = append(attributes, &gitlab.JobVariableOptions{
+				Key:   gitlab.Ptr(v.Key),
+				Value: gitlab.Ptr(v.Value),
+			})
+		}
+		opts.JobVariablesAttributes = &attributes
+	}
+
+	job, resp, err := c.client.Jobs.PlayJob(project, jobID, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, wrapError(fmt.Sprintf("play job %d", jobID), resp, err)
+	}
+
+	result := convertJob(job)
+	return &result, nil
+}
+
+func convertProject(p *gitlab.Project) core.Project {
+	lastActivity := ""
+	if p.LastActivityAt != nil {
+		lastActivity = p.LastActivityAt.Format(time.RFC3339)
+	}
+	return core.Project{
+		ID:                p.ID,
+		Name:              p.Name,
+		NameWithNamespace: p.NameWithNamespace,
+		PathWithNamespace: p.PathWithNamespace,
+		DefaultBranch:     p.DefaultBranch,
+		WebURL:            p.WebURL,
+		LastActivityAt:    lastActivity,
+		Archived:          p.Archived,
+	}
+}
+
+func convertMergeRequest(mr *gitlab.MergeRequest) core.MergeRequest {
+	result := core.MergeRequest{
+		ID:           mr.ID,
+		IID:          mr.IID,
+		ProjectID:    mr.ProjectID,
+		Title:        mr.Title,
+		SourceBranch: mr.SourceBranch,
+		TargetBranch: mr.TargetBranch,
+		State:        mr.State,
+		Draft:        mr.Draft,
+		WebURL:       mr.WebURL,
+		UpdatedAt:    timeValue(mr.UpdatedAt),
+	}
+	if mr.Author != nil {
+		result.Author = mr.Author.Username
+	}
+	return result
+}
+
+func convertPipeline(p *gitlab.Pipeline) core.Pipeline {
+	return core.Pipeline{
+		ID:              p.ID,
+		IID:             p.IID,
+		Status:          p.Status,
+		Ref:             p.Ref,
+		SHA:             p.SHA,
+		Source:          p.Source,
+		WebURL:          p.WebURL,
+		ProjectID:       p.ProjectID,
+		CreatedAt:       timeValue(p.CreatedAt),
+		UpdatedAt:       timeValue(p.UpdatedAt),
+		Duration:        core.FormatDuration(float64(p.Duration)),
+		DurationSeconds: float64(p.Duration),
+		QueuedSeconds:   float64(p.QueuedDuration),
+	}
+}
+
+func convertPipelineInfo(p *gitlab.PipelineInfo) core.Pipeline {
+	return core.Pipeline{
+		ID:        p.ID,
+		IID:       p.IID,
+		Status:    p.Status,
+		Ref:       p.Ref,
+		SHA:       p.SHA,
+		Source:    p.Source,
+		WebURL:    p.WebURL,
+		ProjectID: p.ProjectID,
+		CreatedAt: timeValue(p.CreatedAt),
+		UpdatedAt: timeValue(p.UpdatedAt),
+	}
+}
+
+func convertJob(j *gitlab.Job) core.Job {
+	return core.Job{
+		ID:              j.ID,
+		Name:            j.Name,
+		Status:          j.Status,
+		Stage:           j.Stage,
+		Duration:        core.FormatDuration(j.Duration),
+		DurationSeconds: j.Duration,
+		QueuedSeconds:   j.QueuedDuration,
+		Ref:             j.Ref,
+		WebURL:          j.WebURL,
+		PipelineID:      j.Pipeline.ID,
+		ProjectID:       j.Pipeline.ProjectID,
+	}
+}
+
+func convertBridge(b *gitlab.Bridge) core.Bridge {
+	bridge := core.Bridge{
+		Job: core.Job{
+			ID:              b.ID,
+			Name:            b.Name,
+			Status:          b.Status,
+			Stage:           b.Stage,
+			Duration:        core.FormatDuration(b.Duration),
+			DurationSeconds: b.Duration,
+			QueuedSeconds:   b.QueuedDuration,
+			Ref:             b.Ref,
+			WebURL:          b.WebURL,
+			PipelineID:      b.Pipeline.ID,
+			ProjectID:       b.Pipeline.ProjectID,
+		},
+	}
+	if b.DownstreamPipeline != nil {
+		downstream := convertPipelineInfo(b.DownstreamPipeline)
+		bridge.Downstream = &downstream
+	}
+	return bridge
+}
+
+func convertTestReport(r *gitlab.PipelineTestReport) core.TestReport {
+	report := core.TestReport{TestCounts: core.TestCounts{
+		Total:   r.TotalCount,
+		Success: r.SuccessCount,
+		Failed:  r.FailedCount,
+		Skipped: r.SkippedCount,
+		Error:   r.ErrorCount,
+		Time:    r.TotalTime,
+	}}
+	for _, s := range r.TestSuites {
+		suite := core.TestSuite{
+			Name: s.Name,
+			TestCounts: core.TestCounts{
+				Total:   s.TotalCount,
+				Success: s.SuccessCount,
+				Failed:  s.FailedCount,
+				Skipped: s.SkippedCount,
+				Error:   s.ErrorCount,
+				Time:    s.TotalTime,
+			},
+		}
+		for _, c := range s.TestCases {
+			suite.Cases = append(suite.Cases, core.TestCase{
+				Status:       c.Status,
+				Name:         c.Name,
+				Classname:    c.Classname,
+				File:         c.File,
+				Time:         c.ExecutionTime,
+				SystemOutput: api.SystemOutput(c.SystemOutput),
+				StackTrace:   c.StackTrace,
+			})
+		}
+		report.Suites = append(report.Suites, suite)
+	}
+	return report
+}
+
+func timeValue(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// wrapError converts go-gitlab errors into structured core errors
+func wrapError(op string, resp *gitlab.Response, err error) error {
+	if errors.Is(err, gitlab.ErrNotFound) {
+		return core.NewAPIError(op, http.StatusNotFound, "")
+	}
+
+	var errResp *gitlab.ErrorResponse
+	if errors.As(err, &errResp) {
+		return core.NewAPIError(op, errResp.Response.StatusCode, errResp.Message)
+	}
+
+	if resp != nil && resp.StatusCode >= 400 {
+		return &core.APIError{Op: op, StatusCode: resp.StatusCode, Err: err}
 	}
-	return project, nil
+	return core.WrapError(op, err)
 }
@@ -1,15 +1,25 @@
 package gitlab
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os/exec"
+	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/rkristelijn/glab-tui/internal/api"
 	"github.com/rkristelijn/glab-tui/internal/core"
 )
 
+// httpStatusPattern matches the status glab prints for failed API calls, e.g. "(HTTP 404)"
+var httpStatusPattern = regexp.MustCompile(`HTTP (\d{3})`)
+
 // GlabWrapper uses the glab CLI to interact with GitLab
 type GlabWrapper struct {
 	projectPath string
@@ -21,187 +31,508 @@ func NewGlabWrapper(projectPath string) *GlabWrapper {
 	}
 }
 
-// GetProjectPipelines fetches pipelines using glab CLI
-func (g *GlabWrapper) GetProjectPipelines(projectID int) ([]core.Pipeline, error) {
-	cmd := exec.Command("glab", "pipeline", "list", "-R", g.projectPath, "--output", "json")
-	output, err := cmd.Output()
+// Ensure GlabWrapper satisfies the core backend interfaces
+var (
+	_ core.GitLabClient   = (*GlabWrapper)(nil)
+	_ core.JobNeedsReader = (*GlabWrapper)(nil)
+)
+
+// GetProject returns project info by path or ID
+func (g *GlabWrapper) GetProject(ctx context.Context, project string) (*core.Project, error) {
+	project = g.resolve(project)
+
+	var p api.Project
+	if err := g.api(ctx, "get project "+project, projectPath(project), &p); err != nil {
+		return nil, err
+	}
+	result := p.ToCore()
+	return &result, nil
+}
+
+// ListGroupProjects fetches the projects of a group using glab CLI
+func (g *GlabWrapper) ListGroupProjects(ctx context.Context, group string, opts core.ProjectListOptions) ([]core.Project, error) {
+	query := url.Values{}
+	query.Set("order_by", "last_activity_at")
+	query.Set("sort", "desc")
+	if opts.IncludeSubgroups {
+		query.Set("include_subgroups", "true")
+	}
+	if !opts.Archived {
+		query.Set("archived", "false")
+	}
+
+	inactive := func(p api.Project) bool { return opts.Inactive(p.ToCore()) }
+	glabProjects, err := apiListUntil(ctx, g, "list projects of group "+group, "groups/"+url.PathEscape(group)+"/projects", query, opts.PerPage, opts.Limit, inactive)
 	if err != nil {
-		return nil, fmt.Errorf("failed to run glab command: %w", err)
+		return nil, err
 	}
 
-	var glabPipelines []struct {
-		ID     int    `json:"id"`
-		Status string `json:"status"`
-		Ref    string `json:"ref"`
-		WebURL string `json:"web_url"`
+	projects := make([]core.Project, 0, len(glabProjects))
+	for _, p := range glabProjects {
+		projects = append(projects, p.ToCore())
 	}
+	return projects, nil
+}
+
+// ListPipelines fetches pipelines using glab CLI
+func (g *GlabWrapper) ListPipelines(ctx context.Context, project string, opts core.PipelineListOptions) ([]core.Pipeline, error) {
+	project = g.resolve(project)
 
-	if err := json.Unmarshal(output, &glabPipelines); err != nil {
-		return nil, fmt.Errorf("failed to parse glab output: %w", err)
+	query := url.Values{}
+	query.Set("order_by", "updated_at")
+	query.Set("sort", "desc")
+	if opts.Ref != "" {
+		query.Set("ref", opts.Ref)
+	}
+	if opts.Status != "" {
+		query.Set("status", opts.Status)
 	}
 
-	var pipelines []core.Pipeline
+	glabPipelines, err := apiList[api.Pipeline](ctx, g, "list pipelines for "+project, projectPath(project)+"/pipelines", query, opts.PerPage, opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines := make([]core.Pipeline, 0, len(glabPipelines))
 	for _, p := range glabPipelines {
-		pipelines = append(pipelines, core.Pipeline{
-			ID:          p.ID,
-			Status:      p.Status,
-			Ref:         p.Ref,
-			WebURL:      p.WebURL,
-			ProjectID:   projectID,
-			ProjectName: "frontend-apps",
-			Jobs:        "loading...",
-		})
+		pipeline := p.ToCore()
+		pipeline.Jobs = "loading..."
+		pipelines = append(pipelines, pipeline)
 	}
 
 	return pipelines, nil
 }
 
-// GetJob fetches job info using glab CLI
-func (g *GlabWrapper) GetJob(projectID, jobID int) (interface{}, error) {
-	// Use glab to get job info - this is tricky since glab doesn't have direct job info
-	// For now, let's return a mock job with the ID
-	return &struct {
-		ID     int    `json:"id"`
-		Status string `json:"status"`
-	}{
-		ID:     jobID,
-		Status: "unknown", // We'd need to parse pipeline info to get job status
-	}, nil
-}
-
-// GetProject returns project info
-func (g *GlabWrapper) GetProject(projectID int) (interface{}, error) {
-	cmd := exec.Command("glab", "project", "view", g.projectPath, "--output", "json")
-	output, err := cmd.Output()
+// GetPipeline fetches a single pipeline using glab CLI
+func (g *GlabWrapper) GetPipeline(ctx context.Context, project string, pipelineID int) (*core.Pipeline, error) {
+	project = g.resolve(project)
+
+	var p api.Pipeline
+	if err := g.api(ctx, fmt.Sprintf("get pipeline %d", pipelineID), fmt.Sprintf("%s/pipelines/%d", projectPath(project), pipelineID), &p); err != nil {
+		return nil, err
+	}
+	result := p.ToCore()
+	return &result, nil
+}
+
+// ListPipelineJobs fetches jobs for a specific pipeline using glab CLI
+func (g *GlabWrapper) ListPipelineJobs(ctx context.Context, project string, pipelineID int, opts core.JobListOptions) ([]core.Job, error) {
+	project = g.resolve(project)
+
+	query := url.Values{}
+	if opts.IncludeRetried {
+		query.Set("include_retried", "true")
+	}
+
+	glabJobs, err := apiList[api.Job](ctx, g, fmt.Sprintf("list jobs for pipeline %d", pipelineID), fmt.Sprintf("%s/pipelines/%d/jobs", projectPath(project), pipelineID), query, opts.PerPage, opts.Limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project info: %w", err)
+		return nil, err
 	}
 
-	var project struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
+	jobs := make([]core.Job, 0, len(glabJobs))
+	for _, j := range glabJobs {
+		jobs = append(jobs, j.ToCore())
 	}
+	return jobs, nil
+}
+
+// ListPipelineBridges fetches the trigger jobs of a pipeline using glab CLI
+func (g *GlabWrapper) ListPipelineBridges(ctx context.Context, project string, pipelineID int, opts core.JobListOptions) ([]core.Bridge, error) {
+	project = g.resolve(project)
 
-	if err := json.Unmarshal(output, &project); err != nil {
-		return nil, fmt.Errorf("failed to parse project info: %w", err)
+	glabBridges, err := apiList[api.Bridge](ctx, g, fmt.Sprintf("list bridges for pipeline %d", pipelineID), fmt.Sprintf("%s/pipelines/%d/bridges", projectPath(project), pipelineID), nil, opts.PerPage, opts.Limit)
+	if err != nil {
+		return nil, err
 	}
 
-	return &project, nil
+	bridges := make([]core.Bridge, 0, len(glabBridges))
+	for _, b := range glabBridges {
+		bridges = append(bridges, b.ToCore())
+	}
+	return bridges, nil
 }
 
-// GetProjectByPath returns project info by path
-func (g *GlabWrapper) GetProjectByPath(path string) (interface{}, error) {
-	return g.GetProject(0) // Use the configured project
+// ListBranches fetches the branches of a project using glab CLI
+func (g *GlabWrapper) ListBranches(ctx context.Context, project string, opts core.BranchListOptions) ([]core.Branch, error) {
+	project = g.resolve(project)
+
+	query := url.Values{}
+	if opts.Search != "" {
+		query.Set("search", opts.Search)
+	}
+
+	glabBranches, err := apiList[api.Branch](ctx, g, "list branches for "+project, projectPath(project)+"/repository/branches", query, opts.PerPage, opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]core.Branch, 0, len(glabBranches))
+	for _, b := range glabBranches {
+		branches = append(branches, b.ToCore())
+	}
+	return branches, nil
 }
 
-// GetPipelineJobs fetches jobs for a specific pipeline using glab CLI
-func (g *GlabWrapper) GetPipelineJobs(pipelineID int) ([]core.Job, error) {
-	// Use glab to get pipeline details with jobs
-	cmd := exec.Command("glab", "ci", "view", strconv.Itoa(pipelineID), "-R", g.projectPath)
-	_, err := cmd.Output()
+// ListMergeRequests fetches the merge requests of a project using glab CLI
+func (g *GlabWrapper) ListMergeRequests(ctx context.Context, project string, opts core.MergeRequestListOptions) ([]core.MergeRequest, error) {
+	project = g.resolve(project)
+
+	query := url.Values{}
+	query.Set("order_by", "updated_at")
+	query.Set("sort", "desc")
+	if opts.State != "" {
+		query.Set("state", opts.State)
+	}
+
+	glabMRs, err := apiList[api.MergeRequest](ctx, g, "list merge requests for "+project, projectPath(project)+"/merge_requests", query, opts.PerPage, opts.Limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pipeline jobs: %w", err)
+		return nil, err
 	}
 
-	// For now, return mock jobs - we'll parse the real output later
-	return []core.Job{
-		{ID: 1001, Name: "npm-preparation", Status: "success", Stage: "prepare"},
-		{ID: 1002, Name: "nx-mono-repo-affected", Status: "running", Stage: "build"},
-		{ID: 1003, Name: "cloudflare-deploy", Status: "pending", Stage: "deploy"},
-		{ID: 1004, Name: "zap-security-scan", Status: "pending", Stage: "test"},
-		{ID: 1005, Name: "cypress-e2e", Status: "pending", Stage: "test"},
-	}, nil
+	mrs := make([]core.MergeRequest, 0, len(glabMRs))
+	for _, mr := range glabMRs {
+		mrs = append(mrs, mr.ToCore())
+	}
+	return mrs, nil
 }
 
-// GetJobLogs fetches logs for a specific job using glab CLI
-func (g *GlabWrapper) GetJobLogs(jobID int) (string, error) {
-	// Use glab to get job logs
-	cmd := exec.Command("glab", "ci", "trace", strconv.Itoa(jobID), "-R", g.projectPath)
-	output, err := cmd.Output()
+// ListMergeRequestPipelines fetches the pipelines of a merge request using glab CLI
+func (g *GlabWrapper) ListMergeRequestPipelines(ctx context.Context, project string, iid int, opts core.PipelineListOptions) ([]core.Pipeline, error) {
+	project = g.resolve(project)
+
+	glabPipelines, err := apiList[api.Pipeline](ctx, g, fmt.Sprintf("list pipelines of merge request !%d", iid), fmt.Sprintf("%s/merge_requests/%d/pipelines", projectPath(project), iid), nil, opts.PerPage, opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines := make([]core.Pipeline, 0, len(glabPipelines))
+	for _, p := range glabPipelines {
+		pipelines = append(pipelines, p.ToCore())
+	}
+	return pipelines, nil
+}
+
+// GetMergeRequestApprovals fetches the approval state of a merge request using glab CLI
+func (g *GlabWrapper) GetMergeRequestApprovals(ctx context.Context, project string, iid int) (*core.MergeRequestApproval, error) {
+	project = g.resolve(project)
+
+	var a api.MergeRequestApprovals
+	if err := g.api(ctx, fmt.Sprintf("get approvals of merge request !%d", iid), fmt.Sprintf("%s/merge_requests/%d/approvals", projectPath(project), iid), &a); err != nil {
+		return nil, err
+	}
+	result := a.ToCore()
+	return &result, nil
+}
+
+// ListJobNeeds reads the needs: relations of a pipeline's jobs with 'glab api graphql'
+func (g *GlabWrapper) ListJobNeeds(ctx context.Context, project string, pipelineID int) (map[string][]string, error) {
+	project = g.resolve(project)
+	op := fmt.Sprintf("get job needs for pipeline %d", pipelineID)
+
+	// GraphQL addresses pipelines by project path and IID
+	p, err := g.GetProject(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := g.GetPipeline(ctx, project, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	needs := make(map[string][]string)
+	after := ""
+	for {
+		args := []string{"api", "graphql",
+			"-f", "query=" + api.PipelineNeedsQuery,
+			"-f", "path=" + p.PathWithNamespace,
+			"-f", "iid=" + strconv.Itoa(pipeline.IID),
+		}
+		if after != "" {
+			args = append(args, "-f", "after="+after)
+		}
+
+		output, err := g.run(ctx, op, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp api.GraphQLResponse[api.PipelineNeedsData]
+		if err := json.Unmarshal(output, &resp); err != nil {
+			return nil, core.WrapError(op, fmt.Errorf("failed to parse glab output: %w", err))
+		}
+		if err := resp.Err(); err != nil {
+			return nil, core.WrapError(op, err)
+		}
+
+		after, err = resp.Data.AddTo(op, needs)
+		if err != nil {
+			return nil, err
+		}
+		if after == "" {
+			return needs, nil
+		}
+	}
+}
+
+// GetJob fetches job info using glab CLI
+func (g *GlabWrapper) GetJob(ctx context.Context, project string, jobID int) (*core.Job, error) {
+	project = g.resolve(project)
+
+	var j api.Job
+	if err := g.api(ctx, fmt.Sprintf("get job %d", jobID), fmt.Sprintf("%s/jobs/%d", projectPath(project), jobID), &j); err != nil {
+		return nil, err
+	}
+	result := j.ToCore()
+	return &result, nil
+}
+
+// GetJobTrace fetches logs for a specific job using glab CLI
+func (g *GlabWrapper) GetJobTrace(ctx context.Context, project string, jobID int) (string, error) {
+	project = g.resolve(project)
+
+	output, err := g.run(ctx, fmt.Sprintf("get trace of job %d", jobID), "ci", "trace", strconv.Itoa(jobID), "-R", project)
 	if err != nil {
-		return "", fmt.Errorf("failed to get job logs: %w", err)
+		return "", err
 	}
 
 	return string(output), nil
 }
 
-// GetJobStatus fetches the current status of a job using glab CLI
-func (g *GlabWrapper) GetJobStatus(jobID int) (string, error) {
-	// Use glab API to get job status
-	cmd := exec.Command("glab", "api", fmt.Sprintf("projects/%s/jobs/%d", strings.ReplaceAll(g.projectPath, "/", "%2F"), jobID))
-	output, err := cmd.Output()
+// CreatePipeline runs a new pipeline for a branch or tag
+func (g *GlabWrapper) CreatePipeline(ctx context.Context, project, ref string, variables []core.JobVariable) (*core.Pipeline, error) {
+	var p api.Pipeline
+	if err := g.post(ctx, "run pipeline for "+ref, projectPath(g.resolve(project))+"/pipeline", api.NewCreatePipelineRequest(ref, variables), &p); err != nil {
+		return nil, err
+	}
+	result := p.ToCore()
+	return &result, nil
+}
+
+// GetJobArtifacts streams the artifacts archive of a job to w using glab CLI
+func (g *GlabWrapper) GetJobArtifacts(ctx context.Context, project string, jobID int, w io.Writer) error {
+	path := fmt.Sprintf("%s/jobs/%d/artifacts", projectPath(g.resolve(project)), jobID)
+	return g.runTo(ctx, fmt.Sprintf("get artifacts of job %d", jobID), w, "api", path)
+}
+
+// GetJobArtifactFile streams one file of the artifacts of a job to w using
+// glab CLI
+func (g *GlabWrapper) GetJobArtifactFile(ctx context.Context, project string, jobID int, path string, w io.Writer) error {
+	apiPath := fmt.Sprintf("%s/jobs/%d/artifacts/%s", projectPath(g.resolve(project)), jobID, api.ArtifactPath(path))
+	return g.runTo(ctx, fmt.Sprintf("get artifact %s of job %d", path, jobID), w, "api", apiPath)
+}
+
+// GetPipelineTestReport fetches the JUnit test report of a pipeline using glab CLI
+func (g *GlabWrapper) GetPipelineTestReport(ctx context.Context, project string, pipelineID int) (*core.TestReport, error) {
+	var r api.TestReport
+	if err := g.api(ctx, fmt.Sprintf("get test report of pipeline %d", pipelineID), fmt.Sprintf("%s/pipelines/%d/test_report", projectPath(g.resolve(project)), pipelineID), &r); err != nil {
+		return nil, err
+	}
+	result := r.ToCore()
+	return &result, nil
+}
+
+// GetPipelineTestReportSummary fetches the per-suite test counts of a pipeline using glab CLI
+func (g *GlabWrapper) GetPipelineTestReportSummary(ctx context.Context, project string, pipelineID int) (*core.TestReport, error) {
+	var r api.TestReportSummary
+	if err := g.api(ctx, fmt.Sprintf("get test report summary of pipeline %d", pipelineID), fmt.Sprintf("%s/pipelines/%d/test_report_summary", projectPath(g.resolve(project)), pipelineID), &r); err != nil {
+		return nil, err
+	}
+	result := r.ToCore()
+	return &result, nil
+}
+
+// RetryPipeline reruns the failed and canceled jobs of a pipeline
+func (g *GlabWrapper) RetryPipeline(ctx context.Context, project string, pipelineID int) (*core.Pipeline, error) {
+	return g.pipelineAction(ctx, fmt.Sprintf("retry pipeline %d", pipelineID), fmt.Sprintf("%s/pipelines/%d/retry", projectPath(g.resolve(project)), pipelineID))
+}
+
+// CancelPipeline cancels the running and pending jobs of a pipeline
+func (g *GlabWrapper) CancelPipeline(ctx context.Context, project string, pipelineID int) (*core.Pipeline, error) {
+	return g.pipelineAction(ctx, fmt.Sprintf("cancel pipeline %d", pipelineID), fmt.Sprintf("%s/pipelines/%d/cancel", projectPath(g.resolve(project)), pipelineID))
+}
+
+// DeletePipeline deletes a pipeline with its jobs and logs
+func (g *GlabWrapper) DeletePipeline(ctx context.Context, project string, pipelineID int) error {
+	path := fmt.Sprintf("%s/pipelines/%d", projectPath(g.resolve(project)), pipelineID)
+	_, err := g.run(ctx, fmt.Sprintf("delete pipeline %d", pipelineID), "api", "--method", "DELETE", path)
+	return err
+}
+
+// RetryJob reruns a job and returns the new job
+func (g *GlabWrapper) RetryJob(ctx context.Context, project string, jobID int) (*core.Job, error) {
+	return g.jobAction(ctx, fmt.Sprintf("retry job %d", jobID), fmt.Sprintf("%s/jobs/%d/retry", projectPath(g.resolve(project)), jobID), nil)
+}
+
+// CancelJob cancels a running or pending job
+func (g *GlabWrapper) CancelJob(ctx context.Context, project string, jobID int) (*core.Job, error) {
+	return g.jobAction(ctx, fmt.Sprintf("cancel job %d", jobID), fmt.Sprintf("%s/jobs/%d/cancel", projectPath(g.resolve(project)), jobID), nil)
+}
+
+// PlayJob starts a manual job with optional variables
+func (g *GlabWrapper) PlayJob(ctx context.Context, project string, jobID int, variables []core.JobVariable) (*core.Job, error) {
+	var body interface{}
+	if len(variables) > 0 {
+		body = api.NewPlayJobRequest(variables)
+	}
+	return g.jobAction(ctx, fmt.Sprintf("play job %d", jobID), fmt.Sprintf("%s/jobs/%d/play", projectPath(g.resolve(project)), jobID), body)
+}
+
+func (g *GlabWrapper) pipelineAction(ctx context.Context, op, path string) (*core.Pipeline, error) {
+	var p api.Pipeline
+	if err := g.post(ctx, op, path, nil, &p); err != nil {
+		return nil, err
+	}
+	result := p.ToCore()
+	return &result, nil
+}
+
+func (g *GlabWrapper) jobAction(ctx context.Context, op, path string, body interface{}) (*core.Job, error) {
+	var j api.Job
+	if err := g.post(ctx, op, path, body, &j); err != nil {
+		return nil, err
+	}
+	result := j.ToCore()
+	return &result, nil
+}
+
+// resolve falls back to the wrapper's project when none is given
+func (g *GlabWrapper) resolve(project string) string {
+	if project == "" {
+		return g.projectPath
+	}
+	return project
+}
+
+// api calls a REST endpoint through 'glab api' and decodes the JSON response
+func (g *GlabWrapper) api(ctx context.Context, op, path string, out interface{}) error {
+	output, err := g.run(ctx, op, "api", path)
 	if err != nil {
-		return "", fmt.Errorf("failed to get job status: %w", err)
+		return err
+	}
+
+	if err := json.Unmarshal(output, out); err != nil {
+		return core.WrapError(op, fmt.Errorf("failed to parse glab output: %w", err))
 	}
+	return nil
+}
 
-	// Parse JSON to extract status
-	jobData := string(output)
+// post sends a POST through 'glab api', with a JSON body on stdin unless
+// body is nil, and decodes the JSON response
+func (g *GlabWrapper) post(ctx context.Context, op, path string, body, out interface{}) error {
+	args := []string{"api", "--method", "POST", path}
+	var input io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return core.WrapError(op, fmt.Errorf("failed to encode request: %w", err))
+		}
+		args = append(args, "--header", "Content-Type: application/json", "--input", "-")
+		input = bytes.NewReader(payload)
+	}
 
-	// Simple JSON parsing to extract status field
-	// Look for "status":"value" pattern
-	statusStart := strings.Index(jobData, `"status":"`)
-	if statusStart == -1 {
-		return "unknown", nil
+	output, err := g.runInput(ctx, op, input, args...)
+	if err != nil {
+		return err
 	}
 
-	statusStart += len(`"status":"`)
-	statusEnd := strings.Index(jobData[statusStart:], `"`)
-	if statusEnd == -1 {
-		return "unknown", nil
+	if err := json.Unmarshal(output, out); err != nil {
+		return core.WrapError(op, fmt.Errorf("failed to parse glab output: %w", err))
 	}
+	return nil
+}
 
-	status := jobData[statusStart : statusStart+statusEnd]
-	return status, nil
+// apiList pages through a list endpoint with 'glab api', stopping at the
+// first short page or once limit items have been collected (limit <= 0
+// fetches everything). glab's own --paginate can't stop early, so the page
+// loop is done here.
+func apiList[T any](ctx context.Context, g *GlabWrapper, op, path string, query url.Values, perPage, limit int) ([]T, error) {
+	return apiListUntil[T](ctx, g, op, path, query, perPage, limit, nil)
 }
-func ParseGlabPipelineList(output string) ([]core.Pipeline, error) {
-	lines := strings.Split(output, "\n")
-	var pipelines []core.Pipeline
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "Showing") || strings.HasPrefix(line, "State") {
-			continue
+// apiListUntil is apiList that also stops at the first item for which stop
+// returns true, leaving it out. A nil stop never stops.
+func apiListUntil[T any](ctx context.Context, g *GlabWrapper, op, path string, query url.Values, perPage, limit int, stop func(T) bool) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	perPage = core.PageSize(perPage, limit)
+	query.Set("per_page", strconv.Itoa(perPage))
+
+	var items []T
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+
+		var batch []T
+		if err := g.api(ctx, op, path+"?"+query.Encode(), &batch); err != nil {
+			return nil, err
 		}
 
-		// Parse line like: "(running) • #1996941196	(#6785)	feat/zap-c3	(about 4 minutes ago)"
-		if strings.Contains(line, "•") && strings.Contains(line, "#") {
-			parts := strings.Split(line, "\t")
-			if len(parts) >= 3 {
-				// Extract status
-				status := "unknown"
-				if strings.Contains(parts[0], "(") && strings.Contains(parts[0], ")") {
-					start := strings.Index(parts[0], "(") + 1
-					end := strings.Index(parts[0], ")")
-					if end > start {
-						status = parts[0][start:end]
-					}
-				}
-
-				// Extract pipeline ID
-				pipelineID := 0
-				if strings.Contains(parts[0], "#") {
-					idStr := strings.Split(parts[0], "#")[1]
-					idStr = strings.TrimSpace(idStr)
-					if id, err := strconv.Atoi(idStr); err == nil {
-						pipelineID = id
-					}
-				}
-
-				// Extract ref
-				ref := strings.TrimSpace(parts[2])
-
-				if pipelineID > 0 {
-					pipelines = append(pipelines, core.Pipeline{
-						ID:          pipelineID,
-						Status:      status,
-						Ref:         ref,
-						ProjectName: "frontend-apps",
-						Jobs:        "loading...",
-					})
-				}
+		stopped := false
+		for i, item := range batch {
+			if stop != nil && stop(item) {
+				batch, stopped = batch[:i], true
+				break
 			}
 		}
+
+		items = append(items, batch...)
+		if limit > 0 && len(items) >= limit {
+			return items[:limit], nil
+		}
+		if stopped || len(batch) < perPage {
+			return items, nil
+		}
 	}
+}
 
-	return pipelines, nil
+// run executes glab and converts failures into structured core errors
+func (g *GlabWrapper) run(ctx context.Context, op string, args ...string) ([]byte, error) {
+	return g.runInput(ctx, op, nil, args...)
+}
+
+// runInput is run with stdin connected to input
+func (g *GlabWrapper) runInput(ctx context.Context, op string, input io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "glab", args...)
+	cmd.Stdin = input
+	output, err := cmd.Output()
+	if err == nil {
+		return output, nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil, commandError(op, err, exitErr.Stderr)
+	}
+	return nil, commandError(op, err, nil)
+}
+
+// runTo is run with the output streamed to w, for downloads
+func (g *GlabWrapper) runTo(ctx context.Context, op string, w io.Writer, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "glab", args...)
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return commandError(op, err, stderr.Bytes())
+	}
+	return nil
+}
+
+// commandError turns a failed glab command into an API error when its
+// stderr names the HTTP status
+func commandError(op string, err error, stderr []byte) error {
+	message := strings.TrimSpace(string(stderr))
+	if match := httpStatusPattern.FindStringSubmatch(message); match != nil {
+		status, _ := strconv.Atoi(match[1])
+		return core.NewAPIError(op, status, message)
+	}
+	if message != "" {
+		return core.WrapError(op, fmt.Errorf("glab: %s", message))
+	}
+	return core.WrapError(op, fmt.Errorf("failed to run glab command: %w", err))
+}
+
+// projectPath builds the projects/:id API path for a project path or ID
+func projectPath(project string) string {
+	return "projects/" + url.PathEscape(project)
 }
@@ -0,0 +1,356 @@
+package joblog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// tabWidth is where tab stops are, like in a terminal
+const tabWidth = 8
+
+// Style is how a span of log text looks. Colors are ANSI color numbers, "0"
+// to "255", or "#rrggbb"; empty is the terminal's default.
+type Style struct {
+	Foreground string
+	Background string
+	Bold       bool
+	Faint      bool
+	Italic     bool
+	Underline  bool
+	Reverse    bool
+}
+
+// Span is a run of text in one style
+type Span struct {
+	Text  string
+	Style Style
+}
+
+// Line is a log line with its escape sequences interpreted
+type Line struct {
+	Text  string // What a terminal would show, without styles
+	Spans []Span // Text split by style
+}
+
+// Parse splits a job trace into lines the way a terminal shows them. Colors
+// and text attributes become styled spans, carriage returns and cursor
+// movement overwrite text within a line, and every other escape sequence is
+// dropped. There is one line per "\n" separated line of the trace. GitLab's
+// section markers become the sections of the log.
+func Parse(trace string) Log {
+	var t Trace
+	t.Append(trace)
+	return t.Log()
+}
+
+// Width returns how many columns the line takes on a terminal
+func (l Line) Width() int {
+	return ansi.StringWidth(l.Text)
+}
+
+// Cut returns the part of the line from column left up to column right,
+// like a terminal scrolled sideways or one row of the line wrapped
+func (l Line) Cut(left, right int) Line {
+	var out Line
+	col := 0
+	for _, span := range l.Spans {
+		if col >= right {
+			break
+		}
+		width := ansi.StringWidth(span.Text)
+		if col+width > left {
+			text := ansi.Cut(span.Text, max(left-col, 0), min(right-col, width))
+			out.Spans = append(out.Spans, Span{Text: text, Style: span.Style})
+			out.Text += text
+		}
+		col += width
+	}
+	return out
+}
+
+// Highlight returns the line with byte ranges of its Text, e.g. search
+// matches, drawn in style instead of their own. Ranges must be sorted and
+// must not overlap.
+func (l Line) Highlight(ranges [][]int, style Style) Line {
+	if len(ranges) == 0 {
+		return l
+	}
+
+	out := Line{Text: l.Text}
+	r, start := 0, 0
+	for _, span := range l.Spans {
+		end := start + len(span.Text)
+		for pos := start; pos < end; {
+			for r < len(ranges) && ranges[r][1] <= pos {
+				r++
+			}
+			switch {
+			case r == len(ranges) || ranges[r][0] >= end:
+				out.Spans = append(out.Spans, Span{Text: span.Text[pos-start:], Style: span.Style})
+				pos = end
+			case ranges[r][0] > pos:
+				out.Spans = append(out.Spans, Span{Text: span.Text[pos-start : ranges[r][0]-start], Style: span.Style})
+				pos = ranges[r][0]
+			default:
+				stop := min(ranges[r][1], end)
+				out.Spans = append(out.Spans, Span{Text: span.Text[pos-start : stop-start], Style: style})
+				pos = stop
+			}
+		}
+		start = end
+	}
+	return out
+}
+
+// Parser interprets a trace line by line. Styles and sections carry over
+// from one line to the next, so lines must be given in order.
+type Parser struct {
+	style    Style
+	line     int       // Index of the next line
+	sections []Section // Found so far
+	open     []int     // Sections not ended yet, innermost last
+}
+
+// clone returns a parser that continues from the same state without
+// changing this one
+func (p Parser) clone() Parser {
+	p.sections = append([]Section(nil), p.sections...)
+	p.open = append([]int(nil), p.open...)
+	return p
+}
+
+// cell is a character on the screen
+type cell struct {
+	r     rune
+	style Style
+}
+
+// screenLine is the line a terminal draws while the escapes are applied
+type screenLine struct {
+	cells []cell
+	col   int
+}
+
+// put writes a character at the cursor, padding with blanks if the cursor
+// was moved past the end
+func (s *screenLine) put(r rune, style Style) {
+	for len(s.cells) < s.col {
+		s.cells = append(s.cells, cell{r: ' '})
+	}
+	if s.col < len(s.cells) {
+		s.cells[s.col] = cell{r: r, style: style}
+	} else {
+		s.cells = append(s.cells, cell{r: r, style: style})
+	}
+	s.col++
+}
+
+// erase clears part of the line without moving the cursor: from the cursor
+// to the end (0), from the start to the cursor (1) or all of it (2)
+func (s *screenLine) erase(mode int) {
+	switch mode {
+	case 0:
+		if s.col < len(s.cells) {
+			s.cells = s.cells[:s.col]
+		}
+	case 1:
+		for i := 0; i <= s.col && i < len(s.cells); i++ {
+			s.cells[i] = cell{r: ' '}
+		}
+	case 2:
+		s.cells = s.cells[:0]
+	}
+}
+
+// Line interprets one line of a trace, without its "\n"
+func (p *Parser) Line(raw string) Line {
+	raw, markers := stripMarkers(raw)
+	s := screenLine{cells: make([]cell, 0, len(raw))}
+	for i := 0; i < len(raw); {
+		switch c := raw[i]; {
+		case c == '\x1b':
+			i = p.escape(raw, i, &s)
+			continue
+		case c == '\r':
+			s.col = 0
+		case c == '\b':
+			s.col = max(s.col-1, 0)
+		case c == '\t':
+			for next := (s.col/tabWidth + 1) * tabWidth; s.col < next; {
+				s.put(' ', p.style)
+			}
+		case c < 0x20 || c == 0x7f:
+			// Other control characters don't print
+		default:
+			r, size := utf8.DecodeRuneInString(raw[i:])
+			s.put(r, p.style)
+			i += size
+			continue
+		}
+		i++
+	}
+
+	line := s.line()
+	p.applyMarkers(markers, line)
+	p.line++
+	return line
+}
+
+// line merges the cells into spans of the same style
+func (s *screenLine) line() Line {
+	var line Line
+	var text, span strings.Builder
+	for i, c := range s.cells {
+		if i > 0 && c.style != s.cells[i-1].style {
+			line.Spans = append(line.Spans, Span{Text: span.String(), Style: s.cells[i-1].style})
+			span.Reset()
+		}
+		span.WriteRune(c.r)
+		text.WriteRune(c.r)
+	}
+	if span.Len() > 0 {
+		line.Spans = append(line.Spans, Span{Text: span.String(), Style: s.cells[len(s.cells)-1].style})
+	}
+	line.Text = text.String()
+	return line
+}
+
+// escape applies the escape sequence starting at raw[i] and returns the
+// index after it. Sequences cut off at the end of the line are dropped.
+func (p *Parser) escape(raw string, i int, s *screenLine) int {
+	if i+1 >= len(raw) {
+		return len(raw)
+	}
+	switch raw[i+1] {
+	case '[':
+		// CSI: parameters, intermediates, then a final byte
+		j := i + 2
+		for j < len(raw) && raw[j] >= 0x20 && raw[j] <= 0x3f {
+			j++
+		}
+		if j >= len(raw) {
+			return len(raw)
+		}
+		p.csi(raw[i+2:j], raw[j], s)
+		return j + 1
+	case ']':
+		// OSC, e.g. a window title or hyperlink: ends with BEL or ESC \
+		for j := i + 2; j < len(raw); j++ {
+			if raw[j] == '\a' {
+				return j + 1
+			}
+			if raw[j] == '\x1b' && j+1 < len(raw) && raw[j+1] == '\\' {
+				return j + 2
+			}
+		}
+		return len(raw)
+	case '(', ')', '*', '+':
+		// Character set selection takes one more byte
+		return min(i+3, len(raw))
+	}
+	return i + 2
+}
+
+// csi applies a control sequence with its parameters
+func (p *Parser) csi(params string, final byte, s *screenLine) {
+	switch final {
+	case 'm':
+		p.style = sgr(p.style, params)
+	case 'K':
+		s.erase(param(params, 0))
+	case 'G':
+		s.col = max(param(params, 1)-1, 0)
+	case 'C':
+		s.col += max(param(params, 1), 1)
+	case 'D':
+		s.col = max(s.col-max(param(params, 1), 1), 0)
+	}
+	// Moving to other lines or clearing the screen can't be shown in a log
+}
+
+// param returns the first numeric parameter of a control sequence
+func param(params string, fallback int) int {
+	first, _, _ := strings.Cut(params, ";")
+	if n, err := strconv.Atoi(first); err == nil {
+		return n
+	}
+	return fallback
+}
+
+// sgr applies Select Graphic Rendition parameters to a style
+func sgr(style Style, params string) Style {
+	codes := strings.FieldsFunc(params, func(r rune) bool { return r == ';' || r == ':' })
+	if len(codes) == 0 {
+		return Style{}
+	}
+	nums := make([]int, len(codes))
+	for i, code := range codes {
+		nums[i], _ = strconv.Atoi(code)
+	}
+
+	for i := 0; i < len(nums); i++ {
+		switch n := nums[i]; {
+		case n == 0:
+			style = Style{}
+		case n == 1:
+			style.Bold = true
+		case n == 2:
+			style.Faint = true
+		case n == 3:
+			style.Italic = true
+		case n == 4:
+			style.Underline = true
+		case n == 7:
+			style.Reverse = true
+		case n == 22:
+			style.Bold, style.Faint = false, false
+		case n == 23:
+			style.Italic = false
+		case n == 24:
+			style.Underline = false
+		case n == 27:
+			style.Reverse = false
+		case n >= 30 && n <= 37:
+			style.Foreground = strconv.Itoa(n - 30)
+		case n == 39:
+			style.Foreground = ""
+		case n >= 40 && n <= 47:
+			style.Background = strconv.Itoa(n - 40)
+		case n == 49:
+			style.Background = ""
+		case n >= 90 && n <= 97:
+			style.Foreground = strconv.Itoa(n - 90 + 8)
+		case n >= 100 && n <= 107:
+			style.Background = strconv.Itoa(n - 100 + 8)
+		case n == 38 || n == 48:
+			color, used := extendedColor(nums[i+1:])
+			i += used
+			if n == 38 {
+				style.Foreground = color
+			} else {
+				style.Background = color
+			}
+		}
+	}
+	return style
+}
+
+// extendedColor reads a 256 color (5;n) or true color (2;r;g;b) and how many
+// parameters it used
+func extendedColor(nums []int) (string, int) {
+	switch {
+	case len(nums) >= 2 && nums[0] == 5:
+		return strconv.Itoa(min(max(nums[1], 0), 255)), 2
+	case len(nums) >= 4 && nums[0] == 2:
+		return fmt.Sprintf("#%02x%02x%02x", byteValue(nums[1]), byteValue(nums[2]), byteValue(nums[3])), 4
+	}
+	return "", len(nums)
+}
+
+func byteValue(n int) int {
+	return min(max(n, 0), 255)
+}
@@ -0,0 +1,132 @@
+package joblog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSGR(t *testing.T) {
+	bold := Style{Bold: true}
+	red := Style{Foreground: "1"}
+
+	tests := []struct {
+		name   string
+		style  Style
+		params string
+		want   Style
+	}{
+		{"empty resets", Style{Bold: true, Foreground: "1"}, "", Style{}},
+		{"zero resets", Style{Bold: true, Foreground: "1"}, "0", Style{}},
+		{"bold", Style{}, "1", bold},
+		{"bold off", Style{Bold: true, Faint: true}, "22", Style{}},
+		{"foreground", Style{}, "31", red},
+		{"background", Style{}, "42", Style{Background: "2"}},
+		{"bright colors", Style{}, "91;104", Style{Foreground: "9", Background: "12"}},
+		{"default colors", Style{Foreground: "1", Background: "2"}, "39;49", Style{}},
+		{"several at once", Style{}, "1;4;31", Style{Bold: true, Underline: true, Foreground: "1"}},
+		{"reset then set", bold, "0;31", red},
+		{"256 colors", Style{}, "38;5;208", Style{Foreground: "208"}},
+		{"256 background", Style{}, "48;5;17", Style{Background: "17"}},
+		{"256 colors clamped", Style{}, "38;5;300", Style{Foreground: "255"}},
+		{"true color", Style{}, "38;2;255;128;0", Style{Foreground: "#ff8000"}},
+		{"true color with colons", Style{}, "48:2:0:0:255", Style{Background: "#0000ff"}},
+		{"true color then bold", Style{}, "38;2;1;2;3;1", Style{Foreground: "#010203", Bold: true}},
+		{"extended color cut off", red, "38;5", Style{}},
+		{"unknown codes", red, "5;53;75", red},
+		{"unknown between known", Style{}, "1;5;31", Style{Bold: true, Foreground: "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sgr(tt.style, tt.params); got != tt.want {
+				t.Errorf("sgr(%+v, %q) = %+v, want %+v", tt.style, tt.params, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParserLine(t *testing.T) {
+	red := Style{Foreground: "1"}
+
+	tests := []struct {
+		name string
+		raw  string
+		want Line
+	}{
+		{"plain", "hello", Line{Text: "hello", Spans: []Span{{Text: "hello"}}}},
+		{"empty", "", Line{}},
+		{
+			"colored word",
+			"a \x1b[31mred\x1b[0m b",
+			Line{Text: "a red b", Spans: []Span{{Text: "a "}, {Text: "red", Style: red}, {Text: " b"}}},
+		},
+		{
+			"reset without parameters",
+			"\x1b[1mbold\x1b[m plain",
+			Line{Text: "bold plain", Spans: []Span{{Text: "bold", Style: Style{Bold: true}}, {Text: " plain"}}},
+		},
+		{"truncated escape at the end", "abc\x1b[31", Line{Text: "abc", Spans: []Span{{Text: "abc"}}}},
+		{"lone escape at the end", "abc\x1b", Line{Text: "abc", Spans: []Span{{Text: "abc"}}}},
+		{"carriage return overwrites", "12345\rab", Line{Text: "ab345", Spans: []Span{{Text: "ab345"}}}},
+		{
+			"progress redrawn with erase",
+			"Downloading 10%\r\x1b[KDone",
+			Line{Text: "Done", Spans: []Span{{Text: "Done"}}},
+		},
+		{
+			"overwrite keeps the new style",
+			"xyz\r\x1b[31mA",
+			Line{Text: "Ayz", Spans: []Span{{Text: "A", Style: red}, {Text: "yz"}}},
+		},
+		{"tab stops", "a\tb", Line{Text: "a       b", Spans: []Span{{Text: "a       b"}}}},
+		{"hyperlink dropped", "\x1b]8;;http://x\x1b\\link\x1b]8;;\x07", Line{Text: "link", Spans: []Span{{Text: "link"}}}},
+		{"other controls dropped", "a\x07b", Line{Text: "ab", Spans: []Span{{Text: "ab"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Parser
+			if got := p.Line(tt.raw); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Line(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParserLine_StyleCarriesOver(t *testing.T) {
+	var p Parser
+	p.Line("\x1b[32mgreen")
+	got := p.Line("still green\x1b[0m")
+	want := []Span{{Text: "still green", Style: Style{Foreground: "2"}}}
+	if !reflect.DeepEqual(got.Spans, want) {
+		t.Errorf("Spans = %+v, want %+v", got.Spans, want)
+	}
+}
+
+// texts returns the text of every line
+func texts(lines []Line) []string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = line.Text
+	}
+	return out
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		trace string
+		want  []string
+	}{
+		{"empty", "", []string{}},
+		{"no trailing newline", "a\nb", []string{"a", "b"}},
+		{"trailing newline", "a\nb\n", []string{"a", "b"}},
+		{"blank lines kept", "a\n\n\nb\n", []string{"a", "", "", "b"}},
+		{"only a newline", "\n", []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := texts(Parse(tt.trace).Lines); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %q, want %q", tt.trace, got, tt.want)
+			}
+		})
+	}
+}
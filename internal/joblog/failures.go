@@ -0,0 +1,111 @@
+package joblog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PatternSet is a named group of patterns for lines that report a failure
+type PatternSet struct {
+	Name     string
+	Patterns []string // Regular expressions matched against the text of a line
+}
+
+// PatternSets are the failure patterns known by name, all on by default
+var PatternSets = []PatternSet{
+	{Name: "generic", Patterns: []string{`\bERROR\b`, `\bFAIL(ED|URE)?\b`, `^(Error|error|fatal|FATAL):`}},
+	{Name: "npm", Patterns: []string{`^npm (ERR!|error) `}},
+	{Name: "go", Patterns: []string{`^\s*--- FAIL: `, `^FAIL\s`, `^panic: `}},
+	{Name: "jest", Patterns: []string{`^\s*FAIL\s+\S`, `^\s*● `, `^Tests:.*\b[1-9]\d* failed`}},
+	{Name: "exit", Patterns: []string{`exit (code|status) [1-9]\d*`, `returned a non-zero code`, `^make(\[\d+\])?: \*\*\* `}},
+}
+
+// Matcher finds the lines of a log that report failures. The patterns are
+// tried one by one, which is much faster on long logs than one pattern
+// joining them all.
+type Matcher struct {
+	patterns []*regexp.Regexp
+}
+
+// NewMatcher combines the named pattern sets, all of them if none are
+// named, with an optional pattern of its own. The set "none" turns the
+// others off, leaving only the own pattern.
+func NewMatcher(sets []string, extra string) (*Matcher, error) {
+	var patterns []*regexp.Regexp
+	add := func(exprs []string) {
+		for _, expr := range exprs {
+			patterns = append(patterns, regexp.MustCompile(expr))
+		}
+	}
+	if len(sets) == 0 {
+		for _, set := range PatternSets {
+			add(set.Patterns)
+		}
+	}
+	for _, name := range sets {
+		if name == "none" {
+			continue
+		}
+		set, ok := findPatternSet(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown log error pattern set %q, expected one of %s", name, strings.Join(patternSetNames(), ", "))
+		}
+		add(set.Patterns)
+	}
+	if extra != "" {
+		pattern, err := regexp.Compile(extra)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log error pattern: %w", err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return &Matcher{patterns: patterns}, nil
+}
+
+// DefaultMatcher uses every pattern set
+func DefaultMatcher() *Matcher {
+	m, _ := NewMatcher(nil, "")
+	return m
+}
+
+// Match reports whether the text of a line reports a failure
+func (m *Matcher) Match(text string) bool {
+	if m == nil {
+		return false
+	}
+	for _, pattern := range m.patterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// Failures returns the indexes of the lines that report failures
+func (m *Matcher) Failures(lines []Line) []int {
+	var failures []int
+	for i, line := range lines {
+		if m.Match(line.Text) {
+			failures = append(failures, i)
+		}
+	}
+	return failures
+}
+
+func findPatternSet(name string) (PatternSet, bool) {
+	for _, set := range PatternSets {
+		if set.Name == name {
+			return set, true
+		}
+	}
+	return PatternSet{}, false
+}
+
+func patternSetNames() []string {
+	names := make([]string, len(PatternSets))
+	for i, set := range PatternSets {
+		names[i] = set.Name
+	}
+	return names
+}
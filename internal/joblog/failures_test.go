@@ -0,0 +1,113 @@
+package joblog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPatternSets(t *testing.T) {
+	tests := []struct {
+		set  string
+		line string
+		want bool
+	}{
+		{"generic", "ERROR: job failed", true},
+		{"generic", "[ERROR] Failed to execute goal", true},
+		{"generic", "Build FAILED.", true},
+		{"generic", "FAILURE: Build failed with an exception.", true},
+		{"generic", "Error: Cannot find module 'x'", true},
+		{"generic", "error: failed to push some refs", true},
+		{"generic", "fatal: repository not found", true},
+		{"generic", "FATAL: password authentication failed", true},
+		{"npm", "npm ERR! code ELIFECYCLE", true},
+		{"npm", "npm error Missing script: \"lint\"", true},
+		{"go", "--- FAIL: TestParse (0.00s)", true},
+		{"go", "    --- FAIL: TestParse/empty (0.00s)", true},
+		{"go", "FAIL\tgithub.com/x/y\t0.012s", true},
+		{"go", "panic: runtime error: index out of range", true},
+		{"jest", "FAIL src/app.test.js", true},
+		{"jest", "  ● Suite › fails", true},
+		{"jest", "Tests:       2 failed, 10 passed, 12 total", true},
+		{"exit", "ERROR: Job failed: exit code 1", true},
+		{"exit", "Process exited with exit status 137", true},
+		{"exit", "The command '/bin/sh -c make' returned a non-zero code: 2", true},
+		{"exit", "make: *** [Makefile:12: build] Error 2", true},
+		{"exit", "make[1]: *** [all] Error 1", true},
+
+		// False positives
+		{"generic", "Compiled with 0 errors", false},
+		{"generic", "$ php -d error_reporting=E_ALL index.php", false},
+		{"generic", "$ echo \"LOG_LEVEL=ERROR_ONLY\"", false},
+		{"generic", "allow_failure: true", false},
+		{"generic", "Checking for errors: none", false},
+		{"npm", "$ npm run lint", false},
+		{"go", "ok  \tgithub.com/x/y\t0.012s", false},
+		{"go", "--- PASS: TestParse (0.00s)", false},
+		{"jest", "PASS src/app.test.js", false},
+		{"jest", "Tests:       0 failed, 12 passed, 12 total", false},
+		{"jest", "Tests:       10 failed, 2 passed, 12 total", true},
+		{"exit", "Cleaning up project directory: exit code 0", false},
+		{"exit", "make: Nothing to be done for 'all'.", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.set+"/"+tt.line, func(t *testing.T) {
+			m, err := NewMatcher([]string{tt.set}, "")
+			if err != nil {
+				t.Fatalf("NewMatcher() error = %v", err)
+			}
+			if got := m.Match(tt.line); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMatcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		sets    []string
+		extra   string
+		line    string
+		want    bool
+		wantErr bool
+	}{
+		{"all sets by default", nil, "", "npm ERR! code 1", true, false},
+		{"only the named set", []string{"go"}, "", "npm ERR! code 1", false, false},
+		{"own pattern", nil, `^Oops`, "Oops, something broke", true, false},
+		{"own pattern with a set", []string{"go"}, `^Oops`, "panic: boom", true, false},
+		{"none leaves only the own pattern", []string{"none"}, `^Oops`, "ERROR: x", false, false},
+		{"none without own pattern", []string{"none"}, "", "ERROR: x", false, false},
+		{"unknown set", []string{"maven"}, "", "", false, true},
+		{"own pattern does not compile", nil, `(unclosed`, "", false, true},
+		{"own pattern with bad repetition", nil, `*oops`, "", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMatcher(tt.sets, tt.extra)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewMatcher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if m != nil {
+					t.Errorf("NewMatcher() = %v with an error, want nil", m)
+				}
+				return
+			}
+			if got := m.Match(tt.line); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcherFailures(t *testing.T) {
+	lines := Parse("$ go test ./...\n--- FAIL: TestX\nok\n\x1b[31mERROR\x1b[0m: boom\n").Lines
+	if got, want := DefaultMatcher().Failures(lines), []int{1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Failures() = %v, want %v", got, want)
+	}
+
+	var none *Matcher
+	if none.Match("ERROR") {
+		t.Error("nil Matcher matched")
+	}
+}
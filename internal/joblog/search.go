@@ -0,0 +1,55 @@
+package joblog
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"unicode"
+)
+
+// CompileQuery turns a search query into a pattern for the text of lines.
+// The query is a regular expression if regex is set, plain text otherwise.
+// Case is ignored unless the query has an upper case letter, like smart case
+// in vim; the letters of escapes such as \S don't count. An invalid regular
+// expression, e.g. one still being typed, is searched for as plain text and
+// returned with its error.
+func CompileQuery(query string, regex bool) (*regexp.Regexp, error) {
+	if !regex {
+		return compileSmartCase(regexp.QuoteMeta(query))
+	}
+	pattern, err := compileSmartCase(query)
+	if err != nil {
+		pattern, _ = compileSmartCase(regexp.QuoteMeta(query))
+	}
+	return pattern, err
+}
+
+// compileSmartCase compiles a regular expression that ignores case unless
+// it has an upper case letter
+func compileSmartCase(expr string) (*regexp.Regexp, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	if !hasUpper(re) {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+// hasUpper reports whether a parsed expression matches an upper case letter
+// literally
+func hasUpper(re *syntax.Regexp) bool {
+	if re.Op == syntax.OpLiteral && re.Flags&syntax.FoldCase == 0 {
+		for _, r := range re.Rune {
+			if unicode.IsUpper(r) {
+				return true
+			}
+		}
+	}
+	for _, sub := range re.Sub {
+		if hasUpper(sub) {
+			return true
+		}
+	}
+	return false
+}
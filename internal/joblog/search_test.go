@@ -0,0 +1,47 @@
+package joblog
+
+import "testing"
+
+func TestCompileQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		regex     bool
+		matches   []string
+		unmatched []string
+		wantErr   bool
+	}{
+		{"plain text ignores case", "error", false, []string{"ERROR: x", "an Error"}, []string{"err"}, false},
+		{"capital makes it case sensitive", "Error", false, []string{"Error: x"}, []string{"ERROR: x", "error"}, false},
+		{"plain text is not a regex", "a.b", false, []string{"a.b"}, []string{"axb"}, false},
+		{"plain text with regex characters", "(x", false, []string{"f(x)"}, nil, false},
+		{"regex", `err(or)?\b`, true, []string{"ERR!", "errors are error"}, []string{"errand"}, false},
+		{"regex smart case", `^Fail`, true, []string{"Failed"}, []string{"failed", "FAILED"}, false},
+		{"escape letters don't count", `\S+ed\b`, true, []string{"FAILED", "passed"}, []string{"   "}, false},
+		{"escape with lower case text", `\Wtimeout\D`, true, []string{"a TIMEOUT!"}, nil, false},
+		{"escape with upper case text", `\sTimeout`, true, []string{" Timeout"}, []string{" timeout"}, false},
+		{"invalid regex searched as text", `fail(`, true, []string{"FAIL(x)"}, []string{"failed"}, true},
+		{"invalid regex keeps smart case", `Fail[`, true, []string{"Fail[0]"}, []string{"fail[0]"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := CompileQuery(tt.query, tt.regex)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompileQuery(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+			if pattern == nil {
+				t.Fatalf("CompileQuery(%q) returned no pattern", tt.query)
+			}
+			for _, text := range tt.matches {
+				if !pattern.MatchString(text) {
+					t.Errorf("%q doesn't match %q", tt.query, text)
+				}
+			}
+			for _, text := range tt.unmatched {
+				if pattern.MatchString(text) {
+					t.Errorf("%q matches %q", tt.query, text)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package joblog
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// markerPattern matches the section markers GitLab Runner writes into a
+// trace, e.g. "section_start:1700000000:get_sources[collapsed=true]\r\x1b[0K".
+// The carriage return and erase make terminals hide them.
+var markerPattern = regexp.MustCompile(`section_(start|end):(\d+):([A-Za-z0-9_.-]+)(?:\[([^\]]*)\])?\r?(?:\x1b\[0K)?`)
+
+// boilerplate are the sections the runner puts around the job's own script
+var boilerplate = map[string]bool{
+	"resolve_secrets":             true,
+	"prepare_executor":            true,
+	"prepare_script":              true,
+	"get_sources":                 true,
+	"restore_cache":               true,
+	"download_artifacts":          true,
+	"archive_cache":               true,
+	"archive_cache_on_failure":    true,
+	"upload_artifacts_on_success": true,
+	"upload_artifacts_on_failure": true,
+	"cleanup_file_variables":      true,
+}
+
+// Log is a parsed job trace
+type Log struct {
+	Lines    []Line
+	Sections []Section // In the order they start, so parents before their children
+}
+
+// Section is a collapsible part of a job log, like "Getting source from Git
+// repository" on the job page
+type Section struct {
+	Name      string        // e.g. "get_sources" or "step_script"
+	Header    int           // Line the section starts on, which shows its title
+	End       int           // Last line of the section, -1 while it is still open
+	Depth     int           // How many sections it is nested in
+	Started   time.Time     // From the start marker
+	Duration  time.Duration // Between the markers, 0 while it is still open
+	Collapsed bool          // The job asked for it to start collapsed
+}
+
+// Open reports whether the section hasn't ended yet, e.g. while the job runs
+func (s Section) Open() bool {
+	return s.End < 0
+}
+
+// Last returns the last line of the section in a log of n lines
+func (s Section) Last(n int) int {
+	if s.Open() {
+		return n - 1
+	}
+	return s.End
+}
+
+// StartsCollapsed reports whether the section is hidden until expanded:
+// runner boilerplate and sections the job marked collapsed
+func (s Section) StartsCollapsed() bool {
+	return s.Collapsed || boilerplate[s.Name]
+}
+
+// Sections returns the sections found in the lines parsed so far
+func (p *Parser) Sections() []Section {
+	return p.sections
+}
+
+// stripMarkers removes the section markers from a raw trace line
+func stripMarkers(raw string) (string, [][]string) {
+	if !strings.Contains(raw, "section_") {
+		return raw, nil
+	}
+	markers := markerPattern.FindAllStringSubmatch(raw, -1)
+	if markers == nil {
+		return raw, nil
+	}
+	return markerPattern.ReplaceAllString(raw, ""), markers
+}
+
+// applyMarkers opens and closes sections for the markers of the line just
+// parsed. A section ends on the line before its end marker when the marker
+// shares its line with text, usually the title of the next section.
+func (p *Parser) applyMarkers(markers [][]string, line Line) {
+	for _, marker := range markers {
+		unix, _ := strconv.ParseInt(marker[2], 10, 64)
+		at := time.Unix(unix, 0)
+		name := marker[3]
+
+		if marker[1] == "start" {
+			p.open = append(p.open, len(p.sections))
+			p.sections = append(p.sections, Section{
+				Name:      name,
+				Header:    p.line,
+				End:       -1,
+				Depth:     len(p.open) - 1,
+				Started:   at,
+				Collapsed: strings.Contains(marker[4], "collapsed=true"),
+			})
+			continue
+		}
+
+		// Close the section and any nested one left open inside it
+		for i := len(p.open) - 1; i >= 0; i-- {
+			if p.sections[p.open[i]].Name != name {
+				continue
+			}
+			end := p.line
+			if line.Text != "" {
+				end--
+			}
+			for _, open := range p.open[i:] {
+				s := &p.sections[open]
+				s.End = max(end, s.Header)
+				s.Duration = at.Sub(s.Started)
+			}
+			p.open = p.open[:i]
+			break
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package joblog
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// start and end write GitLab Runner's section markers
+func start(at int, name string) string {
+	return "section_start:" + strconv.Itoa(at) + ":" + name + "\r\x1b[0K"
+}
+
+func end(at int, name string) string {
+	return "section_end:" + strconv.Itoa(at) + ":" + name + "\r\x1b[0K"
+}
+
+// sectionSummary is what the tests check of a section
+type sectionSummary struct {
+	Name      string
+	Header    int
+	End       int
+	Depth     int
+	Duration  time.Duration
+	Collapsed bool
+}
+
+func summarize(sections []Section) []sectionSummary {
+	out := make([]sectionSummary, len(sections))
+	for i, s := range sections {
+		out[i] = sectionSummary{s.Name, s.Header, s.End, s.Depth, s.Duration, s.Collapsed}
+	}
+	return out
+}
+
+func TestSections(t *testing.T) {
+	tests := []struct {
+		name      string
+		trace     string
+		wantLines []string
+		want      []sectionSummary
+	}{
+		{
+			"one section",
+			start(1, "build") + "Building\nstep\n" + end(3, "build") + "\n",
+			[]string{"Building", "step", ""},
+			[]sectionSummary{{"build", 0, 2, 0, 2 * time.Second, false}},
+		},
+		{
+			"end shares its line with the next title",
+			start(1, "a") + "A\nwork\n" + end(2, "a") + start(2, "b") + "B\n" + end(4, "b") + "\n",
+			[]string{"A", "work", "B", ""},
+			[]sectionSummary{
+				{"a", 0, 1, 0, time.Second, false},
+				{"b", 2, 3, 0, 2 * time.Second, false},
+			},
+		},
+		{
+			"nested",
+			start(1, "outer") + "Outer\n" + start(2, "inner") + "Inner\nx\n" + end(3, "inner") + "\ny\n" + end(5, "outer") + "\n",
+			[]string{"Outer", "Inner", "x", "", "y", ""},
+			[]sectionSummary{
+				{"outer", 0, 5, 0, 4 * time.Second, false},
+				{"inner", 1, 3, 1, time.Second, false},
+			},
+		},
+		{
+			"outer end closes an inner section left open",
+			start(1, "outer") + "Outer\n" + start(2, "inner") + "Inner\n" + end(4, "outer") + "\n",
+			[]string{"Outer", "Inner", ""},
+			[]sectionSummary{
+				{"outer", 0, 2, 0, 3 * time.Second, false},
+				{"inner", 1, 2, 1, 2 * time.Second, false},
+			},
+		},
+		{
+			"collapsed option",
+			"section_start:1:deps[collapsed=true]\r\x1b[0KDeps\n" + end(2, "deps") + "\n",
+			[]string{"Deps", ""},
+			[]sectionSummary{{"deps", 0, 1, 0, time.Second, true}},
+		},
+		{
+			"other options",
+			"section_start:1:deps[hide_duration=true]\r\x1b[0KDeps\n" + end(2, "deps") + "\n",
+			[]string{"Deps", ""},
+			[]sectionSummary{{"deps", 0, 1, 0, time.Second, false}},
+		},
+		{
+			"missing end while the job runs",
+			start(1, "script") + "Running\nstill going\n",
+			[]string{"Running", "still going"},
+			[]sectionSummary{{"script", 0, -1, 0, 0, false}},
+		},
+		{
+			"end without a start",
+			"line\n" + end(2, "nothing") + "\n",
+			[]string{"line", ""},
+			[]sectionSummary{},
+		},
+		{
+			"end of another section",
+			start(1, "a") + "A\n" + end(2, "b") + "\n",
+			[]string{"A", ""},
+			[]sectionSummary{{"a", 0, -1, 0, 0, false}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := Parse(tt.trace)
+			if got := texts(log.Lines); !reflect.DeepEqual(got, tt.wantLines) {
+				t.Errorf("lines = %q, want %q", got, tt.wantLines)
+			}
+			if got := summarize(log.Sections); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sections = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSections_MarkersSplitAcrossChunks(t *testing.T) {
+	trace := start(1, "build") + "Build\nok\n" + end(3, "build") + "after\n"
+	want := Parse(trace)
+	for _, split := range []int{5, 20, len(start(1, "build")) - 1, len(start(1, "build")) + 8, len(trace) - 10} {
+		var got Trace
+		got.Append(trace[:split])
+		got.Append(trace[split:])
+		if !reflect.DeepEqual(got.Log(), want) {
+			t.Errorf("split at %d: got %+v, want %+v", split, got.Log(), want)
+		}
+	}
+
+	wantSections := []sectionSummary{{"build", 0, 1, 0, 2 * time.Second, false}}
+	if got := summarize(want.Sections); !reflect.DeepEqual(got, wantSections) {
+		t.Errorf("sections = %+v, want %+v", got, wantSections)
+	}
+}
+
+func TestSections_OpenSectionEndsInLaterChunk(t *testing.T) {
+	var trace Trace
+	trace.Append(start(1, "script") + "Running\n")
+	if s := trace.Log().Sections[0]; !s.Open() || s.Last(len(trace.Log().Lines)) != 0 {
+		t.Fatalf("section = %+v, want open up to line 0", s)
+	}
+	trace.Append("done\n" + end(5, "script") + "\n")
+	if s := trace.Log().Sections[0]; s.Open() || s.End != 2 || s.Duration != 4*time.Second {
+		t.Errorf("section = %+v, want ended on line 2 after 4s", s)
+	}
+}
+
+func TestSectionStartsCollapsed(t *testing.T) {
+	tests := []struct {
+		section Section
+		want    bool
+	}{
+		{Section{Name: "get_sources"}, true},
+		{Section{Name: "step_script"}, false},
+		{Section{Name: "custom", Collapsed: true}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.section.StartsCollapsed(); got != tt.want {
+			t.Errorf("%+v.StartsCollapsed() = %v, want %v", tt.section, got, tt.want)
+		}
+	}
+}
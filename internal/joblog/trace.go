@@ -0,0 +1,43 @@
+package joblog
+
+import "strings"
+
+// Trace parses a job trace as it grows. Appending parses only the new text
+// and the last line, which may have been incomplete, so following a long
+// trace doesn't parse it from the start for every chunk.
+type Trace struct {
+	lines    []Line
+	sections []Section
+	partial  string // Raw text of the last line, without its "\n" yet
+	parser   Parser // State after the last complete line
+}
+
+// Append parses more of the trace and returns the first line that changed.
+// Lines before it are as they were.
+func (t *Trace) Append(data string) int {
+	first := len(t.lines)
+	if t.partial != "" {
+		first--
+	}
+	raw := strings.Split(t.partial+data, "\n")
+	t.lines = t.lines[:first]
+	for _, line := range raw[:len(raw)-1] {
+		t.lines = append(t.lines, t.parser.Line(line))
+	}
+
+	// The last line may continue in the next chunk, so parse it on a copy
+	// of the parser that is thrown away. A trace ending in "\n" has no
+	// last line yet.
+	t.partial = raw[len(raw)-1]
+	p := t.parser.clone()
+	if t.partial != "" {
+		t.lines = append(t.lines, p.Line(t.partial))
+	}
+	t.sections = p.Sections()
+	return first
+}
+
+// Log returns the trace parsed so far
+func (t *Trace) Log() Log {
+	return Log{Lines: t.lines, Sections: t.sections}
+}
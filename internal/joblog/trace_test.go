@@ -0,0 +1,64 @@
+package joblog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTraceAppend(t *testing.T) {
+	tests := []struct {
+		name      string
+		chunks    []string
+		wantLines []string
+		wantFirst []int // Return of each Append
+	}{
+		{"one chunk", []string{"a\nb\n"}, []string{"a", "b"}, []int{0}},
+		{"no trailing newline", []string{"a\nb"}, []string{"a", "b"}, []int{0}},
+		{"empty", []string{""}, []string{}, []int{0}},
+		{"line split across chunks", []string{"a\nhel", "lo\nb\n"}, []string{"a", "hello", "b"}, []int{0, 1}},
+		{"chunks ending in newlines", []string{"a\n", "b\n"}, []string{"a", "b"}, []int{0, 1}},
+		{"newline on its own", []string{"a", "\n", "b"}, []string{"a", "b"}, []int{0, 0, 1}},
+		{"blank lines kept", []string{"a\n\n", "\nb\n"}, []string{"a", "", "", "b"}, []int{0, 2}},
+		{"escape split across chunks", []string{"x\x1b[3", "1mred\n"}, []string{"xred"}, []int{0, 0}},
+		{"overwrite in a later chunk", []string{"50%", "\r100%\n"}, []string{"100%"}, []int{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var trace Trace
+			var firsts []int
+			for _, chunk := range tt.chunks {
+				firsts = append(firsts, trace.Append(chunk))
+			}
+			if got := texts(trace.Log().Lines); !reflect.DeepEqual(got, tt.wantLines) {
+				t.Errorf("lines = %q, want %q", got, tt.wantLines)
+			}
+			if !reflect.DeepEqual(firsts, tt.wantFirst) {
+				t.Errorf("Append returned %v, want %v", firsts, tt.wantFirst)
+			}
+		})
+	}
+}
+
+func TestTraceAppend_SameAsParse(t *testing.T) {
+	trace := "\x1b[32mstart\n\x1b[0mpro\rgress 50%\rprogress 100%\nsection_start:1:build\r\x1b[0Kbuild\nok\nsection_end:2:build\r\x1b[0K\n"
+	want := Parse(trace)
+	for size := 1; size <= len(trace); size++ {
+		var got Trace
+		for i := 0; i < len(trace); i += size {
+			got.Append(trace[i:min(i+size, len(trace))])
+		}
+		if !reflect.DeepEqual(got.Log(), want) {
+			t.Fatalf("chunks of %d bytes: got %+v, want %+v", size, got.Log(), want)
+		}
+	}
+}
+
+func TestTraceAppend_StyleAcrossChunks(t *testing.T) {
+	var trace Trace
+	trace.Append("\x1b[31mred\n")
+	trace.Append("still red\n")
+	want := []Span{{Text: "still red", Style: Style{Foreground: "1"}}}
+	if got := trace.Log().Lines[1].Spans; !reflect.DeepEqual(got, want) {
+		t.Errorf("Spans = %+v, want %+v", got, want)
+	}
+}